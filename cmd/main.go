@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 
 	"github.com/AjxGnx/contacts-go/cmd/providers"
 	"github.com/AjxGnx/contacts-go/config"
+	"github.com/AjxGnx/contacts-go/internal/app"
 	"github.com/AjxGnx/contacts-go/internal/infra/api/router"
 	"github.com/labstack/echo/v4"
 )
@@ -17,10 +20,20 @@ import (
 // @BasePath      /api
 // @schemes       http
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
 	container := providers.BuildContainer()
-	err := container.Invoke(func(router *router.Router, server *echo.Echo) {
+	err := container.Invoke(func(router *router.Router, server *echo.Echo, purger app.Purger) {
 		router.Init()
 
+		go purger.Run(context.Background())
+
 		server.Logger.Fatal(server.Start(fmt.Sprintf("%s:%v", config.Environments().ServerHost,
 			config.Environments().ServerPort)))
 	})
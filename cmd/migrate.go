@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/cmd/providers"
+	"github.com/AjxGnx/contacts-go/config"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg"
+)
+
+const migrateUsage = "usage: app migrate up | down [steps] | status"
+
+// migrate runs the migrate subcommand: up applies the pending migrations, down
+// reverts the last applied one, or the last steps ones, and status lists them.
+func migrate(args []string) error {
+	steps := 1
+
+	switch {
+	case len(args) == 1 && (args[0] == "up" || args[0] == "down" || args[0] == "status"):
+	case len(args) == 2 && args[0] == "down":
+		var err error
+		if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+			return errors.New(migrateUsage)
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	if driver := config.Environments().StorageDriver; driver != providers.StoragePostgres {
+		return fmt.Errorf("the %s storage has no migrations, its schema is built on start", driver)
+	}
+
+	if err := config.Environments().CheckPostgres(); err != nil {
+		return err
+	}
+
+	phones, err := dto.NewPhoneNormalizer(config.Environments().PhoneDefaultRegion)
+	if err != nil {
+		return err
+	}
+
+	migrator, err := pg.NewMigrator(pg.Open(), phones)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		printMigrations("applied", applied)
+
+		return err
+	case "down":
+		reverted, err := migrator.Down(steps)
+		printMigrations("reverted", reverted)
+
+		return err
+	default:
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		return printStatus(statuses)
+	}
+}
+
+func printMigrations(action string, migrations []pg.MigrationStatus) {
+	if len(migrations) == 0 {
+		fmt.Println("no migration " + action)
+	}
+
+	for _, migration := range migrations {
+		fmt.Printf("%s %06d_%s\n", action, migration.Version, migration.Name)
+	}
+}
+
+func printStatus(statuses []pg.MigrationStatus) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = status.AppliedAt.Format(time.RFC3339)
+		}
+
+		if status.Unknown {
+			applied += " (unknown to this build)"
+		}
+
+		fmt.Fprintf(writer, "%06d\t%s\t%s\n", status.Version, status.Name, applied)
+	}
+
+	return writer.Flush()
+}
@@ -1,14 +1,30 @@
 package providers
 
 import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/config"
 	"github.com/AjxGnx/contacts-go/internal/app"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/memory"
 	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg"
 	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/sqlite"
 	"github.com/AjxGnx/contacts-go/internal/infra/api/handler"
 	"github.com/AjxGnx/contacts-go/internal/infra/api/router"
 	"github.com/AjxGnx/contacts-go/internal/infra/api/router/group"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
 	"go.uber.org/dig"
+	"gorm.io/gorm"
+)
+
+// The storage drivers STORAGE_DRIVER can pick.
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
 )
 
 var Container *dig.Container
@@ -20,13 +36,112 @@ func BuildContainer() *dig.Container {
 		return echo.New()
 	})
 
+	_ = Container.Provide(func() dto.CursorCodec {
+		return dto.NewCursorCodec(config.Environments().CursorSecret)
+	})
+	_ = Container.Provide(func() (dto.PaginateConfig, error) {
+		maxLimit := config.Environments().MaxPageLimit
+		if maxLimit < dto.DefaultLimit {
+			return dto.PaginateConfig{}, fmt.Errorf(
+				"MAX_PAGE_LIMIT must be at least %d, the page size of requests without limit, got %d",
+				dto.DefaultLimit, maxLimit)
+		}
+
+		return dto.PaginateConfig{MaxLimit: maxLimit}, nil
+	})
+	_ = Container.Provide(func() dto.ImportConfig {
+		return dto.ImportConfig{MaxRows: config.Environments().ImportMaxRows}
+	})
+	_ = Container.Provide(func() dto.BatchConfig {
+		return dto.BatchConfig{MaxOperations: config.Environments().BatchMaxOperations}
+	})
+	_ = Container.Provide(func() (models.NameFormat, error) {
+		return models.ParseNameFormat(config.Environments().NameFormat)
+	})
+	_ = Container.Provide(func() app.TrashConfig {
+		return app.TrashConfig{
+			Retention:     config.Environments().TrashRetention,
+			PurgeInterval: config.Environments().TrashPurgeInterval,
+		}
+	})
+	_ = Container.Provide(func() app.IdempotencyConfig {
+		return app.IdempotencyConfig{
+			TTL:         config.Environments().IdempotencyTTL,
+			MaxSize:     config.Environments().IdempotencyMaxSize,
+			LockTimeout: config.Environments().IdempotencyLockTimeout,
+		}
+	})
+	_ = Container.Provide(func() (dto.PhoneNormalizer, error) {
+		return dto.NewPhoneNormalizer(config.Environments().PhoneDefaultRegion)
+	})
+
 	_ = Container.Provide(router.New)
-	_ = Container.Provide(pg.ConnInstance)
 
 	_ = Container.Provide(group.NewContacts)
 	_ = Container.Provide(handler.NewContacts)
 	_ = Container.Provide(app.NewContacts)
-	_ = Container.Provide(repository.NewContacts)
+	_ = Container.Provide(app.NewPurger)
+
+	_ = Container.Provide(group.NewTags)
+	_ = Container.Provide(handler.NewTags)
+	_ = Container.Provide(app.NewTags)
+
+	_ = Container.Provide(group.NewGroups)
+	_ = Container.Provide(handler.NewGroups)
+	_ = Container.Provide(app.NewGroups)
+
+	_ = Container.Provide(group.NewAudit)
+	_ = Container.Provide(handler.NewAudit)
+	_ = Container.Provide(app.NewAudit)
+
+	_ = Container.Provide(group.NewCardDAV)
+	_ = Container.Provide(handler.NewCardDAV)
+	_ = Container.Provide(app.NewAddressBook)
+
+	_ = Container.Provide(handler.NewIdempotency)
+	_ = Container.Provide(app.NewIdempotency)
+
+	switch driver := config.Environments().StorageDriver; driver {
+	case StoragePostgres:
+		if err := config.Environments().CheckPostgres(); err != nil {
+			log.Fatal(err)
+		}
+
+		provideRepositories(pg.ConnInstance)
+	case StorageSQLite:
+		provideRepositories(sqlite.ConnInstance)
+	case StorageMemory:
+		provideMemoryRepositories()
+	default:
+		log.Fatalf("unknown storage driver %q, use %s, %s or %s", driver, StoragePostgres, StorageSQLite,
+			StorageMemory)
+	}
 
 	return Container
 }
+
+// provideRepositories provides the repositories over the database connInstance
+// opens, Postgres and SQLite share them.
+func provideRepositories(connInstance func() *gorm.DB) {
+	_ = Container.Provide(connInstance)
+
+	_ = Container.Provide(repository.NewContacts)
+	_ = Container.Provide(repository.NewTags)
+	_ = Container.Provide(repository.NewGroups)
+	_ = Container.Provide(repository.NewAudit)
+	_ = Container.Provide(repository.NewAddressBook)
+	_ = Container.Provide(repository.NewIdempotency)
+}
+
+// provideMemoryRepositories provides the repositories over a store in memory,
+// which starts empty.
+func provideMemoryRepositories() {
+	_ = Container.Provide(memory.NewStore)
+
+	_ = Container.Provide(memory.NewContacts)
+	_ = Container.Provide(memory.NewTags)
+	_ = Container.Provide(memory.NewGroups)
+	_ = Container.Provide(memory.NewAudit)
+	_ = Container.Provide(memory.NewAddressBook)
+	_ = Container.Provide(memory.NewIdempotency)
+}
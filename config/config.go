@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/labstack/gommon/log"
@@ -10,11 +12,41 @@ import (
 type Config struct {
 	ServerHost string `required:"true" split_words:"true"`
 	ServerPort int    `required:"true" split_words:"true"`
-	DBHost     string `required:"true" split_words:"true"`
-	DBPort     int    `required:"true" split_words:"true"`
-	DBUser     string `required:"true" split_words:"true"`
-	DBName     string `required:"true" split_words:"true"`
-	DBPass     string `required:"true" split_words:"true"`
+
+	// StorageDriver picks where contacts are stored: postgres, sqlite or memory.
+	// The DB settings are only used by postgres and SqlitePath by sqlite.
+	StorageDriver string `default:"postgres" split_words:"true"`
+	DBHost        string `split_words:"true"`
+	DBPort        int    `split_words:"true"`
+	DBUser        string `split_words:"true"`
+	DBName        string `split_words:"true"`
+	DBPass        string `split_words:"true"`
+	SqlitePath    string `default:"contacts.db" split_words:"true"`
+
+	CursorSecret string `split_words:"true"`
+	MaxPageLimit int    `default:"100" split_words:"true"`
+
+	NameFormat         string `default:"first_last" split_words:"true"`
+	PhoneDefaultRegion string `default:"CO" split_words:"true"`
+
+	TrashRetention time.Duration `default:"720h" split_words:"true"`
+	// TrashPurgeInterval is also how often the expired idempotent requests are
+	// deleted.
+	TrashPurgeInterval time.Duration `default:"1h" split_words:"true"`
+
+	ImportMaxRows int `default:"10000" split_words:"true"`
+
+	BatchMaxOperations int `default:"100" split_words:"true"`
+
+	IdempotencyTTL time.Duration `default:"24h" split_words:"true"`
+	// IdempotencyMaxSize is the size in bytes of the largest body, of a request or
+	// of its response, kept for a request sent with an Idempotency-Key.
+	IdempotencyMaxSize int64 `default:"1048576" split_words:"true"`
+	// IdempotencyLockTimeout is how long a request sent with an Idempotency-Key
+	// holds it while processed, it must be longer than the slowest request.
+	IdempotencyLockTimeout time.Duration `default:"5m" split_words:"true"`
+
+	MigrateOnBoot bool `default:"true" split_words:"true"`
 }
 
 var once sync.Once
@@ -25,7 +57,60 @@ func Environments() Config {
 		if err := envconfig.Process("", &config); err != nil {
 			log.Panicf("Error parsing environment vars %#v", err)
 		}
+
+		if err := config.validate(); err != nil {
+			log.Panic(err)
+		}
 	})
 
 	return config
 }
+
+// validate checks the settings that must be positive. The settings that depend
+// on the domain are checked as its configs are built.
+func (config Config) validate() error {
+	if config.ImportMaxRows <= 0 {
+		return fmt.Errorf("IMPORT_MAX_ROWS must be positive, got %d", config.ImportMaxRows)
+	}
+
+	if config.BatchMaxOperations <= 0 {
+		return fmt.Errorf("BATCH_MAX_OPERATIONS must be positive, got %d", config.BatchMaxOperations)
+	}
+
+	if config.IdempotencyTTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_TTL must be positive, got %s", config.IdempotencyTTL)
+	}
+
+	if config.IdempotencyMaxSize <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_MAX_SIZE must be positive, got %d", config.IdempotencyMaxSize)
+	}
+
+	if config.IdempotencyLockTimeout <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_LOCK_TIMEOUT must be positive, got %s", config.IdempotencyLockTimeout)
+	}
+
+	return nil
+}
+
+// CheckPostgres checks that the settings of the Postgres connection are set,
+// they are only required when the storage is postgres.
+func (config Config) CheckPostgres() error {
+	settings := []struct {
+		name string
+		set  bool
+	}{
+		{"DB_HOST", config.DBHost != ""},
+		{"DB_PORT", config.DBPort != 0},
+		{"DB_USER", config.DBUser != ""},
+		{"DB_NAME", config.DBName != ""},
+		{"DB_PASS", config.DBPass != ""},
+	}
+
+	for _, setting := range settings {
+		if !setting.set {
+			return fmt.Errorf("%s is required by the %s storage", setting.name, config.StorageDriver)
+		}
+	}
+
+	return nil
+}
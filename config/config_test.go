@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	valid := Config{ImportMaxRows: 10000, BatchMaxOperations: 100, IdempotencyTTL: 24 * time.Hour,
+		IdempotencyMaxSize: 1 << 20, IdempotencyLockTimeout: 5 * time.Minute}
+
+	assert.NoError(t, valid.validate())
+
+	invalid := valid
+	invalid.ImportMaxRows = 0
+	assert.EqualError(t, invalid.validate(), "IMPORT_MAX_ROWS must be positive, got 0")
+
+	invalid = valid
+	invalid.BatchMaxOperations = -1
+	assert.EqualError(t, invalid.validate(), "BATCH_MAX_OPERATIONS must be positive, got -1")
+
+	invalid = valid
+	invalid.IdempotencyTTL = 0
+	assert.EqualError(t, invalid.validate(), "IDEMPOTENCY_TTL must be positive, got 0s")
+
+	invalid = valid
+	invalid.IdempotencyMaxSize = 0
+	assert.EqualError(t, invalid.validate(), "IDEMPOTENCY_MAX_SIZE must be positive, got 0")
+
+	invalid = valid
+	invalid.IdempotencyLockTimeout = -time.Minute
+	assert.EqualError(t, invalid.validate(), "IDEMPOTENCY_LOCK_TIMEOUT must be positive, got -1m0s")
+}
+
+func TestCheckPostgres(t *testing.T) {
+	config := Config{StorageDriver: "postgres", DBHost: "localhost", DBPort: 5432, DBUser: "postgres",
+		DBName: "contacts", DBPass: "secret"}
+
+	assert.NoError(t, config.CheckPostgres())
+
+	config.DBName = ""
+	assert.EqualError(t, config.CheckPostgres(), "DB_NAME is required by the postgres storage")
+
+	config.DBPort = 0
+	assert.EqualError(t, config.CheckPostgres(), "DB_PORT is required by the postgres storage")
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+// AddressBook maps contacts to the resources of the CardDAV address book and
+// tracks the changes made to it.
+type AddressBook interface {
+	Resolve(name string) (models.CardResource, error)
+	Resources(contactIDs []uint) ([]models.CardResource, error)
+	Bind(resource models.CardResource) error
+	SyncToken() (uint, error)
+	Changes(since uint) (models.AddressBookChanges, error)
+}
+
+type addressBook struct {
+	repo repository.AddressBook
+}
+
+func NewAddressBook(repo repository.AddressBook) AddressBook {
+	return &addressBook{
+		repo,
+	}
+}
+
+// Resolve finds the resource named name. The default name of a contact, its id
+// followed by .vcf, only resolves while no client gave the contact another one.
+// Whether the contact exists is left to the caller to check.
+func (app *addressBook) Resolve(name string) (models.CardResource, error) {
+	resource, err := app.repo.GetResource(name)
+	if err == nil {
+		return withUID(resource), nil
+	}
+
+	if !apperrors.Is(err, apperrors.KindNotFound) {
+		return models.CardResource{}, err
+	}
+
+	id, parseErr := strconv.ParseUint(strings.TrimSuffix(name, vcard.Extension), 10, 64)
+	if parseErr != nil || id == 0 || !strings.HasSuffix(name, vcard.Extension) {
+		return models.CardResource{}, err
+	}
+
+	named, err := app.repo.HasResource(uint(id))
+	if err != nil {
+		return models.CardResource{}, err
+	}
+
+	if named {
+		return models.CardResource{}, apperrors.NotFound(fmt.Sprintf("the resource %s was not found", name), nil)
+	}
+
+	return defaultResource(uint(id)), nil
+}
+
+// Resources returns the resource of each contact, in the order of contactIDs.
+func (app *addressBook) Resources(contactIDs []uint) ([]models.CardResource, error) {
+	named, err := app.repo.GetResources(contactIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byContact := make(map[uint]models.CardResource, len(named))
+	for _, resource := range named {
+		byContact[resource.ContactID] = withUID(resource)
+	}
+
+	resources := make([]models.CardResource, 0, len(contactIDs))
+
+	for _, id := range contactIDs {
+		resource, found := byContact[id]
+		if !found {
+			resource = defaultResource(id)
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+func (app *addressBook) Bind(resource models.CardResource) error {
+	return app.repo.SaveResource(resource)
+}
+
+func (app *addressBook) SyncToken() (uint, error) {
+	return app.repo.SyncToken()
+}
+
+// Changes returns the contacts changed after the since token, which must be a
+// token handed out before.
+func (app *addressBook) Changes(since uint) (models.AddressBookChanges, error) {
+	token, err := app.repo.SyncToken()
+	if err != nil {
+		return models.AddressBookChanges{}, err
+	}
+
+	if since > token {
+		return models.AddressBookChanges{}, apperrors.Validation(fmt.Sprintf("the sync token %d is not valid", since), nil)
+	}
+
+	return app.repo.Changes(since)
+}
+
+func defaultResource(contactID uint) models.CardResource {
+	return withUID(models.CardResource{
+		Name:      fmt.Sprintf("%d%s", contactID, vcard.Extension),
+		ContactID: contactID,
+	})
+}
+
+// withUID gives a UID to resources whose cards had none.
+func withUID(resource models.CardResource) models.CardResource {
+	if resource.UID == "" {
+		resource.UID = fmt.Sprintf("urn:contacts-go:contact:%d", resource.ContactID)
+	}
+
+	return resource
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type addressBookTestSuite struct {
+	suite.Suite
+	repo      *mocks.AddressBook
+	underTest AddressBook
+}
+
+func TestAddressBookSuite(t *testing.T) {
+	suite.Run(t, new(addressBookTestSuite))
+}
+
+func (suite *addressBookTestSuite) SetupTest() {
+	suite.repo = &mocks.AddressBook{}
+	suite.underTest = NewAddressBook(suite.repo)
+}
+
+func (suite *addressBookTestSuite) TestResolve_WhenNamed() {
+	suite.repo.Mock.On("GetResource", "juan.vcf").
+		Return(models.CardResource{Name: "juan.vcf", ContactID: 7, UID: "juan"}, nil)
+
+	resource, err := suite.underTest.Resolve("juan.vcf")
+
+	suite.NoError(err)
+	suite.Equal(models.CardResource{Name: "juan.vcf", ContactID: 7, UID: "juan"}, resource)
+}
+
+func (suite *addressBookTestSuite) TestResolve_WhenDefaultName() {
+	suite.repo.Mock.On("GetResource", "7.vcf").Return(models.CardResource{}, apperrors.NotFound("", nil))
+	suite.repo.Mock.On("HasResource", uint(7)).Return(false, nil)
+
+	resource, err := suite.underTest.Resolve("7.vcf")
+
+	suite.NoError(err)
+	suite.Equal(models.CardResource{Name: "7.vcf", ContactID: 7, UID: "urn:contacts-go:contact:7"}, resource)
+}
+
+func (suite *addressBookTestSuite) TestResolve_WhenDefaultNameOfNamedContact() {
+	suite.repo.Mock.On("GetResource", "7.vcf").Return(models.CardResource{}, apperrors.NotFound("", nil))
+	suite.repo.Mock.On("HasResource", uint(7)).Return(true, nil)
+
+	_, err := suite.underTest.Resolve("7.vcf")
+
+	suite.True(apperrors.Is(err, apperrors.KindNotFound))
+}
+
+func (suite *addressBookTestSuite) TestResolve_WhenNotFound() {
+	suite.repo.Mock.On("GetResource", "juan.vcf").Return(models.CardResource{}, apperrors.NotFound("", nil))
+
+	_, err := suite.underTest.Resolve("juan.vcf")
+
+	suite.True(apperrors.Is(err, apperrors.KindNotFound))
+	suite.repo.Mock.AssertNotCalled(suite.T(), "HasResource")
+}
+
+func (suite *addressBookTestSuite) TestResolve_WhenFail() {
+	suite.repo.Mock.On("GetResource", "7.vcf").Return(models.CardResource{}, errors.New("connection refused"))
+
+	_, err := suite.underTest.Resolve("7.vcf")
+
+	suite.Error(err)
+	suite.repo.Mock.AssertNotCalled(suite.T(), "HasResource")
+}
+
+func (suite *addressBookTestSuite) TestResources_KeepsTheOrderOfContacts() {
+	suite.repo.Mock.On("GetResources", []uint{3, 1, 2}).Return([]models.CardResource{
+		{Name: "one.vcf", ContactID: 1, UID: "one"},
+		{Name: "three.vcf", ContactID: 3},
+	}, nil)
+
+	resources, err := suite.underTest.Resources([]uint{3, 1, 2})
+
+	suite.NoError(err)
+	suite.Equal([]models.CardResource{
+		{Name: "three.vcf", ContactID: 3, UID: "urn:contacts-go:contact:3"},
+		{Name: "one.vcf", ContactID: 1, UID: "one"},
+		{Name: "2.vcf", ContactID: 2, UID: "urn:contacts-go:contact:2"},
+	}, resources)
+}
+
+func (suite *addressBookTestSuite) TestChanges_WhenSuccess() {
+	expected := models.AddressBookChanges{ContactIDs: []uint{4, 2}, Token: 12}
+
+	suite.repo.Mock.On("SyncToken").Return(uint(12), nil)
+	suite.repo.Mock.On("Changes", uint(9)).Return(expected, nil)
+
+	changes, err := suite.underTest.Changes(9)
+
+	suite.NoError(err)
+	suite.Equal(expected, changes)
+}
+
+func (suite *addressBookTestSuite) TestChanges_WhenTokenIsAhead() {
+	suite.repo.Mock.On("SyncToken").Return(uint(12), nil)
+
+	_, err := suite.underTest.Changes(13)
+
+	suite.True(apperrors.Is(err, apperrors.KindValidation))
+	suite.repo.Mock.AssertNotCalled(suite.T(), "Changes")
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type Audit interface {
+	History(contactID uint, paginate dto.Paginate) (*models.Paginator, error)
+	Get(paginate dto.Paginate, filter models.AuditFilter) (*models.Paginator, error)
+}
+
+type audit struct {
+	repo repository.Audit
+}
+
+func NewAudit(repo repository.Audit) Audit {
+	return &audit{
+		repo,
+	}
+}
+
+func (app *audit) History(contactID uint, paginate dto.Paginate) (*models.Paginator, error) {
+	return app.repo.History(contactID, models.Paginator{
+		Page:  paginate.Page,
+		Limit: paginate.Limit,
+	})
+}
+
+func (app *audit) Get(paginate dto.Paginate, filter models.AuditFilter) (*models.Paginator, error) {
+	return app.repo.Get(models.Paginator{
+		Page:  paginate.Page,
+		Limit: paginate.Limit,
+	}, filter)
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type auditTestSuite struct {
+	suite.Suite
+	repo      *mocks.Audit
+	underTest Audit
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(auditTestSuite))
+}
+
+func (suite *auditTestSuite) SetupTest() {
+	suite.repo = &mocks.Audit{}
+	suite.underTest = NewAudit(suite.repo)
+}
+
+func (suite *auditTestSuite) TestHistory_WhenSuccess() {
+	expected := &models.Paginator{Records: []models.AuditEntry{{ID: 1, ContactID: 7}}}
+
+	suite.repo.Mock.On("History", uint(7), models.Paginator{Page: 1, Limit: 10}).Return(expected, nil)
+
+	history, err := suite.underTest.History(uint(7), dto.Paginate{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal(expected, history)
+}
+
+func (suite *auditTestSuite) TestHistory_WhenFail() {
+	suite.repo.Mock.On("History", uint(7), models.Paginator{Page: 1, Limit: 10}).
+		Return(nil, errors.New("some error"))
+
+	_, err := suite.underTest.History(uint(7), dto.Paginate{Page: 1, Limit: 10})
+
+	suite.Error(err)
+}
+
+func (suite *auditTestSuite) TestGet_WhenSuccess() {
+	filter := models.AuditFilter{Actor: "ana", Action: models.AuditDelete}
+
+	suite.repo.Mock.On("Get", models.Paginator{Page: 2, Limit: 5}, filter).Return(&models.Paginator{}, nil)
+
+	_, err := suite.underTest.Get(dto.Paginate{Page: 2, Limit: 5}, filter)
+
+	suite.NoError(err)
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Batch applies operations in a single transaction and returns their results in
+// the same order. Each operation is validated as if it was sent on its own, and
+// a contact can only be written by one operation of the batch. When atomic, a
+// failed operation keeps the rest from being applied, and they fail as depending
+// on it; otherwise every operation that can be applied is.
+func (app *contacts) Batch(origin models.Origin, operations []dto.BatchOperation,
+	atomic bool) ([]models.BatchResult, error) {
+	results := make([]models.BatchResult, len(operations))
+	writtenBy := map[uint]int{}
+
+	var valid []models.BatchOperation
+	var indexes []int
+
+	for i, operation := range operations {
+		model, err := app.batchOperation(operation)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		if model.Action != models.BatchCreate {
+			if first, repeated := writtenBy[model.ID]; repeated {
+				results[i].Err = apperrors.Validation(
+					fmt.Sprintf("the contact: %v is already written by the operation %d", model.ID, first), nil)
+
+				continue
+			}
+
+			writtenBy[model.ID] = i
+		}
+
+		valid = append(valid, model)
+		indexes = append(indexes, i)
+	}
+
+	failed := firstFailure(results)
+
+	if len(valid) > 0 && (!atomic || failed < 0) {
+		applied, err := app.repo.Batch(origin, valid, atomic)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, result := range applied {
+			results[indexes[i]] = result
+		}
+
+		failed = firstFailure(results)
+	}
+
+	if atomic && failed >= 0 {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i] = models.BatchResult{Err: apperrors.FailedDependency(
+					fmt.Sprintf("the operation was not applied because the operation %d failed", failed), nil)}
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// batchOperation validates an operation of a batch and normalizes its contact.
+func (app *contacts) batchOperation(operation dto.BatchOperation) (models.BatchOperation, error) {
+	if err := operation.Validate(); err != nil {
+		return models.BatchOperation{}, err
+	}
+
+	model := models.BatchOperation{
+		Action:  models.BatchAction(operation.Op),
+		ID:      operation.ID,
+		Version: operation.Version,
+	}
+
+	if operation.Contact == nil {
+		return model, nil
+	}
+
+	contact, err := app.toModel(*operation.Contact)
+	if err != nil {
+		return models.BatchOperation{}, err
+	}
+
+	model.Contact = contact
+
+	return model, nil
+}
+
+// firstFailure is the index of the first failed result, -1 when none failed.
+func firstFailure(results []models.BatchResult) int {
+	for i, result := range results {
+		if result.Err != nil {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *contactsTestSuite) batchOperations() []dto.BatchOperation {
+	return []dto.BatchOperation{
+		{Op: "create", Contact: &dto.Contact{Name: "Juan", PhoneNumber: "3000000000"}},
+		{Op: "update", ID: 7, Version: 2, Contact: &dto.Contact{Name: "Ana", PhoneNumber: "3100000000"}},
+		{Op: "delete", ID: 7},
+		{Op: "delete", ID: 8},
+	}
+}
+
+func (suite *contactsTestSuite) TestBatch_WhenBestEffort() {
+	suite.repo.Mock.On("Batch", suite.origin, mock.MatchedBy(func(operations []models.BatchOperation) bool {
+		return len(operations) == 3 &&
+			operations[0].Action == models.BatchCreate && operations[0].Contact.PhoneNumber == "+573000000000" &&
+			operations[1].Action == models.BatchUpdate && operations[1].ID == 7 && operations[1].Version == 2 &&
+			operations[2].Action == models.BatchDelete && operations[2].ID == 8
+	}), false).Return([]models.BatchResult{
+		{Contact: models.Contact{ID: 9}},
+		{Err: apperrors.PreconditionFailed("modified", nil)},
+		{Contact: models.Contact{ID: 8}},
+	}, nil)
+
+	results, err := suite.underTest.Batch(suite.origin, suite.batchOperations(), false)
+
+	suite.NoError(err)
+	suite.Len(results, 4)
+	suite.Equal(models.Contact{ID: 9}, results[0].Contact)
+	suite.True(apperrors.Is(results[1].Err, apperrors.KindPreconditionFailed))
+	suite.EqualError(results[2].Err, "the contact: 7 is already written by the operation 1")
+	suite.Equal(models.BatchResult{Contact: models.Contact{ID: 8}}, results[3])
+}
+
+func (suite *contactsTestSuite) TestBatch_WhenAtomicFailsValidation() {
+	operations := suite.batchOperations()
+	operations[2].ID = 10
+
+	operations = append(operations, dto.BatchOperation{Op: "create", Contact: &dto.Contact{Name: "Luis"}})
+
+	results, err := suite.underTest.Batch(suite.origin, operations, true)
+
+	suite.NoError(err)
+	suite.True(apperrors.Is(results[4].Err, apperrors.KindValidation))
+
+	for _, result := range results[:4] {
+		suite.True(apperrors.Is(result.Err, apperrors.KindFailedDependency))
+		suite.EqualError(result.Err, "the operation was not applied because the operation 4 failed")
+	}
+
+	suite.repo.AssertNotCalled(suite.T(), "Batch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *contactsTestSuite) TestBatch_WhenAtomicFailsInRepository() {
+	operations := suite.batchOperations()[:2]
+
+	suite.repo.Mock.On("Batch", suite.origin, mock.Anything, true).Return([]models.BatchResult{
+		{},
+		{Err: apperrors.NotFound("the contact: 7 does not exist", nil)},
+	}, nil)
+
+	results, err := suite.underTest.Batch(suite.origin, operations, true)
+
+	suite.NoError(err)
+	suite.True(apperrors.Is(results[0].Err, apperrors.KindFailedDependency))
+	suite.True(apperrors.Is(results[1].Err, apperrors.KindNotFound))
+}
+
+func (suite *contactsTestSuite) TestBatch_WhenFail() {
+	suite.repo.Mock.On("Batch", suite.origin, mock.Anything, true).Return(nil, errors.New("connection refused"))
+
+	_, err := suite.underTest.Batch(suite.origin, suite.batchOperations()[:1], true)
+
+	suite.Error(err)
+}
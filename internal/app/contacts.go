@@ -1,53 +1,216 @@
 package app
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
 	"github.com/AjxGnx/contacts-go/internal/domain/dto"
 	"github.com/AjxGnx/contacts-go/internal/domain/models"
 	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
 )
 
 type Contacts interface {
-	Create(contact dto.Contact) (models.Contact, error)
+	Create(origin models.Origin, contact dto.Contact) (models.Contact, error)
 	GetByID(id uint) (models.Contact, error)
-	Update(id uint, contact dto.Contact) (models.Contact, error)
-	Delete(id uint) error
+	Update(origin models.Origin, id uint, contact dto.Contact, version uint) (models.Contact, error)
+	Patch(origin models.Origin, id uint, patch dto.ContactPatch, version uint) (models.Contact, error)
+	Delete(origin models.Origin, id uint, version uint) error
 	Get(paginate dto.Paginate) (*models.Paginator, error)
+	Export(paginate dto.Paginate, write func(contacts []models.Contact) error) error
+	GetTrash(paginate dto.Paginate) (*models.Paginator, error)
+	Restore(origin models.Origin, id uint) (models.Contact, error)
+	Purge(origin models.Origin, id uint) error
+	GetAsOf(id uint, at time.Time) (models.Contact, error)
+	GetRevisions(id uint, paginate dto.Paginate) (*models.Paginator, error)
+	Revert(origin models.Origin, id uint, revision uint, version uint) (models.Contact, error)
+	Import(origin models.Origin, rows []dto.ImportRow, options dto.ImportOptions) (dto.ImportReport, error)
+	Batch(origin models.Origin, operations []dto.BatchOperation, atomic bool) ([]models.BatchResult, error)
+	Duplicates(minScore float64) ([]models.DuplicateCluster, error)
+	Merge(origin models.Origin, merge dto.Merge, version uint) (models.Contact, error)
+	Search(query string, paginate dto.Paginate) (*models.Paginator, error)
 }
 
 type contacts struct {
-	repo repository.Contacts
+	repo   repository.Contacts
+	names  models.NameFormat
+	phones dto.PhoneNormalizer
 }
 
-func NewContacts(repo repository.Contacts) Contacts {
+func NewContacts(repo repository.Contacts, names models.NameFormat, phones dto.PhoneNormalizer) Contacts {
 	return &contacts{
 		repo,
+		names,
+		phones,
 	}
 }
 
-func (app *contacts) Create(contact dto.Contact) (models.Contact, error) {
-	return app.repo.Create(contact.ToModel())
+// Create saves the contact. Every change made to contacts is audited with origin
+// as its author.
+func (app *contacts) Create(origin models.Origin, contact dto.Contact) (models.Contact, error) {
+	model, err := app.toModel(contact)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return app.repo.Create(origin, model)
 }
 
 func (app *contacts) GetByID(id uint) (models.Contact, error) {
 	return app.repo.GetByID(id)
 }
 
-func (app *contacts) Update(id uint, contact dto.Contact) (models.Contact, error) {
-	if _, err := app.GetByID(id); err != nil {
+// Update replaces the contact. A version other than 0 is the version the client
+// last read, the update fails when the contact changed since then.
+func (app *contacts) Update(origin models.Origin, id uint, contact dto.Contact, version uint) (models.Contact, error) {
+	model, err := app.toModel(contact)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	if _, err = app.GetByID(id); err != nil {
+		return models.Contact{}, err
+	}
+
+	return app.repo.Update(origin, id, model, version)
+}
+
+func (app *contacts) Patch(origin models.Origin, id uint, patch dto.ContactPatch, version uint) (models.Contact, error) {
+	current, err := app.GetByID(id)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	if version != 0 && current.Version != version {
+		return models.Contact{}, apperrors.PreconditionFailed(
+			fmt.Sprintf("the contact: %v was modified by someone else, reload it and try again", id), nil)
+	}
+
+	contact, err := patch.Apply(dto.NewContact(current))
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	if err = contact.Validate(); err != nil {
+		return models.Contact{}, err
+	}
+
+	patched := contact.ToModel()
+	if patched.Name != current.Name && patched.StructuredName == current.StructuredName {
+		// only the display name was patched, split it again instead of rebuilding
+		// it from the old components.
+		patched.StructuredName = models.StructuredName{}
+	}
+
+	patched.NormalizeName(app.names)
+
+	if err = app.phones.Normalize(&patched); err != nil {
 		return models.Contact{}, err
 	}
 
-	return app.repo.Update(id, contact.ToModel())
+	return app.repo.Update(origin, id, patched, current.Version)
 }
 
-func (app *contacts) Delete(id uint) error {
+func (app *contacts) toModel(contact dto.Contact) (models.Contact, error) {
+	model := contact.ToModel()
+	model.NormalizeName(app.names)
+
+	if err := app.phones.Normalize(&model); err != nil {
+		return models.Contact{}, err
+	}
+
+	return model, nil
+}
+
+func (app *contacts) Delete(origin models.Origin, id uint, version uint) error {
 	if _, err := app.GetByID(id); err != nil {
 		return err
 	}
 
-	return app.repo.Delete(id)
+	return app.repo.Delete(origin, id, version)
 }
 
 func (app *contacts) Get(paginate dto.Paginate) (*models.Paginator, error) {
-	return app.repo.Get(models.Paginator{Page: paginate.Page, Limit: paginate.Limit})
+	filter := paginate.ToFilter()
+	filter.Filters = app.phones.NormalizeFilters(filter.Filters)
+
+	return app.repo.Get(models.Paginator{
+		Page:   paginate.Page,
+		Limit:  paginate.Limit,
+		Cursor: paginate.Cursor,
+	}, filter)
+}
+
+// Export hands to write, batch after batch, every contact matching the search,
+// filters and sort of paginate; its page and limit are ignored.
+func (app *contacts) Export(paginate dto.Paginate, write func(contacts []models.Contact) error) error {
+	filter := paginate.ToFilter()
+	filter.Filters = app.phones.NormalizeFilters(filter.Filters)
+
+	return app.repo.Export(filter, write)
+}
+
+// Search finds the contacts whose names, company, notes or email addresses match
+// query, regardless of accents and tolerating typos, ranked by how well they
+// match.
+func (app *contacts) Search(query string, paginate dto.Paginate) (*models.Paginator, error) {
+	return app.repo.Search(models.Paginator{
+		Page:  paginate.Page,
+		Limit: paginate.Limit,
+	}, query)
+}
+
+func (app *contacts) GetTrash(paginate dto.Paginate) (*models.Paginator, error) {
+	return app.repo.GetTrash(models.Paginator{
+		Page:  paginate.Page,
+		Limit: paginate.Limit,
+	})
+}
+
+func (app *contacts) Restore(origin models.Origin, id uint) (models.Contact, error) {
+	return app.repo.Restore(origin, id)
+}
+
+// Purge deletes for good a contact, it must have been moved to the trash first.
+func (app *contacts) Purge(origin models.Origin, id uint) error {
+	return app.repo.Purge(origin, id)
+}
+
+// GetAsOf returns the contact as it was last written at or before at.
+func (app *contacts) GetAsOf(id uint, at time.Time) (models.Contact, error) {
+	revision, err := app.repo.GetRevisionAt(id, at)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return revision.Contact, nil
+}
+
+func (app *contacts) GetRevisions(id uint, paginate dto.Paginate) (*models.Paginator, error) {
+	return app.repo.GetRevisions(id, models.Paginator{
+		Page:  paginate.Page,
+		Limit: paginate.Limit,
+	})
+}
+
+// Revert writes the content the contact had at revision as a new revision. The
+// content is validated again since the rules could have changed after it was
+// saved. A version other than 0 is the version the client last read.
+func (app *contacts) Revert(origin models.Origin, id uint, revision uint, version uint) (models.Contact, error) {
+	previous, err := app.repo.GetRevision(id, revision)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	contact := dto.NewContact(previous.Contact)
+	if err = contact.Validate(); err != nil {
+		return models.Contact{}, err
+	}
+
+	model, err := app.toModel(contact)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return app.repo.Revert(origin, id, model, version)
 }
@@ -3,16 +3,21 @@ package app
 import (
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
 	"github.com/AjxGnx/contacts-go/internal/domain/dto"
 	"github.com/AjxGnx/contacts-go/internal/domain/models"
 	mocks "github.com/AjxGnx/contacts-go/mocks/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 type contactsTestSuite struct {
 	suite.Suite
 	repo      *mocks.Contacts
+	phones    dto.PhoneNormalizer
+	origin    models.Origin
 	underTest Contacts
 }
 
@@ -22,23 +27,29 @@ func TestContactsSuite(t *testing.T) {
 
 func (suite *contactsTestSuite) SetupTest() {
 	suite.repo = &mocks.Contacts{}
-	suite.underTest = NewContacts(suite.repo)
+	suite.phones, _ = dto.NewPhoneNormalizer("CO")
+	suite.origin = models.Origin{Actor: "tester", RequestID: "request-1"}
+	suite.underTest = NewContacts(suite.repo, models.NameFormatGivenFirst, suite.phones)
 }
 
 func (suite *contactsTestSuite) TestCreate_WhenSuccess() {
 	contact := dto.Contact{
 		Name:        "test",
-		PhoneNumber: "+570000000",
+		PhoneNumber: "+573000000000",
 	}
 
 	expected := models.Contact{Name: contact.Name, PhoneNumber: contact.PhoneNumber, ID: 1}
 
-	suite.repo.Mock.On("Create", models.Contact{
-		Name:        contact.Name,
-		PhoneNumber: contact.PhoneNumber,
+	suite.repo.Mock.On("Create", suite.origin, models.Contact{
+		Name:           contact.Name,
+		StructuredName: models.StructuredName{GivenName: "test"},
+		PhoneNumber:    contact.PhoneNumber,
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
 	}).Return(expected, nil)
 
-	contactModel, err := suite.underTest.Create(contact)
+	contactModel, err := suite.underTest.Create(suite.origin, contact)
 
 	suite.NoError(err)
 	suite.Equal(expected, contactModel)
@@ -47,24 +58,28 @@ func (suite *contactsTestSuite) TestCreate_WhenSuccess() {
 func (suite *contactsTestSuite) TestCreate_WhenFail() {
 	contact := dto.Contact{
 		Name:        "test",
-		PhoneNumber: "+570000000",
+		PhoneNumber: "+573000000000",
 	}
 
 	expectedError := errors.New("some error")
 
-	suite.repo.Mock.On("Create", models.Contact{
-		Name:        contact.Name,
-		PhoneNumber: contact.PhoneNumber,
+	suite.repo.Mock.On("Create", suite.origin, models.Contact{
+		Name:           contact.Name,
+		StructuredName: models.StructuredName{GivenName: "test"},
+		PhoneNumber:    contact.PhoneNumber,
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
 	}).Return(models.Contact{}, expectedError)
 
-	contactModel, err := suite.underTest.Create(contact)
+	contactModel, err := suite.underTest.Create(suite.origin, contact)
 
 	suite.Error(err)
 	suite.Equal(models.Contact{}, contactModel)
 }
 
 func (suite *contactsTestSuite) TestGetByID_WhenSuccess() {
-	expected := models.Contact{Name: "test", PhoneNumber: "+570000000", ID: 1}
+	expected := models.Contact{Name: "test", PhoneNumber: "+573000000000", ID: 1}
 
 	suite.repo.Mock.On("GetByID", uint(1)).Return(expected, nil)
 
@@ -88,18 +103,22 @@ func (suite *contactsTestSuite) TestGetByID_WhenFail() {
 func (suite *contactsTestSuite) TestUpdate_WhenSuccess() {
 	contact := dto.Contact{
 		Name:        "test",
-		PhoneNumber: "+570000000",
+		PhoneNumber: "+573000000000",
 	}
 
 	expected := models.Contact{Name: contact.Name, PhoneNumber: contact.PhoneNumber, ID: 1}
 
 	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{}, nil)
-	suite.repo.Mock.On("Update", uint(1), models.Contact{
-		Name:        contact.Name,
-		PhoneNumber: contact.PhoneNumber,
-	}).Return(expected, nil)
+	suite.repo.Mock.On("Update", suite.origin, uint(1), models.Contact{
+		Name:           contact.Name,
+		StructuredName: models.StructuredName{GivenName: "test"},
+		PhoneNumber:    contact.PhoneNumber,
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
+	}, uint(0)).Return(expected, nil)
 
-	contactModel, err := suite.underTest.Update(uint(1), contact)
+	contactModel, err := suite.underTest.Update(suite.origin, uint(1), contact, uint(0))
 
 	suite.NoError(err)
 	suite.Equal(expected, contactModel)
@@ -108,18 +127,22 @@ func (suite *contactsTestSuite) TestUpdate_WhenSuccess() {
 func (suite *contactsTestSuite) TestUpdate_WhenFail() {
 	contact := dto.Contact{
 		Name:        "test",
-		PhoneNumber: "+570000000",
+		PhoneNumber: "+573000000000",
 	}
 
 	expectedError := errors.New("some error")
 
 	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{}, nil)
-	suite.repo.Mock.On("Update", uint(1), models.Contact{
-		Name:        contact.Name,
-		PhoneNumber: contact.PhoneNumber,
-	}).Return(models.Contact{}, expectedError)
+	suite.repo.Mock.On("Update", suite.origin, uint(1), models.Contact{
+		Name:           contact.Name,
+		StructuredName: models.StructuredName{GivenName: "test"},
+		PhoneNumber:    contact.PhoneNumber,
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
+	}, uint(0)).Return(models.Contact{}, expectedError)
 
-	contactModel, err := suite.underTest.Update(uint(1), contact)
+	contactModel, err := suite.underTest.Update(suite.origin, uint(1), contact, uint(0))
 
 	suite.Error(err)
 	suite.Equal(models.Contact{}, contactModel)
@@ -128,13 +151,13 @@ func (suite *contactsTestSuite) TestUpdate_WhenFail() {
 func (suite *contactsTestSuite) TestUpdate_WhenGetByIDFail() {
 	contact := dto.Contact{
 		Name:        "test",
-		PhoneNumber: "+570000000",
+		PhoneNumber: "+573000000000",
 	}
 	expectedError := errors.New("some error")
 
 	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{}, expectedError)
 
-	contactModel, err := suite.underTest.Update(uint(1), contact)
+	contactModel, err := suite.underTest.Update(suite.origin, uint(1), contact, uint(0))
 
 	suite.Error(err)
 	suite.Equal(models.Contact{}, contactModel)
@@ -142,9 +165,9 @@ func (suite *contactsTestSuite) TestUpdate_WhenGetByIDFail() {
 
 func (suite *contactsTestSuite) TestDelete_WhenSuccess() {
 	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{}, nil)
-	suite.repo.Mock.On("Delete", uint(1)).Return(nil)
+	suite.repo.Mock.On("Delete", suite.origin, uint(1), uint(0)).Return(nil)
 
-	suite.NoError(suite.underTest.Delete(uint(1)))
+	suite.NoError(suite.underTest.Delete(suite.origin, uint(1), uint(0)))
 }
 
 func (suite *contactsTestSuite) TestDelete_WhenGetByIDFail() {
@@ -152,16 +175,16 @@ func (suite *contactsTestSuite) TestDelete_WhenGetByIDFail() {
 
 	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{}, expectedError)
 
-	suite.Error(suite.underTest.Delete(uint(1)))
+	suite.Error(suite.underTest.Delete(suite.origin, uint(1), uint(0)))
 }
 
 func (suite *contactsTestSuite) TestDelete_WhenFail() {
 	expectedError := errors.New("some error")
 
 	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{}, nil)
-	suite.repo.Mock.On("Delete", uint(1)).Return(expectedError)
+	suite.repo.Mock.On("Delete", suite.origin, uint(1), uint(0)).Return(expectedError)
 
-	suite.Error(suite.underTest.Delete(uint(1)))
+	suite.Error(suite.underTest.Delete(suite.origin, uint(1), uint(0)))
 }
 
 func (suite *contactsTestSuite) TestGet_WhenSuccess() {
@@ -169,7 +192,7 @@ func (suite *contactsTestSuite) TestGet_WhenSuccess() {
 		Page:  1,
 		Limit: 10,
 	}
-	suite.repo.Mock.On("Get", models.Paginator{Page: paginate.Page, Limit: paginate.Limit}).
+	suite.repo.Mock.On("Get", models.Paginator{Page: paginate.Page, Limit: paginate.Limit}, models.ContactFilter{}).
 		Return(&models.Paginator{}, nil)
 
 	_, err := suite.underTest.Get(paginate)
@@ -183,10 +206,403 @@ func (suite *contactsTestSuite) TestGet_WhenFail() {
 		Limit: 10,
 	}
 	expectedError := errors.New("some error")
-	suite.repo.Mock.On("Get", models.Paginator{Page: paginate.Page, Limit: paginate.Limit}).
+	suite.repo.Mock.On("Get", models.Paginator{Page: paginate.Page, Limit: paginate.Limit}, models.ContactFilter{}).
 		Return(&models.Paginator{}, expectedError)
 
 	_, err := suite.underTest.Get(paginate)
 
 	suite.Error(err)
 }
+
+func (suite *contactsTestSuite) TestGet_WhenFiltered() {
+	paginate := dto.Paginate{
+		Page:    1,
+		Limit:   10,
+		Search:  "juan",
+		Filters: []models.FieldFilter{{Field: "name", Value: "Ju", Prefix: true}},
+		Sort:    []models.SortField{{Field: "name"}, {Field: "id", Desc: true}},
+	}
+	filter := models.ContactFilter{
+		Search:  paginate.Search,
+		Filters: paginate.Filters,
+		Sort:    paginate.Sort,
+	}
+	suite.repo.Mock.On("Get", models.Paginator{Page: paginate.Page, Limit: paginate.Limit}, filter).
+		Return(&models.Paginator{}, nil)
+
+	_, err := suite.underTest.Get(paginate)
+
+	suite.NoError(err)
+}
+
+func (suite *contactsTestSuite) TestGet_WhenCursor() {
+	paginate := dto.Paginate{
+		Page:   1,
+		Limit:  10,
+		Cursor: &models.Cursor{Keys: []string{"10"}},
+	}
+	suite.repo.Mock.On("Get", models.Paginator{Page: 1, Limit: 10, Cursor: paginate.Cursor}, models.ContactFilter{}).
+		Return(&models.Paginator{}, nil)
+
+	_, err := suite.underTest.Get(paginate)
+
+	suite.NoError(err)
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenSuccess() {
+	patch, _ := dto.NewMergePatch([]byte(`{"name": "new name"}`))
+	expected := models.Contact{Name: "new name", PhoneNumber: "+573000000000", ID: 1}
+
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{
+		Name:           "test",
+		StructuredName: models.StructuredName{GivenName: "test"},
+		PhoneNumber:    "+573000000000",
+		ID:             1,
+		Version:        3,
+	}, nil)
+	suite.repo.Mock.On("Update", suite.origin, uint(1), models.Contact{
+		Name:           "new name",
+		StructuredName: models.StructuredName{GivenName: "new", FamilyName: "name"},
+		PhoneNumber:    "+573000000000",
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
+	}, uint(3)).Return(expected, nil)
+
+	contactModel, err := suite.underTest.Patch(suite.origin, uint(1), patch, uint(3))
+
+	suite.NoError(err)
+	suite.Equal(expected, contactModel)
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenPatchedContactIsInvalid() {
+	patch, _ := dto.NewMergePatch([]byte(`{"phone_number": null}`))
+
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{Name: "test", PhoneNumber: "+573000000000", ID: 1}, nil)
+
+	_, err := suite.underTest.Patch(suite.origin, uint(1), patch, uint(0))
+
+	suite.True(apperrors.Is(err, apperrors.KindValidation))
+	suite.repo.Mock.AssertNotCalled(suite.T(), "Update")
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenGetByIDFail() {
+	patch, _ := dto.NewMergePatch([]byte(`{"name": "new name"}`))
+	expectedError := errors.New("some error")
+
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{}, expectedError)
+
+	contactModel, err := suite.underTest.Patch(suite.origin, uint(1), patch, uint(0))
+
+	suite.Error(err)
+	suite.Equal(models.Contact{}, contactModel)
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenVersionDoesNotMatch() {
+	patch, _ := dto.NewMergePatch([]byte(`{"name": "new name"}`))
+
+	suite.repo.Mock.On("GetByID", uint(1)).
+		Return(models.Contact{Name: "test", PhoneNumber: "+573000000000", ID: 1, Version: 3}, nil)
+
+	_, err := suite.underTest.Patch(suite.origin, uint(1), patch, uint(2))
+
+	suite.True(apperrors.Is(err, apperrors.KindPreconditionFailed))
+	suite.repo.Mock.AssertNotCalled(suite.T(), "Update")
+}
+
+func (suite *contactsTestSuite) TestUpdate_WhenVersionIsSent() {
+	contact := dto.Contact{
+		Name:        "test",
+		PhoneNumber: "+573000000000",
+	}
+	expectedError := apperrors.PreconditionFailed("modified", nil)
+
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{Version: 3}, nil)
+	suite.repo.Mock.On("Update", suite.origin, uint(1), models.Contact{
+		Name:           contact.Name,
+		StructuredName: models.StructuredName{GivenName: "test"},
+		PhoneNumber:    contact.PhoneNumber,
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
+	}, uint(2)).Return(models.Contact{}, expectedError)
+
+	_, err := suite.underTest.Update(suite.origin, uint(1), contact, uint(2))
+
+	suite.ErrorIs(err, expectedError)
+}
+
+func (suite *contactsTestSuite) TestDelete_WhenVersionIsSent() {
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{Version: 2}, nil)
+	suite.repo.Mock.On("Delete", suite.origin, uint(1), uint(2)).Return(nil)
+
+	suite.NoError(suite.underTest.Delete(suite.origin, uint(1), uint(2)))
+}
+
+func (suite *contactsTestSuite) TestCreate_WhenFamilyNameFirst() {
+	suite.underTest = NewContacts(suite.repo, models.NameFormatFamilyFirst, suite.phones)
+	contact := dto.Contact{
+		GivenName:   "Juan",
+		FamilyName:  "Pérez",
+		PhoneNumber: "+573000000000",
+	}
+
+	expected := models.Contact{
+		Name:           "Pérez, Juan",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Pérez"},
+		PhoneNumber:    "+573000000000",
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
+	}
+
+	suite.repo.Mock.On("Create", suite.origin, expected).Return(expected, nil)
+
+	contactModel, err := suite.underTest.Create(suite.origin, contact)
+
+	suite.NoError(err)
+	suite.Equal(expected, contactModel)
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenNameComponentIsPatched() {
+	patch, _ := dto.NewMergePatch([]byte(`{"family_name": "Gómez"}`))
+	expected := models.Contact{
+		Name:           "Juan Gómez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Gómez"},
+		PhoneNumber:    "+573000000000",
+		PhoneDisplay:   "+573000000000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
+	}
+
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{
+		ID:             1,
+		Name:           "Juan Pérez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Pérez"},
+		PhoneNumber:    "+573000000000",
+		Version:        1,
+	}, nil)
+	suite.repo.Mock.On("Update", suite.origin, uint(1), expected, uint(1)).Return(expected, nil)
+
+	contactModel, err := suite.underTest.Patch(suite.origin, uint(1), patch, uint(0))
+
+	suite.NoError(err)
+	suite.Equal(expected, contactModel)
+}
+
+func (suite *contactsTestSuite) TestCreate_WhenPhoneNumberIsInvalid() {
+	contact := dto.Contact{
+		Name:        "test",
+		PhoneNumber: "+570000000",
+	}
+
+	_, err := suite.underTest.Create(suite.origin, contact)
+
+	suite.True(apperrors.Is(err, apperrors.KindValidation))
+	suite.repo.Mock.AssertNotCalled(suite.T(), "Create")
+}
+
+func (suite *contactsTestSuite) TestGet_WhenPhoneNumberIsFormatted() {
+	paginate := dto.Paginate{
+		Page:    1,
+		Limit:   10,
+		Filters: []models.FieldFilter{{Field: "phone_number", Value: "(300) 000 0000"}},
+	}
+	filter := models.ContactFilter{
+		Filters: []models.FieldFilter{{Field: "phone_number", Value: "+573000000000"}},
+	}
+	suite.repo.Mock.On("Get", models.Paginator{Page: 1, Limit: 10}, filter).
+		Return(&models.Paginator{}, nil)
+
+	_, err := suite.underTest.Get(paginate)
+
+	suite.NoError(err)
+}
+
+func (suite *contactsTestSuite) TestExport_WhenSuccess() {
+	paginate := dto.Paginate{
+		Search:  "juan",
+		Filters: []models.FieldFilter{{Field: "phone_number", Value: "(300) 000 0000"}},
+		Sort:    []models.SortField{{Field: "name"}},
+	}
+	filter := models.ContactFilter{
+		Search:  "juan",
+		Filters: []models.FieldFilter{{Field: "phone_number", Value: "+573000000000"}},
+		Sort:    paginate.Sort,
+	}
+	batch := []models.Contact{{ID: 1, Name: "juan"}}
+
+	suite.repo.Mock.On("Export", filter, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		suite.NoError(args.Get(1).(func([]models.Contact) error)(batch))
+	})
+
+	var written []models.Contact
+	err := suite.underTest.Export(paginate, func(contacts []models.Contact) error {
+		written = append(written, contacts...)
+		return nil
+	})
+
+	suite.NoError(err)
+	suite.Equal(batch, written)
+}
+
+func (suite *contactsTestSuite) TestExport_WhenFail() {
+	expectedError := errors.New("some error")
+
+	suite.repo.Mock.On("Export", models.ContactFilter{}, mock.Anything).Return(expectedError)
+
+	err := suite.underTest.Export(dto.Paginate{}, func(contacts []models.Contact) error { return nil })
+
+	suite.ErrorIs(err, expectedError)
+}
+
+func (suite *contactsTestSuite) TestGetTrash_WhenSuccess() {
+	paginate := dto.Paginate{
+		Page:  1,
+		Limit: 10,
+	}
+	expected := &models.Paginator{Page: 1, Limit: 10, Records: []models.Contact{{ID: 1}}}
+
+	suite.repo.Mock.On("GetTrash", models.Paginator{Page: paginate.Page, Limit: paginate.Limit}).
+		Return(expected, nil)
+
+	trash, err := suite.underTest.GetTrash(paginate)
+
+	suite.NoError(err)
+	suite.Equal(expected, trash)
+}
+
+func (suite *contactsTestSuite) TestGetTrash_WhenFail() {
+	expectedError := errors.New("some error")
+
+	suite.repo.Mock.On("GetTrash", models.Paginator{Page: 1, Limit: 10}).
+		Return(nil, expectedError)
+
+	_, err := suite.underTest.GetTrash(dto.Paginate{Page: 1, Limit: 10})
+
+	suite.Error(err)
+}
+
+func (suite *contactsTestSuite) TestRestore_WhenSuccess() {
+	expected := models.Contact{ID: 1, Name: "test", PhoneNumber: "+573000000000", Version: 2}
+
+	suite.repo.Mock.On("Restore", suite.origin, uint(1)).Return(expected, nil)
+
+	contact, err := suite.underTest.Restore(suite.origin, uint(1))
+
+	suite.NoError(err)
+	suite.Equal(expected, contact)
+}
+
+func (suite *contactsTestSuite) TestRestore_WhenPhoneNumberIsTaken() {
+	expectedError := apperrors.Conflict("the number is taken", nil)
+
+	suite.repo.Mock.On("Restore", suite.origin, uint(1)).Return(models.Contact{}, expectedError)
+
+	_, err := suite.underTest.Restore(suite.origin, uint(1))
+
+	suite.ErrorIs(err, expectedError)
+}
+
+func (suite *contactsTestSuite) TestPurge_WhenSuccess() {
+	suite.repo.Mock.On("Purge", suite.origin, uint(1)).Return(nil)
+
+	suite.NoError(suite.underTest.Purge(suite.origin, uint(1)))
+}
+
+func (suite *contactsTestSuite) TestPurge_WhenFail() {
+	suite.repo.Mock.On("Purge", suite.origin, uint(1)).Return(errors.New("some error"))
+
+	suite.Error(suite.underTest.Purge(suite.origin, uint(1)))
+}
+
+func (suite *contactsTestSuite) TestGetAsOf_WhenSuccess() {
+	at := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	expected := models.Contact{ID: 1, Name: "old name", PhoneNumber: "+573000000000", Version: 2}
+
+	suite.repo.Mock.On("GetRevisionAt", uint(1), at).
+		Return(models.Revision{ContactID: 1, Version: 2, Contact: expected}, nil)
+
+	contact, err := suite.underTest.GetAsOf(uint(1), at)
+
+	suite.NoError(err)
+	suite.Equal(expected, contact)
+}
+
+func (suite *contactsTestSuite) TestGetAsOf_WhenContactDidNotExist() {
+	at := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.repo.Mock.On("GetRevisionAt", uint(1), at).
+		Return(models.Revision{}, apperrors.NotFound("the contact: 1 did not exist", nil))
+
+	_, err := suite.underTest.GetAsOf(uint(1), at)
+
+	suite.True(apperrors.Is(err, apperrors.KindNotFound))
+}
+
+func (suite *contactsTestSuite) TestGetRevisions_WhenSuccess() {
+	suite.repo.Mock.On("GetRevisions", uint(1), models.Paginator{Page: 1, Limit: 10}).
+		Return(&models.Paginator{}, nil)
+
+	_, err := suite.underTest.GetRevisions(uint(1), dto.Paginate{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+}
+
+func (suite *contactsTestSuite) TestRevert_WhenSuccess() {
+	previous := models.Contact{
+		ID:             1,
+		Name:           "Juan Pérez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Pérez"},
+		PhoneNumber:    "+573000000000",
+		PhoneDisplay:   "300 000 0000",
+		Version:        2,
+	}
+	expected := models.Contact{ID: 1, Name: "Juan Pérez", Version: 5}
+
+	suite.repo.Mock.On("GetRevision", uint(1), uint(2)).
+		Return(models.Revision{ContactID: 1, Version: 2, Contact: previous}, nil)
+	suite.repo.Mock.On("Revert", suite.origin, uint(1), models.Contact{
+		Name:           "Juan Pérez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Pérez"},
+		PhoneNumber:    "+573000000000",
+		PhoneDisplay:   "300 000 0000",
+		PhoneRegion:    "CO",
+		PhoneType:      "mobile",
+	}, uint(4)).Return(expected, nil)
+
+	contact, err := suite.underTest.Revert(suite.origin, uint(1), uint(2), uint(4))
+
+	suite.NoError(err)
+	suite.Equal(expected, contact)
+}
+
+func (suite *contactsTestSuite) TestRevert_WhenRevisionIsNoLongerValid() {
+	suite.repo.Mock.On("GetRevision", uint(1), uint(2)).
+		Return(models.Revision{ContactID: 1, Version: 2, Contact: models.Contact{ID: 1, Name: "Juan"}}, nil)
+
+	_, err := suite.underTest.Revert(suite.origin, uint(1), uint(2), uint(0))
+
+	suite.True(apperrors.Is(err, apperrors.KindValidation))
+	suite.repo.AssertNotCalled(suite.T(), "Revert")
+}
+
+func (suite *contactsTestSuite) TestRevert_WhenRevisionDoesNotExist() {
+	suite.repo.Mock.On("GetRevision", uint(1), uint(9)).
+		Return(models.Revision{}, apperrors.NotFound("the contact: 1 has no revision 9", nil))
+
+	_, err := suite.underTest.Revert(suite.origin, uint(1), uint(9), uint(0))
+
+	suite.True(apperrors.Is(err, apperrors.KindNotFound))
+}
+
+func (suite *contactsTestSuite) TestSearch_WhenSuccess() {
+	expected := &models.Paginator{Page: 1, Limit: 10, Records: []models.SearchResult{{Contact: models.Contact{ID: 1}}}}
+
+	suite.repo.Mock.On("Search", models.Paginator{Page: 1, Limit: 10}, "perez").Return(expected, nil)
+
+	results, err := suite.underTest.Search("perez", dto.Paginate{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal(expected, results)
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/duplicates"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Duplicates returns the clusters of contacts that look like the same person,
+// made of the pairs scoring at least minScore, the best clusters first.
+func (app *contacts) Duplicates(minScore float64) ([]models.DuplicateCluster, error) {
+	var all []models.Contact
+
+	err := app.repo.Export(models.ContactFilter{}, func(contacts []models.Contact) error {
+		all = append(all, contacts...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return duplicates.Find(all, minScore), nil
+}
+
+// Merge merges the contacts of merge into its survivor, which keeps its id. A
+// version other than 0 is the version of the survivor the client last read.
+func (app *contacts) Merge(origin models.Origin, merge dto.Merge, version uint) (models.Contact, error) {
+	survivor, err := app.GetByID(merge.SurvivorID)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	if version != 0 && survivor.Version != version {
+		return models.Contact{}, apperrors.PreconditionFailed(
+			fmt.Sprintf("the contact: %v was modified by someone else, reload it and try again", survivor.ID), nil)
+	}
+
+	others := make([]models.Contact, len(merge.ContactIDs))
+	for i, id := range merge.ContactIDs {
+		if others[i], err = app.GetByID(id); err != nil {
+			return models.Contact{}, err
+		}
+	}
+
+	merged := duplicates.Merge(survivor, others, merge.Picks())
+
+	return app.repo.Merge(origin, models.Merge{Survivor: merged, Merged: others})
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *contactsTestSuite) TestDuplicates_WhenSuccess() {
+	suite.repo.Mock.On("Export", models.ContactFilter{}, mock.Anything).Run(func(args mock.Arguments) {
+		write := args.Get(1).(func(contacts []models.Contact) error)
+
+		suite.NoError(write([]models.Contact{
+			{ID: 1, Name: "Juan Pérez", PhoneNumber: "+573000000000"},
+			{ID: 2, Name: "Ana Gómez", PhoneNumber: "+573100000000"},
+		}))
+		suite.NoError(write([]models.Contact{{ID: 3, Name: "juan perez", PhoneNumber: "+573000000000"}}))
+	}).Return(nil)
+
+	clusters, err := suite.underTest.Duplicates(0.6)
+
+	suite.NoError(err)
+	suite.Len(clusters, 1)
+	suite.Equal(uint(1), clusters[0].Contacts[0].ID)
+	suite.Equal(uint(3), clusters[0].Contacts[1].ID)
+}
+
+func (suite *contactsTestSuite) TestDuplicates_WhenFail() {
+	suite.repo.Mock.On("Export", models.ContactFilter{}, mock.Anything).Return(errors.New("connection refused"))
+
+	_, err := suite.underTest.Duplicates(0.6)
+
+	suite.Error(err)
+}
+
+func (suite *contactsTestSuite) TestMerge_WhenSuccess() {
+	survivor := models.Contact{ID: 1, Version: 2, Name: "Juan", PhoneNumber: "+573000000000"}
+	other := models.Contact{ID: 3, Version: 1, Name: "Juan Pérez", PhoneNumber: "+573100000000", Notes: "friend"}
+	expected := models.Contact{ID: 1, Version: 3}
+
+	suite.repo.Mock.On("GetByID", uint(1)).Return(survivor, nil)
+	suite.repo.Mock.On("GetByID", uint(3)).Return(other, nil)
+	suite.repo.Mock.On("Merge", suite.origin, mock.MatchedBy(func(merge models.Merge) bool {
+		return merge.Survivor.ID == 1 && merge.Survivor.Name == "Juan Pérez" && merge.Survivor.Notes == "friend" &&
+			merge.Survivor.PhoneNumber == "+573000000000" && len(merge.Survivor.Phones) == 1 &&
+			len(merge.Merged) == 1 && merge.Merged[0].Version == 1
+	})).Return(expected, nil)
+
+	contact, err := suite.underTest.Merge(suite.origin, dto.Merge{
+		SurvivorID: 1,
+		ContactIDs: []uint{3},
+		Fields:     map[string]uint{"name": 3},
+	}, 2)
+
+	suite.NoError(err)
+	suite.Equal(expected, contact)
+}
+
+func (suite *contactsTestSuite) TestMerge_WhenSurvivorModified() {
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{ID: 1, Version: 3}, nil)
+
+	_, err := suite.underTest.Merge(suite.origin, dto.Merge{SurvivorID: 1, ContactIDs: []uint{3}}, 2)
+
+	suite.True(apperrors.Is(err, apperrors.KindPreconditionFailed))
+	suite.repo.AssertNotCalled(suite.T(), "Merge", mock.Anything, mock.Anything)
+}
+
+func (suite *contactsTestSuite) TestMerge_WhenMergedNotFound() {
+	suite.repo.Mock.On("GetByID", uint(1)).Return(models.Contact{ID: 1, Version: 2}, nil)
+	suite.repo.Mock.On("GetByID", uint(3)).Return(models.Contact{},
+		apperrors.NotFound("the contact: 3 does not exist", nil))
+
+	_, err := suite.underTest.Merge(suite.origin, dto.Merge{SurvivorID: 1, ContactIDs: []uint{3}}, 0)
+
+	suite.True(apperrors.Is(err, apperrors.KindNotFound))
+}
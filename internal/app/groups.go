@@ -0,0 +1,61 @@
+package app
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type Groups interface {
+	Create(group dto.Group) (models.Group, error)
+	GetByID(id uint) (models.Group, error)
+	Update(id uint, group dto.Group) (models.Group, error)
+	Delete(id uint) error
+	Get() ([]models.Group, error)
+	AddMembers(id uint, members dto.GroupMembers) error
+	RemoveMembers(id uint, members dto.GroupMembers) error
+	Members(id uint) ([]models.Contact, error)
+}
+
+type groups struct {
+	repo repository.Groups
+}
+
+func NewGroups(repo repository.Groups) Groups {
+	return &groups{
+		repo,
+	}
+}
+
+func (app *groups) Create(group dto.Group) (models.Group, error) {
+	return app.repo.Create(group.ToModel())
+}
+
+func (app *groups) GetByID(id uint) (models.Group, error) {
+	return app.repo.GetByID(id)
+}
+
+func (app *groups) Update(id uint, group dto.Group) (models.Group, error) {
+	return app.repo.Update(id, group.ToModel())
+}
+
+func (app *groups) Delete(id uint) error {
+	return app.repo.Delete(id)
+}
+
+func (app *groups) Get() ([]models.Group, error) {
+	return app.repo.Get()
+}
+
+func (app *groups) AddMembers(id uint, members dto.GroupMembers) error {
+	return app.repo.AddMembers(id, members.UniqueContactIDs(), members.UniqueGroupIDs())
+}
+
+func (app *groups) RemoveMembers(id uint, members dto.GroupMembers) error {
+	return app.repo.RemoveMembers(id, members.UniqueContactIDs(), members.UniqueGroupIDs())
+}
+
+// Members resolves the group to every contact reachable through it.
+func (app *groups) Members(id uint) ([]models.Contact, error) {
+	return app.repo.Members(id)
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type groupsTestSuite struct {
+	suite.Suite
+	repo      *mocks.Groups
+	underTest Groups
+}
+
+func TestGroupsSuite(t *testing.T) {
+	suite.Run(t, new(groupsTestSuite))
+}
+
+func (suite *groupsTestSuite) SetupTest() {
+	suite.repo = &mocks.Groups{}
+	suite.underTest = NewGroups(suite.repo)
+}
+
+func (suite *groupsTestSuite) TestCreate_WhenSuccess() {
+	expected := models.Group{ID: 1, Name: "Bogotá clients"}
+
+	suite.repo.Mock.On("Create", models.Group{Name: "Bogotá clients"}).Return(expected, nil)
+
+	group, err := suite.underTest.Create(dto.Group{Name: "Bogotá clients "})
+
+	suite.NoError(err)
+	suite.Equal(expected, group)
+}
+
+func (suite *groupsTestSuite) TestUpdate_WhenFail() {
+	suite.repo.Mock.On("Update", uint(1), models.Group{Name: "clients"}).
+		Return(models.Group{}, errors.New("some error"))
+
+	_, err := suite.underTest.Update(uint(1), dto.Group{Name: "clients"})
+
+	suite.Error(err)
+}
+
+func (suite *groupsTestSuite) TestAddMembers_WhenRepeatedValues() {
+	suite.repo.Mock.On("AddMembers", uint(1), []uint{3, 4}, []uint{2}).Return(nil)
+
+	suite.NoError(suite.underTest.AddMembers(uint(1), dto.GroupMembers{
+		ContactIDs: []uint{3, 4, 3},
+		GroupIDs:   []uint{2, 2},
+	}))
+}
+
+func (suite *groupsTestSuite) TestAddMembers_WhenCycle() {
+	expectedError := apperrors.Conflict("cycle", nil)
+
+	suite.repo.Mock.On("AddMembers", uint(1), []uint{}, []uint{2}).Return(expectedError)
+
+	err := suite.underTest.AddMembers(uint(1), dto.GroupMembers{GroupIDs: []uint{2}})
+
+	suite.ErrorIs(err, expectedError)
+}
+
+func (suite *groupsTestSuite) TestRemoveMembers_WhenSuccess() {
+	suite.repo.Mock.On("RemoveMembers", uint(1), []uint{3}, []uint{}).Return(nil)
+
+	suite.NoError(suite.underTest.RemoveMembers(uint(1), dto.GroupMembers{ContactIDs: []uint{3}}))
+}
+
+func (suite *groupsTestSuite) TestMembers_WhenSuccess() {
+	expected := []models.Contact{{ID: 3}, {ID: 4}}
+
+	suite.repo.Mock.On("Members", uint(1)).Return(expected, nil)
+
+	contacts, err := suite.underTest.Members(uint(1))
+
+	suite.NoError(err)
+	suite.Equal(expected, contacts)
+}
+
+func (suite *groupsTestSuite) TestDelete_WhenFail() {
+	suite.repo.Mock.On("Delete", uint(1)).Return(errors.New("some error"))
+
+	suite.Error(suite.underTest.Delete(uint(1)))
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+// IdempotencyConfig sets how long the responses of requests sent with an
+// idempotency key are replayed. MaxSize bounds, in bytes, the bodies of those requests and of the responses kept.
+// LockTimeout is how long a request holds its key while processed, a retry
+// after it processes the request again.
+type IdempotencyConfig struct {
+	TTL         time.Duration
+	MaxSize     int64
+	LockTimeout time.Duration
+}
+
+// Idempotency keeps the responses of the requests sent with an idempotency key,
+// so their retries get the same response instead of being processed again.
+type Idempotency interface {
+	Begin(key string, fingerprint string) (*models.IdempotentRequest, error)
+	Complete(key string, status int, header map[string][]string, body []byte) error
+}
+
+type idempotency struct {
+	repo   repository.Idempotency
+	config IdempotencyConfig
+	now    func() time.Time
+}
+
+func NewIdempotency(repo repository.Idempotency, config IdempotencyConfig) Idempotency {
+	return &idempotency{
+		repo,
+		config,
+		time.Now,
+	}
+}
+
+// Begin reserves key for the request identified by fingerprint. It returns the
+// stored request, whose response must be replayed, when key was already used by
+// the same request, and nil when the request has to be processed. Reusing key
+// for another request is unprocessable and retrying a request that is still
+// being processed, within LockTimeout, is a conflict.
+func (app *idempotency) Begin(key string, fingerprint string) (*models.IdempotentRequest, error) {
+	now := app.now()
+
+	stored, reserved, err := app.repo.Reserve(models.IdempotentRequest{
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(app.config.TTL),
+		LockedUntil: now.Add(app.config.LockTimeout),
+	}, now)
+	if err != nil || reserved {
+		return nil, err
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, apperrors.Unprocessable(
+			fmt.Sprintf("the Idempotency-Key %s was already used for another request", key), nil)
+	}
+
+	if stored.Status == 0 {
+		return nil, apperrors.Conflict(
+			fmt.Sprintf("the request with the Idempotency-Key %s is still being processed", key), nil)
+	}
+
+	return &stored, nil
+}
+
+// Complete keeps the response of the request that reserved key. Server errors
+// are not kept: the key is released so a retry is processed again.
+func (app *idempotency) Complete(key string, status int, header map[string][]string, body []byte) error {
+	if status >= http.StatusInternalServerError {
+		return app.repo.Release(key)
+	}
+
+	return app.repo.Complete(models.IdempotentRequest{Key: key, Status: status, Header: header, Body: body})
+}
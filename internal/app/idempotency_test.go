@@ -0,0 +1,98 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type idempotencyTestSuite struct {
+	suite.Suite
+	repo      *mocks.Idempotency
+	now       time.Time
+	underTest *idempotency
+}
+
+func TestIdempotencySuite(t *testing.T) {
+	suite.Run(t, new(idempotencyTestSuite))
+}
+
+func (suite *idempotencyTestSuite) SetupTest() {
+	suite.repo = &mocks.Idempotency{}
+	suite.now = time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)
+	suite.underTest = NewIdempotency(suite.repo, IdempotencyConfig{TTL: time.Hour, LockTimeout: time.Minute}).(*idempotency)
+	suite.underTest.now = func() time.Time { return suite.now }
+}
+
+func (suite *idempotencyTestSuite) reservation() models.IdempotentRequest {
+	return models.IdempotentRequest{Key: "key-1", Fingerprint: "abc", ExpiresAt: suite.now.Add(time.Hour),
+		LockedUntil: suite.now.Add(time.Minute)}
+}
+
+func (suite *idempotencyTestSuite) TestBegin_WhenReserved() {
+	suite.repo.Mock.On("Reserve", suite.reservation(), suite.now).Return(suite.reservation(), true, nil)
+
+	stored, err := suite.underTest.Begin("key-1", "abc")
+
+	suite.NoError(err)
+	suite.Nil(stored)
+}
+
+func (suite *idempotencyTestSuite) TestBegin_WhenReplayed() {
+	completed := models.IdempotentRequest{Key: "key-1", Fingerprint: "abc", Status: 200, Body: []byte("{}")}
+
+	suite.repo.Mock.On("Reserve", suite.reservation(), suite.now).Return(completed, false, nil)
+
+	stored, err := suite.underTest.Begin("key-1", "abc")
+
+	suite.NoError(err)
+	suite.Equal(&completed, stored)
+}
+
+func (suite *idempotencyTestSuite) TestBegin_WhenKeyIsReused() {
+	suite.repo.Mock.On("Reserve", suite.reservation(), suite.now).
+		Return(models.IdempotentRequest{Key: "key-1", Fingerprint: "def", Status: 200}, false, nil)
+
+	_, err := suite.underTest.Begin("key-1", "abc")
+
+	suite.True(apperrors.Is(err, apperrors.KindUnprocessable))
+}
+
+func (suite *idempotencyTestSuite) TestBegin_WhenInProgress() {
+	suite.repo.Mock.On("Reserve", suite.reservation(), suite.now).
+		Return(models.IdempotentRequest{Key: "key-1", Fingerprint: "abc"}, false, nil)
+
+	_, err := suite.underTest.Begin("key-1", "abc")
+
+	suite.True(apperrors.Is(err, apperrors.KindConflict))
+}
+
+func (suite *idempotencyTestSuite) TestBegin_WhenFail() {
+	suite.repo.Mock.On("Reserve", suite.reservation(), suite.now).
+		Return(models.IdempotentRequest{}, false, errors.New("connection refused"))
+
+	_, err := suite.underTest.Begin("key-1", "abc")
+
+	suite.Error(err)
+}
+
+func (suite *idempotencyTestSuite) TestComplete_WhenSuccess() {
+	header := map[string][]string{"Content-Type": {"application/json"}}
+
+	suite.repo.Mock.On("Complete", models.IdempotentRequest{Key: "key-1", Status: 201, Header: header, Body: []byte("{}")}).
+		Return(nil)
+
+	suite.NoError(suite.underTest.Complete("key-1", 201, header, []byte("{}")))
+}
+
+func (suite *idempotencyTestSuite) TestComplete_WhenServerError() {
+	suite.repo.Mock.On("Release", "key-1").Return(nil)
+
+	suite.NoError(suite.underTest.Complete("key-1", 503, nil, nil))
+	suite.repo.Mock.AssertNotCalled(suite.T(), "Complete")
+}
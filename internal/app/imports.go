@@ -0,0 +1,150 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// importedContact is a row of an import that passed the validation.
+type importedContact struct {
+	row     int
+	contact models.Contact
+}
+
+// Import saves the contacts read from an imported file one by one, so a rejected
+// row does not stop the others. Rows are validated as if they were created
+// through the API; the ones repeating a phone number of a previous row are
+// skipped and the ones whose phone number belongs to a saved contact are skipped
+// or update it, as options say. Rows are reported in the order of the file.
+// Unexpected errors stop the import, leaving the rows already saved: the error
+// is returned along with the report of the rows up to the one that failed.
+func (app *contacts) Import(origin models.Origin, rows []dto.ImportRow, options dto.ImportOptions) (dto.ImportReport, error) {
+	results := make(map[int]dto.ImportResult, len(rows))
+	firstRows := map[string]int{}
+
+	var imported []importedContact
+	var numbers []string
+
+	for _, row := range rows {
+		if err := row.Contact.Validate(); err != nil {
+			results[row.Row] = dto.ImportFailure(row.Row, err)
+			continue
+		}
+
+		contact, err := app.toModel(row.Contact)
+		if err != nil {
+			results[row.Row] = dto.ImportFailure(row.Row, err)
+			continue
+		}
+
+		if first, repeated := firstRows[contact.PhoneNumber]; repeated {
+			results[row.Row] = dto.ImportResult{Row: row.Row, Status: dto.ImportSkipped, Reasons: []string{
+				fmt.Sprintf("the phone number %s is repeated, it was imported from the row %d", contact.PhoneNumber, first),
+			}}
+
+			continue
+		}
+
+		firstRows[contact.PhoneNumber] = row.Row
+		numbers = append(numbers, contact.PhoneNumber)
+		imported = append(imported, importedContact{row: row.Row, contact: contact})
+	}
+
+	saved, err := app.repo.GetByPhoneNumbers(numbers)
+	if err != nil {
+		return dto.ImportReport{}, err
+	}
+
+	owners := make(map[string]uint, len(saved))
+	for _, contact := range saved {
+		owners[contact.PhoneNumber] = contact.ID
+	}
+
+	report := dto.ImportReport{DryRun: options.DryRun, Rows: []dto.ImportResult{}}
+
+	for i, row := range imported {
+		result, err := app.importContact(origin, row, owners[row.contact.PhoneNumber], options)
+		if err != nil {
+			report.StoppedAt = row.row
+			stopImport(results, imported[i:])
+
+			for _, row := range rows {
+				report.Add(results[row.Row])
+			}
+
+			return report, err
+		}
+
+		results[row.row] = result
+	}
+
+	for _, row := range rows {
+		report.Add(results[row.Row])
+	}
+
+	return report, nil
+}
+
+// stopImport reports as failed the rows left, the first of them being the one
+// an unexpected error stopped the import at.
+func stopImport(results map[int]dto.ImportResult, left []importedContact) {
+	stoppedAt := left[0].row
+
+	results[stoppedAt] = dto.ImportResult{Row: stoppedAt, Status: dto.ImportFailed, Reasons: []string{
+		"the contact could not be saved because of an unexpected error, the import stopped here",
+	}}
+
+	for _, row := range left[1:] {
+		results[row.row] = dto.ImportResult{Row: row.row, Status: dto.ImportFailed, Reasons: []string{
+			fmt.Sprintf("the import stopped at the row %d before reaching this row", stoppedAt),
+		}}
+	}
+}
+
+// importContact saves a row of an import, owner is the id of the contact that
+// already has its phone number or 0 when there is none.
+func (app *contacts) importContact(origin models.Origin, row importedContact, owner uint,
+	options dto.ImportOptions) (dto.ImportResult, error) {
+	result := dto.ImportResult{Row: row.row, Status: dto.ImportCreated, ContactID: owner}
+
+	if owner != 0 && options.OnDuplicate == dto.DuplicateSkip {
+		result.Status = dto.ImportSkipped
+		result.Reasons = []string{
+			fmt.Sprintf("the phone number %s belongs to the contact %d", row.contact.PhoneNumber, owner),
+		}
+
+		return result, nil
+	}
+
+	if owner != 0 {
+		result.Status = dto.ImportUpdated
+	}
+
+	if options.DryRun {
+		return result, nil
+	}
+
+	var contact models.Contact
+	var err error
+
+	if owner != 0 {
+		contact, err = app.repo.Update(origin, owner, row.contact, 0)
+	} else {
+		contact, err = app.repo.Create(origin, row.contact)
+	}
+
+	if apperrors.Is(err, apperrors.KindInternal) {
+		return dto.ImportResult{}, err
+	}
+
+	if err != nil {
+		return dto.ImportFailure(row.row, err), nil
+	}
+
+	result.ContactID = contact.ID
+
+	return result, nil
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *contactsTestSuite) importRows() []dto.ImportRow {
+	return []dto.ImportRow{
+		{Row: 2, Contact: dto.Contact{Name: "Juan", PhoneNumber: "300 000 0000"}},
+		{Row: 3, Contact: dto.Contact{Name: "Ana", PhoneNumber: "+573100000000"}},
+		{Row: 4, Contact: dto.Contact{Name: "Juan again", PhoneNumber: "+573000000000"}},
+		{Row: 5, Contact: dto.Contact{Name: "Luis"}},
+	}
+}
+
+func (suite *contactsTestSuite) TestImport_WhenDuplicatesAreSkipped() {
+	suite.repo.Mock.On("GetByPhoneNumbers", []string{"+573000000000", "+573100000000"}).
+		Return([]models.Contact{{ID: 7, PhoneNumber: "+573100000000"}}, nil)
+	suite.repo.Mock.On("Create", suite.origin, mock.MatchedBy(func(contact models.Contact) bool {
+		return contact.Name == "Juan" && contact.PhoneNumber == "+573000000000"
+	})).Return(models.Contact{ID: 9}, nil)
+
+	report, err := suite.underTest.Import(suite.origin, suite.importRows(), dto.ImportOptions{OnDuplicate: dto.DuplicateSkip})
+
+	suite.NoError(err)
+	suite.Equal(1, report.Created)
+	suite.Equal(2, report.Skipped)
+	suite.Equal(1, report.Failed)
+	suite.Equal(dto.ImportResult{Row: 2, Status: dto.ImportCreated, ContactID: 9}, report.Rows[0])
+	suite.Equal(dto.ImportResult{Row: 3, Status: dto.ImportSkipped, ContactID: 7, Reasons: []string{
+		"the phone number +573100000000 belongs to the contact 7",
+	}}, report.Rows[1])
+	suite.Equal(dto.ImportResult{Row: 4, Status: dto.ImportSkipped, Reasons: []string{
+		"the phone number +573000000000 is repeated, it was imported from the row 2",
+	}}, report.Rows[2])
+	suite.Equal(dto.ImportFailed, report.Rows[3].Status)
+	suite.Equal([]string{"phone_number is required"}, report.Rows[3].Reasons)
+}
+
+func (suite *contactsTestSuite) TestImport_WhenDuplicatesAreUpdated() {
+	suite.repo.Mock.On("GetByPhoneNumbers", []string{"+573000000000", "+573100000000"}).
+		Return([]models.Contact{{ID: 7, PhoneNumber: "+573100000000"}}, nil)
+	suite.repo.Mock.On("Create", suite.origin, mock.Anything).Return(models.Contact{ID: 9}, nil)
+	suite.repo.Mock.On("Update", suite.origin, uint(7), mock.MatchedBy(func(contact models.Contact) bool {
+		return contact.Name == "Ana"
+	}), uint(0)).Return(models.Contact{ID: 7}, nil)
+
+	report, err := suite.underTest.Import(suite.origin, suite.importRows(), dto.ImportOptions{OnDuplicate: dto.DuplicateUpdate})
+
+	suite.NoError(err)
+	suite.Equal(1, report.Created)
+	suite.Equal(1, report.Updated)
+	suite.Equal(dto.ImportResult{Row: 3, Status: dto.ImportUpdated, ContactID: 7}, report.Rows[1])
+}
+
+func (suite *contactsTestSuite) TestImport_WhenDryRun() {
+	suite.repo.Mock.On("GetByPhoneNumbers", []string{"+573000000000", "+573100000000"}).
+		Return([]models.Contact{{ID: 7, PhoneNumber: "+573100000000"}}, nil)
+
+	report, err := suite.underTest.Import(suite.origin, suite.importRows(),
+		dto.ImportOptions{DryRun: true, OnDuplicate: dto.DuplicateUpdate})
+
+	suite.NoError(err)
+	suite.True(report.DryRun)
+	suite.Equal(1, report.Created)
+	suite.Equal(1, report.Updated)
+	suite.repo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	suite.repo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *contactsTestSuite) TestImport_WhenSaveIsRejected() {
+	rows := []dto.ImportRow{{Row: 2, Contact: dto.Contact{Name: "Juan", PhoneNumber: "+573000000000"}}}
+
+	suite.repo.Mock.On("GetByPhoneNumbers", []string{"+573000000000"}).Return([]models.Contact{}, nil)
+	suite.repo.Mock.On("Create", suite.origin, mock.Anything).
+		Return(models.Contact{}, apperrors.Conflict("your contact number +573000000000 already exists", nil))
+
+	report, err := suite.underTest.Import(suite.origin, rows, dto.ImportOptions{OnDuplicate: dto.DuplicateSkip})
+
+	suite.NoError(err)
+	suite.Equal([]dto.ImportResult{{Row: 2, Status: dto.ImportFailed, Reasons: []string{
+		"your contact number +573000000000 already exists",
+	}}}, report.Rows)
+}
+
+func (suite *contactsTestSuite) TestImport_WhenSaveFails() {
+	rows := []dto.ImportRow{
+		{Row: 2, Contact: dto.Contact{Name: "Juan", PhoneNumber: "+573000000000"}},
+		{Row: 3, Contact: dto.Contact{Name: "Ana", PhoneNumber: "+573100000000"}},
+		{Row: 4, Contact: dto.Contact{Name: "Luis", PhoneNumber: "+573200000000"}},
+		{Row: 5, Contact: dto.Contact{Name: "Pedro"}},
+	}
+
+	suite.repo.Mock.On("GetByPhoneNumbers", []string{"+573000000000", "+573100000000", "+573200000000"}).
+		Return([]models.Contact{}, nil)
+	suite.repo.Mock.On("Create", suite.origin, mock.MatchedBy(func(contact models.Contact) bool {
+		return contact.Name == "Juan"
+	})).Return(models.Contact{ID: 9}, nil)
+	suite.repo.Mock.On("Create", suite.origin, mock.MatchedBy(func(contact models.Contact) bool {
+		return contact.Name == "Ana"
+	})).Return(models.Contact{}, apperrors.Internal(errors.New("connection reset")))
+
+	report, err := suite.underTest.Import(suite.origin, rows, dto.ImportOptions{OnDuplicate: dto.DuplicateSkip})
+
+	suite.Error(err)
+	suite.Equal(3, report.StoppedAt)
+	suite.Equal(1, report.Created)
+	suite.Equal(3, report.Failed)
+	suite.Equal(dto.ImportResult{Row: 2, Status: dto.ImportCreated, ContactID: 9}, report.Rows[0])
+	suite.Equal(dto.ImportResult{Row: 3, Status: dto.ImportFailed, Reasons: []string{
+		"the contact could not be saved because of an unexpected error, the import stopped here",
+	}}, report.Rows[1])
+	suite.Equal(dto.ImportResult{Row: 4, Status: dto.ImportFailed, Reasons: []string{
+		"the import stopped at the row 3 before reaching this row",
+	}}, report.Rows[2])
+	suite.Equal([]string{"phone_number is required"}, report.Rows[3].Reasons)
+	suite.repo.AssertNumberOfCalls(suite.T(), "Create", 2)
+}
+
+func (suite *contactsTestSuite) TestImport_WhenLookupFails() {
+	rows := []dto.ImportRow{{Row: 2, Contact: dto.Contact{Name: "Juan", PhoneNumber: "+573000000000"}}}
+
+	suite.repo.Mock.On("GetByPhoneNumbers", []string{"+573000000000"}).Return([]models.Contact(nil), errors.New("some error"))
+
+	report, err := suite.underTest.Import(suite.origin, rows, dto.ImportOptions{OnDuplicate: dto.DuplicateSkip})
+
+	suite.Error(err)
+	suite.Zero(report.StoppedAt)
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+	"github.com/labstack/gommon/log"
+)
+
+// purgerActor is the actor of the purges audited by the Purger.
+const purgerActor = "system:purger"
+
+// TrashConfig sets how long deleted contacts stay in the trash and how often the
+// expired ones, and the expired idempotent requests, are purged. A Retention of
+// 0 keeps deleted contacts forever.
+type TrashConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+// Purger deletes for good the contacts that stayed in the trash longer than the
+// retention and the idempotent requests whose responses are no longer replayed.
+type Purger interface {
+	Run(ctx context.Context)
+	PurgeExpired() (int64, error)
+	PurgeExpiredRequests() (int64, error)
+}
+
+type purger struct {
+	repo     repository.Contacts
+	requests repository.Idempotency
+	config   TrashConfig
+	now      func() time.Time
+}
+
+func NewPurger(repo repository.Contacts, requests repository.Idempotency, config TrashConfig) Purger {
+	return &purger{
+		repo,
+		requests,
+		config,
+		time.Now,
+	}
+}
+
+// Run purges every PurgeInterval until ctx is done. The trash is only purged
+// when the retention is set, and it returns at once when the interval is not.
+func (purger *purger) Run(ctx context.Context) {
+	if purger.config.PurgeInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(purger.config.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if purger.config.Retention > 0 {
+			purged, err := purger.PurgeExpired()
+			if err != nil {
+				log.Errorf("purging the trash: %v", err)
+			} else if purged > 0 {
+				log.Infof("purged %d contacts from the trash", purged)
+			}
+		}
+
+		purged, err := purger.PurgeExpiredRequests()
+		if err != nil {
+			log.Errorf("purging the idempotent requests: %v", err)
+		} else if purged > 0 {
+			log.Infof("purged %d expired idempotent requests", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (purger *purger) PurgeExpired() (int64, error) {
+	return purger.repo.PurgeDeletedBefore(models.Origin{Actor: purgerActor},
+		purger.now().Add(-purger.config.Retention))
+}
+
+func (purger *purger) PurgeExpiredRequests() (int64, error) {
+	return purger.requests.PurgeExpired(purger.now())
+}
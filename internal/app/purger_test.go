@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type purgerTestSuite struct {
+	suite.Suite
+	repo      *mocks.Contacts
+	requests  *mocks.Idempotency
+	now       time.Time
+	underTest *purger
+}
+
+func TestPurgerSuite(t *testing.T) {
+	suite.Run(t, new(purgerTestSuite))
+}
+
+func (suite *purgerTestSuite) SetupTest() {
+	suite.repo = &mocks.Contacts{}
+	suite.requests = &mocks.Idempotency{}
+	suite.now = time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)
+	suite.underTest = NewPurger(suite.repo, suite.requests, TrashConfig{
+		Retention:     30 * 24 * time.Hour,
+		PurgeInterval: time.Hour,
+	}).(*purger)
+	suite.underTest.now = func() time.Time { return suite.now }
+}
+
+func (suite *purgerTestSuite) TestPurgeExpired_WhenSuccess() {
+	suite.repo.Mock.On("PurgeDeletedBefore", models.Origin{Actor: purgerActor}, time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)).
+		Return(int64(3), nil)
+
+	purged, err := suite.underTest.PurgeExpired()
+
+	suite.NoError(err)
+	suite.Equal(int64(3), purged)
+}
+
+func (suite *purgerTestSuite) TestPurgeExpired_WhenFail() {
+	suite.repo.Mock.On("PurgeDeletedBefore", models.Origin{Actor: purgerActor}, time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)).
+		Return(int64(0), errors.New("some error"))
+
+	_, err := suite.underTest.PurgeExpired()
+
+	suite.Error(err)
+}
+
+func (suite *purgerTestSuite) TestPurgeExpiredRequests_WhenSuccess() {
+	suite.requests.Mock.On("PurgeExpired", suite.now).Return(int64(2), nil)
+
+	purged, err := suite.underTest.PurgeExpiredRequests()
+
+	suite.NoError(err)
+	suite.Equal(int64(2), purged)
+}
+
+func (suite *purgerTestSuite) TestRun_WhenIntervalIsNotSet() {
+	suite.underTest.config.PurgeInterval = 0
+
+	suite.underTest.Run(context.Background())
+
+	suite.repo.AssertNotCalled(suite.T(), "PurgeDeletedBefore")
+	suite.requests.AssertNotCalled(suite.T(), "PurgeExpired")
+}
+
+func (suite *purgerTestSuite) TestRun_WhenRetentionIsNotSet() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	suite.underTest.config.Retention = 0
+	suite.requests.Mock.On("PurgeExpired", suite.now).
+		Run(func(_ mock.Arguments) { cancel() }).
+		Return(int64(0), nil).
+		Once()
+
+	suite.underTest.Run(ctx)
+
+	suite.repo.AssertNotCalled(suite.T(), "PurgeDeletedBefore")
+	suite.requests.AssertExpectations(suite.T())
+}
+
+func (suite *purgerTestSuite) TestRun_PurgesUntilCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	suite.repo.Mock.On("PurgeDeletedBefore", models.Origin{Actor: purgerActor}, time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)).
+		Return(int64(1), nil).
+		Once()
+	suite.requests.Mock.On("PurgeExpired", suite.now).
+		Run(func(_ mock.Arguments) { cancel() }).
+		Return(int64(1), nil).
+		Once()
+
+	suite.underTest.Run(ctx)
+
+	suite.repo.AssertExpectations(suite.T())
+	suite.requests.AssertExpectations(suite.T())
+}
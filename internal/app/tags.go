@@ -0,0 +1,55 @@
+package app
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type Tags interface {
+	Create(tag dto.Tag) (models.Tag, error)
+	GetByID(id uint) (models.Tag, error)
+	Update(origin models.Origin, id uint, tag dto.Tag) (models.Tag, error)
+	Delete(origin models.Origin, id uint) error
+	Get() ([]models.Tag, error)
+	Assign(origin models.Origin, assignment dto.TagAssignment) error
+	Unassign(origin models.Origin, assignment dto.TagAssignment) error
+}
+
+type tags struct {
+	repo repository.Tags
+}
+
+func NewTags(repo repository.Tags) Tags {
+	return &tags{
+		repo,
+	}
+}
+
+func (app *tags) Create(tag dto.Tag) (models.Tag, error) {
+	return app.repo.Create(tag.ToModel())
+}
+
+func (app *tags) GetByID(id uint) (models.Tag, error) {
+	return app.repo.GetByID(id)
+}
+
+func (app *tags) Update(origin models.Origin, id uint, tag dto.Tag) (models.Tag, error) {
+	return app.repo.Update(origin, id, tag.ToModel())
+}
+
+func (app *tags) Delete(origin models.Origin, id uint) error {
+	return app.repo.Delete(origin, id)
+}
+
+func (app *tags) Get() ([]models.Tag, error) {
+	return app.repo.Get()
+}
+
+func (app *tags) Assign(origin models.Origin, assignment dto.TagAssignment) error {
+	return app.repo.Assign(origin, assignment.UniqueContactIDs(), assignment.UniqueTags())
+}
+
+func (app *tags) Unassign(origin models.Origin, assignment dto.TagAssignment) error {
+	return app.repo.Unassign(origin, assignment.UniqueContactIDs(), assignment.UniqueTags())
+}
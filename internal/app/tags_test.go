@@ -0,0 +1,90 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type tagsTestSuite struct {
+	suite.Suite
+	repo      *mocks.Tags
+	origin    models.Origin
+	underTest Tags
+}
+
+func TestTagsSuite(t *testing.T) {
+	suite.Run(t, new(tagsTestSuite))
+}
+
+func (suite *tagsTestSuite) SetupTest() {
+	suite.repo = &mocks.Tags{}
+	suite.origin = models.Origin{Actor: "tester", RequestID: "request-1"}
+	suite.underTest = NewTags(suite.repo)
+}
+
+func (suite *tagsTestSuite) TestCreate_WhenSuccess() {
+	expected := models.Tag{ID: 1, Name: "family", Color: "#ff8800"}
+
+	suite.repo.Mock.On("Create", models.Tag{Name: "family", Color: "#ff8800"}).Return(expected, nil)
+
+	tag, err := suite.underTest.Create(dto.Tag{Name: " family ", Color: "#ff8800"})
+
+	suite.NoError(err)
+	suite.Equal(expected, tag)
+}
+
+func (suite *tagsTestSuite) TestCreate_WhenFail() {
+	suite.repo.Mock.On("Create", models.Tag{Name: "family"}).Return(models.Tag{}, errors.New("some error"))
+
+	_, err := suite.underTest.Create(dto.Tag{Name: "family"})
+
+	suite.Error(err)
+}
+
+func (suite *tagsTestSuite) TestUpdate_WhenSuccess() {
+	expected := models.Tag{ID: 1, Name: "friends"}
+
+	suite.repo.Mock.On("Update", suite.origin, uint(1), models.Tag{Name: "friends"}).Return(expected, nil)
+
+	tag, err := suite.underTest.Update(suite.origin, uint(1), dto.Tag{Name: "friends"})
+
+	suite.NoError(err)
+	suite.Equal(expected, tag)
+}
+
+func (suite *tagsTestSuite) TestGet_WhenSuccess() {
+	expected := []models.Tag{{ID: 1, Name: "family"}}
+
+	suite.repo.Mock.On("Get").Return(expected, nil)
+
+	tags, err := suite.underTest.Get()
+
+	suite.NoError(err)
+	suite.Equal(expected, tags)
+}
+
+func (suite *tagsTestSuite) TestDelete_WhenFail() {
+	suite.repo.Mock.On("Delete", suite.origin, uint(1)).Return(errors.New("some error"))
+
+	suite.Error(suite.underTest.Delete(suite.origin, uint(1)))
+}
+
+func (suite *tagsTestSuite) TestAssign_WhenRepeatedValues() {
+	suite.repo.Mock.On("Assign", suite.origin, []uint{1, 2}, []string{"family", "work"}).Return(nil)
+
+	suite.NoError(suite.underTest.Assign(suite.origin, dto.TagAssignment{
+		ContactIDs: []uint{1, 2, 1},
+		Tags:       []string{"family", "work", " family"},
+	}))
+}
+
+func (suite *tagsTestSuite) TestUnassign_WhenFail() {
+	suite.repo.Mock.On("Unassign", suite.origin, []uint{1}, []string{"family"}).Return(errors.New("some error"))
+
+	suite.Error(suite.underTest.Unassign(suite.origin, dto.TagAssignment{ContactIDs: []uint{1}, Tags: []string{"family"}}))
+}
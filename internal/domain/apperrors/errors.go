@@ -0,0 +1,145 @@
+package apperrors
+
+import (
+	"errors"
+)
+
+type Kind uint8
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindPreconditionFailed
+	KindFailedDependency
+	KindUnprocessable
+)
+
+func (kind Kind) String() string {
+	switch kind {
+	case KindNotFound:
+		return "not found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation"
+	case KindPreconditionFailed:
+		return "precondition failed"
+	case KindFailedDependency:
+		return "failed dependency"
+	case KindUnprocessable:
+		return "unprocessable"
+	default:
+		return "internal"
+	}
+}
+
+// FieldError describes why a single field of a request was rejected. Field is
+// the path of the field as the client sent it, e.g. "phone_number".
+type FieldError struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// Error is the error type shared by the repository, app and api layers. The Kind
+// decides how the error is reported to clients; Message is safe to expose and Err
+// keeps the original cause for logging and errors.Is/As.
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+	Fields  []FieldError
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return e.Kind.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(kind Kind, message string, err error) error {
+	return &Error{
+		Kind:    kind,
+		Message: message,
+		Err:     err,
+	}
+}
+
+func NotFound(message string, err error) error {
+	return New(KindNotFound, message, err)
+}
+
+func Conflict(message string, err error) error {
+	return New(KindConflict, message, err)
+}
+
+func Validation(message string, err error) error {
+	return New(KindValidation, message, err)
+}
+
+// InvalidFields is a validation error that carries the fields that were rejected.
+func InvalidFields(message string, err error, fields []FieldError) error {
+	return &Error{
+		Kind:    KindValidation,
+		Message: message,
+		Err:     err,
+		Fields:  fields,
+	}
+}
+
+func PreconditionFailed(message string, err error) error {
+	return New(KindPreconditionFailed, message, err)
+}
+
+// FailedDependency is the error of a change that was not made because another
+// change it goes along with failed.
+func FailedDependency(message string, err error) error {
+	return New(KindFailedDependency, message, err)
+}
+
+// Unprocessable is the error of a well formed request that can not be processed
+// as it conflicts with a previous one, like the reuse of an idempotency key.
+func Unprocessable(message string, err error) error {
+	return New(KindUnprocessable, message, err)
+}
+
+func Internal(err error) error {
+	return New(KindInternal, "", err)
+}
+
+// KindOf returns the Kind of the first *Error found in the chain of err, or
+// KindInternal when there is none.
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+
+	return KindInternal
+}
+
+// FieldsOf returns the rejected fields carried by err, if any.
+func FieldsOf(err error) []FieldError {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+
+	return nil
+}
+
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
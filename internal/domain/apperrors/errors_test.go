@@ -0,0 +1,49 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Error(t *testing.T) {
+	cause := errors.New("cause")
+
+	assert.Equal(t, "message", NotFound("message", cause).Error())
+	assert.Equal(t, "cause", Internal(cause).Error())
+	assert.Equal(t, "conflict", Conflict("", nil).Error())
+}
+
+func TestError_Unwrap(t *testing.T) {
+	cause := errors.New("cause")
+
+	assert.ErrorIs(t, Conflict("message", cause), cause)
+}
+
+func TestKindOf(t *testing.T) {
+	wrapped := fmt.Errorf("wrapped: %w", NotFound("message", nil))
+
+	assert.Equal(t, KindNotFound, KindOf(wrapped))
+	assert.Equal(t, KindValidation, KindOf(Validation("message", nil)))
+	assert.Equal(t, KindPreconditionFailed, KindOf(PreconditionFailed("message", nil)))
+	assert.Equal(t, KindFailedDependency, KindOf(FailedDependency("message", nil)))
+	assert.Equal(t, KindUnprocessable, KindOf(Unprocessable("message", nil)))
+	assert.Equal(t, KindInternal, KindOf(errors.New("some error")))
+}
+
+func TestFieldsOf(t *testing.T) {
+	fields := []FieldError{{Field: "name", Rule: "required", Message: "name is required"}}
+	err := InvalidFields("invalid fields", nil, fields)
+
+	assert.Equal(t, KindValidation, KindOf(err))
+	assert.Equal(t, fields, FieldsOf(err))
+	assert.Nil(t, FieldsOf(errors.New("some error")))
+}
+
+func TestIs(t *testing.T) {
+	assert.True(t, Is(Conflict("message", nil), KindConflict))
+	assert.False(t, Is(Conflict("message", nil), KindNotFound))
+	assert.False(t, Is(nil, KindInternal))
+}
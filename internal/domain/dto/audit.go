@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// AuditQuery holds the query params of an audit search, as sent by the client.
+type AuditQuery struct {
+	ContactID string
+	Actor     string
+	Action    string
+	RequestID string
+	From      string
+	To        string
+}
+
+// ToFilter parses the query. from and to are RFC 3339 timestamps and from can not
+// be after to.
+func (query AuditQuery) ToFilter() (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		Actor:     strings.TrimSpace(query.Actor),
+		RequestID: strings.TrimSpace(query.RequestID),
+	}
+
+	var err error
+
+	if query.ContactID != "" {
+		if filter.ContactID, err = ParseID("contact_id", query.ContactID); err != nil {
+			return models.AuditFilter{}, err
+		}
+	}
+
+	if query.Action != "" {
+		filter.Action = models.AuditAction(query.Action)
+		if !isAuditAction(filter.Action) {
+			return models.AuditFilter{}, invalidParam("action", "oneof",
+				fmt.Sprintf("action must be one of [%s], got %q", auditActions(), query.Action), nil)
+		}
+	}
+
+	if filter.From, err = ParseTime("from", query.From); err != nil {
+		return models.AuditFilter{}, err
+	}
+
+	if filter.To, err = ParseTime("to", query.To); err != nil {
+		return models.AuditFilter{}, err
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return models.AuditFilter{}, invalidParam("from", "ltefield", "from can not be after to", nil)
+	}
+
+	return filter, nil
+}
+
+func isAuditAction(action models.AuditAction) bool {
+	for _, current := range models.AuditActions {
+		if current == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func auditActions() string {
+	actions := make([]string, len(models.AuditActions))
+	for i, action := range models.AuditActions {
+		actions[i] = string(action)
+	}
+
+	return strings.Join(actions, " ")
+}
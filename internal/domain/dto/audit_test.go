@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditQuery_ToFilter(t *testing.T) {
+	filter, err := AuditQuery{
+		ContactID: "7",
+		Actor:     " ana ",
+		Action:    "update",
+		RequestID: "abc",
+		From:      "2024-03-01T00:00:00Z",
+		To:        "2024-03-31T23:59:59-05:00",
+	}.ToFilter()
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), filter.ContactID)
+	assert.Equal(t, "ana", filter.Actor)
+	assert.Equal(t, models.AuditUpdate, filter.Action)
+	assert.Equal(t, "abc", filter.RequestID)
+	assert.True(t, filter.From.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, filter.To.Equal(time.Date(2024, time.April, 1, 4, 59, 59, 0, time.UTC)))
+}
+
+func TestAuditQuery_ToFilter_WhenEmpty(t *testing.T) {
+	filter, err := AuditQuery{}.ToFilter()
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuditFilter{}, filter)
+}
+
+func TestAuditQuery_ToFilter_WhenInvalid(t *testing.T) {
+	tests := map[string]AuditQuery{
+		"contact_id": {ContactID: "abc"},
+		"action":     {Action: "rename"},
+		"from":       {From: "yesterday"},
+		"to":         {To: "2024-03-01"},
+		"from after": {From: "2024-03-02T00:00:00Z", To: "2024-03-01T00:00:00Z"},
+	}
+
+	for name, query := range tests {
+		_, err := query.ToFilter()
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Modes of a batch: atomic applies every operation or none of them and best
+// effort applies the operations that succeed.
+const (
+	BatchAtomic     = "atomic"
+	BatchBestEffort = "best_effort"
+)
+
+// BatchConfig bounds the number of operations of a batch.
+type BatchConfig struct {
+	MaxOperations int
+}
+
+// Batch is a list of writes sent in a single request, atomic unless Mode says
+// otherwise.
+type Batch struct {
+	Mode       string           `json:"mode,omitempty" validate:"omitempty,oneof=atomic best_effort" enums:"atomic,best_effort"`
+	Operations []BatchOperation `json:"operations" validate:"required,min=1"`
+}
+
+// Validate checks the batch but not its operations, which are validated one by
+// one so that each of them can fail on its own.
+func (batch Batch) Validate(config BatchConfig) error {
+	if err := validateStruct(batch); err != nil {
+		return err
+	}
+
+	if len(batch.Operations) > config.MaxOperations {
+		return invalidParam("operations", "max",
+			fmt.Sprintf("operations must be at most %d items", config.MaxOperations), nil)
+	}
+
+	return nil
+}
+
+func (batch Batch) Atomic() bool {
+	return batch.Mode != BatchBestEffort
+}
+
+// BatchOperation is a write of a batch. Creates carry a contact, updates an id
+// and a contact and deletes just an id. Version is optional and plays the role
+// of the If-Match header of single writes.
+type BatchOperation struct {
+	Op      string   `json:"op" validate:"required,oneof=create update delete" enums:"create,update,delete"`
+	ID      uint     `json:"id,omitempty" validate:"required_unless=Op create,excluded_if=Op create"`
+	Version uint     `json:"version,omitempty" validate:"excluded_if=Op create"`
+	Contact *Contact `json:"contact,omitempty" validate:"required_unless=Op delete,excluded_if=Op delete"`
+}
+
+func (operation BatchOperation) Validate() error {
+	return validateStruct(operation)
+}
+
+// BatchResult is the outcome of an operation of a batch. Status is the status
+// of the operation: 201, 200 and 204 for applied creates, updates and deletes,
+// the status of its problem otherwise. Operations of an atomic batch that were
+// not applied because another one failed get a 424.
+type BatchResult struct {
+	Index  int             `json:"index"`
+	Op     string          `json:"op" enums:"create,update,delete"`
+	Status int             `json:"status" example:"201"`
+	ID     uint            `json:"id,omitempty"`
+	Data   *models.Contact `json:"data,omitempty"`
+	Error  *Problem        `json:"error,omitempty"`
+}
+
+// BatchReport is the outcome of a batch, operation by operation.
+type BatchReport struct {
+	Mode      string        `json:"mode" enums:"atomic,best_effort"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+	Results   []BatchResult `json:"results"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch_Validate(t *testing.T) {
+	config := BatchConfig{MaxOperations: 2}
+	operation := BatchOperation{Op: "delete", ID: 1}
+
+	assert.NoError(t, Batch{Operations: []BatchOperation{operation}}.Validate(config))
+
+	err := Batch{Mode: "sometimes", Operations: []BatchOperation{operation}}.Validate(config)
+	assert.Equal(t, "mode", apperrors.FieldsOf(err)[0].Field)
+
+	err = Batch{}.Validate(config)
+	assert.Equal(t, "operations", apperrors.FieldsOf(err)[0].Field)
+
+	err = Batch{Operations: []BatchOperation{operation, operation, operation}}.Validate(config)
+	assert.Equal(t, apperrors.FieldError{
+		Field:   "operations",
+		Rule:    "max",
+		Message: "operations must be at most 2 items",
+	}, apperrors.FieldsOf(err)[0])
+}
+
+func TestBatch_Atomic(t *testing.T) {
+	assert.True(t, Batch{}.Atomic())
+	assert.True(t, Batch{Mode: BatchAtomic}.Atomic())
+	assert.False(t, Batch{Mode: BatchBestEffort}.Atomic())
+}
+
+func TestBatchOperation_Validate(t *testing.T) {
+	contact := &Contact{Name: "Juan", PhoneNumber: "3000000000"}
+
+	assert.NoError(t, BatchOperation{Op: "create", Contact: contact}.Validate())
+	assert.NoError(t, BatchOperation{Op: "update", ID: 1, Version: 2, Contact: contact}.Validate())
+	assert.NoError(t, BatchOperation{Op: "delete", ID: 1}.Validate())
+
+	err := BatchOperation{Op: "create", ID: 1, Version: 2}.Validate()
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "id", Rule: "excluded_if", Message: "id must be empty when op is create"},
+		{Field: "version", Rule: "excluded_if", Message: "version must be empty when op is create"},
+		{Field: "contact", Rule: "required_unless", Message: "contact is required unless op is delete"},
+	}, apperrors.FieldsOf(err))
+
+	err = BatchOperation{Op: "delete", Contact: contact}.Validate()
+	assert.Equal(t, []string{"id", "contact"}, fields(err))
+
+	err = BatchOperation{Op: "update", ID: 1, Contact: &Contact{Name: "Juan"}}.Validate()
+	assert.Equal(t, []string{"contact.phone_number"}, fields(err))
+
+	err = BatchOperation{Op: "merge"}.Validate()
+	assert.Equal(t, "op", apperrors.FieldsOf(err)[0].Field)
+}
+
+func fields(err error) []string {
+	var names []string
+	for _, field := range apperrors.FieldsOf(err) {
+		names = append(names, field.Field)
+	}
+
+	return names
+}
@@ -2,26 +2,211 @@ package dto
 
 import (
 	"github.com/AjxGnx/contacts-go/internal/domain/models"
-	"github.com/go-playground/validator/v10"
 )
 
+// Contact is the contact sent by clients. The name can be given as a whole in
+// Name, which is then split in its components, or by component, in which case
+// Name is ignored and rebuilt from them.
 type Contact struct {
-	Name        string `json:"name" validate:"required"`
-	PhoneNumber string `json:"phone_number" validate:"required"`
+	Name        string    `json:"name" validate:"contact_name,max=300"`
+	NamePrefix  string    `json:"name_prefix,omitempty" validate:"max=50" example:"Dr."`
+	GivenName   string    `json:"given_name,omitempty" validate:"max=100" example:"Juan"`
+	FamilyName  string    `json:"family_name,omitempty" validate:"max=100" example:"Pérez"`
+	NameSuffix  string    `json:"name_suffix,omitempty" validate:"max=50" example:"Jr."`
+	Nickname    string    `json:"nickname,omitempty" validate:"max=100" example:"Juancho"`
+	PhoneNumber string    `json:"phone_number" validate:"required"`
+	Phones      []Phone   `json:"phones,omitempty" validate:"max=20,one_primary,dive"`
+	Emails      []Email   `json:"emails,omitempty" validate:"max=20,one_primary,dive"`
+	Addresses   []Address `json:"addresses,omitempty" validate:"max=10,one_primary,dive"`
+	Company     string    `json:"company,omitempty" validate:"max=200" example:"Acme"`
+	Notes       string    `json:"notes,omitempty" validate:"max=5000"`
+	Photo       string    `json:"photo,omitempty" validate:"omitempty,max=1000000,url" example:"https://example.com/juan.jpg"`
+}
+
+type Phone struct {
+	Label   string `json:"label" validate:"required,oneof=mobile work home main fax other" example:"mobile"`
+	Number  string `json:"number" validate:"required,max=32" example:"+573000000000"`
+	Primary bool   `json:"primary"`
+}
+
+type Email struct {
+	Label   string `json:"label" validate:"required,oneof=home work other" example:"work"`
+	Address string `json:"address" validate:"required,email,max=254" example:"juan@example.com"`
+	Primary bool   `json:"primary"`
+}
+
+type Address struct {
+	Label      string `json:"label" validate:"required,oneof=home work other" example:"home"`
+	Street     string `json:"street" validate:"required,max=200" example:"Calle 100 # 10-20"`
+	City       string `json:"city" validate:"max=100" example:"Bogotá"`
+	Region     string `json:"region" validate:"max=100" example:"Cundinamarca"`
+	PostalCode string `json:"postal_code" validate:"max=20" example:"110111"`
+	Country    string `json:"country" validate:"omitempty,iso3166_1_alpha2" example:"CO"`
+	Primary    bool   `json:"primary"`
+}
+
+func NewContact(contact models.Contact) Contact {
+	return Contact{
+		Name:        contact.Name,
+		NamePrefix:  contact.Prefix,
+		GivenName:   contact.GivenName,
+		FamilyName:  contact.FamilyName,
+		NameSuffix:  contact.Suffix,
+		Nickname:    contact.Nickname,
+		PhoneNumber: displayPhone(contact.PhoneNumber, contact.PhoneDisplay),
+		Phones:      newPhones(contact.Phones),
+		Emails:      newEmails(contact.Emails),
+		Addresses:   newAddresses(contact.Addresses),
+		Company:     contact.Company,
+		Notes:       contact.Notes,
+		Photo:       contact.Photo,
+	}
 }
 
 func (dto Contact) ToModel() models.Contact {
 	return models.Contact{
-		Name:        dto.Name,
-		PhoneNumber: dto.PhoneNumber,
+		Name:           dto.Name,
+		StructuredName: dto.structuredName(),
+		PhoneNumber:    dto.PhoneNumber,
+		Phones:         dto.phonesModel(),
+		Emails:         dto.emailsModel(),
+		Addresses:      dto.addressesModel(),
+		Company:        dto.Company,
+		Notes:          dto.Notes,
+		Photo:          dto.Photo,
 	}
 }
 
 func (dto Contact) Validate() error {
-	validate := validator.New()
-	if err := validate.Struct(dto); err != nil {
-		return err
+	return validateStruct(dto)
+}
+
+func (dto Contact) structuredName() models.StructuredName {
+	return models.StructuredName{
+		Prefix:     dto.NamePrefix,
+		GivenName:  dto.GivenName,
+		FamilyName: dto.FamilyName,
+		Suffix:     dto.NameSuffix,
+		Nickname:   dto.Nickname,
+	}
+}
+
+func (dto Contact) phonesModel() []models.Phone {
+	if len(dto.Phones) == 0 {
+		return nil
+	}
+
+	phones := make([]models.Phone, len(dto.Phones))
+	for i, phone := range dto.Phones {
+		phones[i] = models.Phone{
+			Label:   phone.Label,
+			Number:  phone.Number,
+			Primary: phone.Primary,
+		}
+	}
+
+	return phones
+}
+
+func (dto Contact) emailsModel() []models.Email {
+	if len(dto.Emails) == 0 {
+		return nil
+	}
+
+	emails := make([]models.Email, len(dto.Emails))
+	for i, email := range dto.Emails {
+		emails[i] = models.Email{
+			Label:   email.Label,
+			Address: email.Address,
+			Primary: email.Primary,
+		}
+	}
+
+	return emails
+}
+
+func (dto Contact) addressesModel() []models.Address {
+	if len(dto.Addresses) == 0 {
+		return nil
+	}
+
+	addresses := make([]models.Address, len(dto.Addresses))
+	for i, address := range dto.Addresses {
+		addresses[i] = models.Address{
+			Label:      address.Label,
+			Street:     address.Street,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Primary:    address.Primary,
+		}
+	}
+
+	return addresses
+}
+
+func newPhones(phones []models.Phone) []Phone {
+	if len(phones) == 0 {
+		return nil
+	}
+
+	result := make([]Phone, len(phones))
+	for i, phone := range phones {
+		result[i] = Phone{
+			Label:   phone.Label,
+			Number:  displayPhone(phone.Number, phone.Display),
+			Primary: phone.Primary,
+		}
+	}
+
+	return result
+}
+
+func newEmails(emails []models.Email) []Email {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	result := make([]Email, len(emails))
+	for i, email := range emails {
+		result[i] = Email{
+			Label:   email.Label,
+			Address: email.Address,
+			Primary: email.Primary,
+		}
+	}
+
+	return result
+}
+
+func newAddresses(addresses []models.Address) []Address {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	result := make([]Address, len(addresses))
+	for i, address := range addresses {
+		result[i] = Address{
+			Label:      address.Label,
+			Street:     address.Street,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Primary:    address.Primary,
+		}
+	}
+
+	return result
+}
+
+// displayPhone returns the number as the client wrote it, numbers saved before
+// they were normalized have no display format.
+func displayPhone(number, display string) string {
+	if display != "" {
+		return display
 	}
 
-	return nil
+	return number
 }
@@ -3,6 +3,7 @@ package dto
 import (
 	"testing"
 
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
 	"github.com/AjxGnx/contacts-go/internal/domain/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -22,10 +23,126 @@ func TestContact_ToModel(t *testing.T) {
 }
 
 func TestContact_Validate(t *testing.T) {
-	assert.Error(t, Contact{}.Validate())
+	err := Contact{}.Validate()
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "name", Rule: "contact_name",
+			Message: "name is required when given_name, family_name and nickname are empty"},
+		{Field: "phone_number", Rule: "required", Message: "phone_number is required"},
+	}, apperrors.FieldsOf(err))
 
 	assert.NoError(t, Contact{
 		Name:        "name",
 		PhoneNumber: "phone number",
 	}.Validate())
+
+	assert.NoError(t, Contact{
+		FamilyName:  "family name",
+		PhoneNumber: "phone number",
+	}.Validate())
+}
+
+func TestContact_ToModelWithNameComponents(t *testing.T) {
+	contact := Contact{
+		Name:        "ignored",
+		NamePrefix:  "Dr.",
+		GivenName:   "Juan",
+		FamilyName:  "Pérez",
+		NameSuffix:  "Jr.",
+		Nickname:    "Juancho",
+		PhoneNumber: "+570000000",
+	}
+
+	contactExpected := models.Contact{
+		Name: "ignored",
+		StructuredName: models.StructuredName{
+			Prefix:     "Dr.",
+			GivenName:  "Juan",
+			FamilyName: "Pérez",
+			Suffix:     "Jr.",
+			Nickname:   "Juancho",
+		},
+		PhoneNumber: "+570000000",
+	}
+
+	assert.Equal(t, contactExpected, contact.ToModel())
+	assert.Equal(t, contact, NewContact(contactExpected))
+}
+
+func TestNewContact(t *testing.T) {
+	contact := models.Contact{
+		ID:          1,
+		Name:        "test",
+		PhoneNumber: "+570000000",
+	}
+
+	assert.Equal(t, Contact{Name: "test", PhoneNumber: "+570000000"}, NewContact(contact))
+}
+
+func TestContact_ToModelWithChildren(t *testing.T) {
+	contact := Contact{
+		Name:        "test",
+		PhoneNumber: "+570000000",
+		Phones:      []Phone{{Label: "work", Number: "+571111111", Primary: true}},
+		Emails:      []Email{{Label: "home", Address: "test@example.com"}},
+		Addresses:   []Address{{Label: "home", Street: "Calle 1", City: "Bogotá", Country: "CO"}},
+	}
+
+	contactExpected := models.Contact{
+		Name:        "test",
+		PhoneNumber: "+570000000",
+		Phones:      []models.Phone{{Label: "work", Number: "+571111111", Primary: true}},
+		Emails:      []models.Email{{Label: "home", Address: "test@example.com"}},
+		Addresses:   []models.Address{{Label: "home", Street: "Calle 1", City: "Bogotá", Country: "CO"}},
+	}
+
+	assert.Equal(t, contactExpected, contact.ToModel())
+	assert.Equal(t, contact, NewContact(contactExpected))
+}
+
+func TestContact_ValidateChildren(t *testing.T) {
+	err := Contact{
+		Name:        "name",
+		PhoneNumber: "phone number",
+		Phones:      []Phone{{Label: "mobile", Number: "1"}, {Label: "pager", Number: "2"}},
+		Emails:      []Email{{Label: "work", Address: "not an email"}},
+		Addresses:   []Address{{Label: "home"}},
+	}.Validate()
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "phones[1].label", Rule: "oneof", Message: "phones[1].label must be one of [mobile work home main fax other]"},
+		{Field: "emails[0].address", Rule: "email", Message: "emails[0].address must be a valid email address"},
+		{Field: "addresses[0].street", Rule: "required", Message: "addresses[0].street is required"},
+	}, apperrors.FieldsOf(err))
+}
+
+func TestContact_ValidateOnePrimary(t *testing.T) {
+	err := Contact{
+		Name:        "name",
+		PhoneNumber: "phone number",
+		Emails: []Email{
+			{Label: "work", Address: "work@example.com", Primary: true},
+			{Label: "home", Address: "home@example.com", Primary: true},
+		},
+	}.Validate()
+
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "emails", Rule: "one_primary", Message: "emails must have at most one primary item"},
+	}, apperrors.FieldsOf(err))
+}
+
+func TestContact_ValidatePhoto(t *testing.T) {
+	contact := Contact{Name: "name", PhoneNumber: "phone number"}
+
+	for _, photo := range []string{"https://example.com/juan.jpg", "data:image/png;base64,iVBORw0KGgo="} {
+		contact.Photo = photo
+		assert.NoError(t, contact.Validate(), photo)
+	}
+
+	contact.Photo = "juan.jpg"
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "photo", Rule: "url", Message: "photo must be a URL or a data URI"},
+	}, apperrors.FieldsOf(contact.Validate()))
 }
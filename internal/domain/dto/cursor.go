@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+type cursorPayload struct {
+	Keys     []string `json:"k,omitempty"`
+	Sort     string   `json:"s,omitempty"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// CursorCodec turns models.Cursor into opaque strings signed with HMAC-SHA256, so
+// clients can not forge positions. The sort of the listing is signed along with
+// the position because a cursor is only meaningful for the order it was built for.
+type CursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec creates a codec signing with secret. When secret is empty a
+// random one is generated, so cursors do not survive a restart of the server.
+func NewCursorCodec(secret string) CursorCodec {
+	if secret != "" {
+		return CursorCodec{secret: []byte(secret)}
+	}
+
+	random := make([]byte, sha256.Size)
+	if _, err := rand.Read(random); err != nil {
+		panic(err)
+	}
+
+	return CursorCodec{secret: random}
+}
+
+func (codec CursorCodec) Encode(cursor models.Cursor, sort []models.SortField) string {
+	payload, _ := json.Marshal(cursorPayload{
+		Keys:     cursor.Keys,
+		Sort:     FormatSort(sort),
+		Backward: cursor.Backward,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." +
+		base64.RawURLEncoding.EncodeToString(codec.sign(payload))
+}
+
+// Decode verifies and decodes a cursor built by Encode, returning the position and
+// the sort it belongs to. When sort is not empty the cursor must have been built
+// for it.
+func (codec CursorCodec) Decode(value string, sort []models.SortField) (models.Cursor, []models.SortField, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(value, ".")
+	if !found {
+		return models.Cursor{}, nil, invalidCursor()
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return models.Cursor{}, nil, invalidCursor()
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, codec.sign(payload)) {
+		return models.Cursor{}, nil, invalidCursor()
+	}
+
+	var decoded cursorPayload
+	if err = json.Unmarshal(payload, &decoded); err != nil {
+		return models.Cursor{}, nil, invalidCursor()
+	}
+
+	if len(sort) > 0 && FormatSort(sort) != decoded.Sort {
+		return models.Cursor{}, nil, invalidCursor()
+	}
+
+	return models.Cursor{Keys: decoded.Keys, Backward: decoded.Backward}, ParseSort(decoded.Sort), nil
+}
+
+func (codec CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, codec.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+func invalidCursor() error {
+	return apperrors.InvalidFields(invalidFieldsMessage, errInvalidCursor, []apperrors.FieldError{{
+		Field:   "cursor",
+		Rule:    "cursor",
+		Message: "cursor is invalid or was built for a different sort",
+	}})
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorCodec_EncodeDecode(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	cursor := models.Cursor{Keys: []string{"Juan", "10"}, Backward: true}
+	sort := []models.SortField{{Field: "name"}, {Field: "id", Desc: true}}
+
+	decoded, decodedSort, err := codec.Decode(codec.Encode(cursor, sort), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+	assert.Equal(t, sort, decodedSort)
+}
+
+func TestCursorCodec_DecodeWhenSignatureDoesNotMatch(t *testing.T) {
+	value := NewCursorCodec("secret").Encode(models.Cursor{Keys: []string{"10"}}, nil)
+
+	_, _, err := NewCursorCodec("other secret").Decode(value, nil)
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestCursorCodec_DecodeWhenMalformed(t *testing.T) {
+	codec := NewCursorCodec("secret")
+
+	for _, value := range []string{"", "abc", "abc.def", "!!.!!"} {
+		_, _, err := codec.Decode(value, nil)
+
+		assert.True(t, apperrors.Is(err, apperrors.KindValidation), value)
+	}
+}
+
+func TestCursorCodec_DecodeWhenSortDoesNotMatch(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	value := codec.Encode(models.Cursor{Keys: []string{"Juan", "10"}}, []models.SortField{{Field: "name"}})
+
+	_, _, err := codec.Decode(value, []models.SortField{{Field: "name", Desc: true}})
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestNewCursorCodec_WhenSecretIsEmpty(t *testing.T) {
+	value := NewCursorCodec("").Encode(models.Cursor{}, nil)
+
+	_, _, err := NewCursorCodec("").Decode(value, nil)
+
+	assert.Error(t, err)
+}
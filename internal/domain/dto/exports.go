@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+)
+
+// Formats of an export.
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+	ExportVCard  = "vcf"
+)
+
+// exportSeparator joins the values of a CSV column that a contact has many of,
+// like two phones with the same label.
+const exportSeparator = "; "
+
+var addressFields = []struct {
+	name  string
+	value func(address models.Address) string
+}{
+	{"street", func(address models.Address) string { return address.Street }},
+	{"city", func(address models.Address) string { return address.City }},
+	{"region", func(address models.Address) string { return address.Region }},
+	{"postal_code", func(address models.Address) string { return address.PostalCode }},
+	{"country", func(address models.Address) string { return address.Country }},
+}
+
+// ParseExportFormat parses the format param of an export, which is required.
+func ParseExportFormat(value string) (string, error) {
+	switch value {
+	case ExportCSV, ExportNDJSON, ExportVCard:
+		return value, nil
+	default:
+		return "", invalidParam("format", "oneof",
+			fmt.Sprintf("format must be one of [%s %s %s], got %q", ExportCSV, ExportNDJSON, ExportVCard, value), nil)
+	}
+}
+
+// ParseVCardVersion parses the version param of a vCard download, 3.0 by default
+// as it is the version every client reads.
+func ParseVCardVersion(value string) (string, error) {
+	if value == "" {
+		return vcard.Version3, nil
+	}
+
+	if !vcard.IsVersion(value) {
+		return "", invalidParam("version", "oneof",
+			fmt.Sprintf("version must be one of [%s %s], got %q", vcard.Version3, vcard.Version4, value), nil)
+	}
+
+	return value, nil
+}
+
+// ExportCSVHeader names the columns of a CSV export. They are named after the
+// fields of an import, so an exported file can be imported back as it is.
+func ExportCSVHeader() []string {
+	header := []string{"id", "name", "name_prefix", "given_name", "family_name", "name_suffix", "nickname",
+		"phone_number"}
+
+	for _, label := range phoneLabels {
+		header = append(header, "phones."+label)
+	}
+
+	for _, label := range emailLabels {
+		header = append(header, "emails."+label)
+	}
+
+	for _, label := range addressLabels {
+		for _, field := range addressFields {
+			header = append(header, "addresses."+label+"."+field.name)
+		}
+	}
+
+	return append(header, "company", "notes", "tags", "version")
+}
+
+// ExportCSVRecord is the row of contact in a CSV export, its values follow
+// ExportCSVHeader.
+func ExportCSVRecord(contact models.Contact) []string {
+	record := []string{
+		strconv.FormatUint(uint64(contact.ID), 10),
+		contact.Name,
+		contact.Prefix,
+		contact.GivenName,
+		contact.FamilyName,
+		contact.Suffix,
+		contact.Nickname,
+		contact.PhoneNumber,
+	}
+
+	for _, label := range phoneLabels {
+		var numbers []string
+		for _, phone := range contact.Phones {
+			if phone.Label == label {
+				numbers = append(numbers, phone.Number)
+			}
+		}
+
+		record = append(record, strings.Join(numbers, exportSeparator))
+	}
+
+	for _, label := range emailLabels {
+		var addresses []string
+		for _, email := range contact.Emails {
+			if email.Label == label {
+				addresses = append(addresses, email.Address)
+			}
+		}
+
+		record = append(record, strings.Join(addresses, exportSeparator))
+	}
+
+	for _, label := range addressLabels {
+		for _, field := range addressFields {
+			var values []string
+			for _, address := range contact.Addresses {
+				if value := field.value(address); address.Label == label && value != "" {
+					values = append(values, value)
+				}
+			}
+
+			record = append(record, strings.Join(values, exportSeparator))
+		}
+	}
+
+	tags := make([]string, len(contact.Tags))
+	for i, tag := range contact.Tags {
+		tags[i] = tag.Name
+	}
+
+	return append(record, contact.Company, contact.Notes, strings.Join(tags, exportSeparator),
+		strconv.FormatUint(uint64(contact.Version), 10))
+}
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := ParseExportFormat("ndjson")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ExportNDJSON, format)
+
+	_, err = ParseExportFormat("")
+	assert.Equal(t, "format", apperrors.FieldsOf(err)[0].Field)
+
+	_, err = ParseExportFormat("xml")
+	assert.Equal(t, "format", apperrors.FieldsOf(err)[0].Field)
+}
+
+func TestExportCSVRecord(t *testing.T) {
+	contact := models.Contact{
+		ID:             7,
+		Name:           "Juan Perez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Perez"},
+		PhoneNumber:    "+573000000000",
+		Version:        2,
+		Phones: []models.Phone{
+			{Label: "work", Number: "+573000000001"},
+			{Label: "work", Number: "+573000000002"},
+		},
+		Emails:    []models.Email{{Label: "home", Address: "juan@example.com"}},
+		Addresses: []models.Address{{Label: "home", City: "Bogota", Country: "CO"}},
+		Tags:      []models.Tag{{Name: "family"}, {Name: "friends"}},
+	}
+
+	header := ExportCSVHeader()
+	record := ExportCSVRecord(contact)
+	values := map[string]string{}
+
+	assert.Len(t, record, len(header))
+
+	for i, column := range header {
+		values[column] = record[i]
+	}
+
+	assert.Equal(t, "7", values["id"])
+	assert.Equal(t, "+573000000001; +573000000002", values["phones.work"])
+	assert.Equal(t, "", values["phones.mobile"])
+	assert.Equal(t, "juan@example.com", values["emails.home"])
+	assert.Equal(t, "Bogota", values["addresses.home.city"])
+	assert.Equal(t, "family; friends", values["tags"])
+	assert.Equal(t, "2", values["version"])
+}
+
+func TestExportCSVRecord_CanBeImported(t *testing.T) {
+	contact := models.Contact{
+		Name:           "Juan Perez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Perez"},
+		PhoneNumber:    "+573000000000",
+		Emails:         []models.Email{{Label: "work", Address: "juan@example.com"}},
+		Addresses:      []models.Address{{Label: "home", Street: "Calle 1", City: "Bogota", Country: "CO"}},
+		Company:        "Acme",
+	}
+
+	file := &bytes.Buffer{}
+	writer := csv.NewWriter(file)
+	_ = writer.Write(ExportCSVHeader())
+	_ = writer.Write(ExportCSVRecord(contact))
+	writer.Flush()
+
+	rows, err := ParseCSV(file, ImportMapping{}, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ImportRow{{Row: 2, Contact: Contact{
+		Name:        "Juan Perez",
+		GivenName:   "Juan",
+		FamilyName:  "Perez",
+		PhoneNumber: "+573000000000",
+		Emails:      []Email{{Label: "work", Address: "juan@example.com"}},
+		Addresses:   []Address{{Label: "home", Street: "Calle 1", City: "Bogota", Country: "CO"}},
+		Company:     "Acme",
+	}}}, rows)
+}
+
+func TestParseVCardVersion(t *testing.T) {
+	version, err := ParseVCardVersion("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, vcard.Version3, version)
+
+	version, err = ParseVCardVersion("4.0")
+
+	assert.NoError(t, err)
+	assert.Equal(t, vcard.Version4, version)
+
+	_, err = ParseVCardVersion("2.1")
+	assert.Equal(t, "version", apperrors.FieldsOf(err)[0].Field)
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+type Group struct {
+	Name        string `json:"name" validate:"required,max=100" example:"Bogotá clients"`
+	Description string `json:"description,omitempty" validate:"max=500" example:"clients of the Bogotá office"`
+}
+
+func (dto Group) ToModel() models.Group {
+	return models.Group{
+		Name:        strings.TrimSpace(dto.Name),
+		Description: dto.Description,
+	}
+}
+
+func (dto Group) Validate() error {
+	return validateStruct(dto)
+}
+
+// GroupMembers are the contacts and groups added to or removed from a group.
+type GroupMembers struct {
+	ContactIDs []uint `json:"contact_ids,omitempty" validate:"max=1000,dive,min=1"`
+	GroupIDs   []uint `json:"group_ids,omitempty" validate:"max=100,dive,min=1"`
+}
+
+func (dto GroupMembers) Validate() error {
+	if err := validateStruct(dto); err != nil {
+		return err
+	}
+
+	if len(dto.ContactIDs) == 0 && len(dto.GroupIDs) == 0 {
+		return apperrors.InvalidFields(invalidFieldsMessage, nil, []apperrors.FieldError{{
+			Field:   "contact_ids",
+			Rule:    "required",
+			Message: "contact_ids or group_ids is required",
+		}})
+	}
+
+	return nil
+}
+
+// UniqueContactIDs returns ContactIDs without repetitions, in their first order.
+func (dto GroupMembers) UniqueContactIDs() []uint {
+	return uniqueIDs(dto.ContactIDs)
+}
+
+// UniqueGroupIDs returns GroupIDs without repetitions, in their first order.
+func (dto GroupMembers) UniqueGroupIDs() []uint {
+	return uniqueIDs(dto.GroupIDs)
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupMembers_Validate(t *testing.T) {
+	assert.NoError(t, GroupMembers{GroupIDs: []uint{2}}.Validate())
+
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "contact_ids", Rule: "required", Message: "contact_ids or group_ids is required"},
+	}, apperrors.FieldsOf(GroupMembers{}.Validate()))
+
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "group_ids[1]", Rule: "min", Message: "group_ids[1] must be at least 1"},
+	}, apperrors.FieldsOf(GroupMembers{GroupIDs: []uint{2, 0}}.Validate()))
+}
+
+func TestGroupMembers_Unique(t *testing.T) {
+	members := GroupMembers{ContactIDs: []uint{3, 1, 3}, GroupIDs: []uint{2, 2}}
+
+	assert.Equal(t, []uint{3, 1}, members.UniqueContactIDs())
+	assert.Equal(t, []uint{2}, members.UniqueGroupIDs())
+}
@@ -0,0 +1,372 @@
+package dto
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+)
+
+// Statuses of the rows of an import.
+const (
+	ImportCreated = "created"
+	ImportUpdated = "updated"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+// What an import does with a row whose phone number belongs to a saved contact.
+const (
+	DuplicateSkip   = "skip"
+	DuplicateUpdate = "update"
+)
+
+var (
+	phoneLabels    = []string{"mobile", "work", "home", "main", "fax", "other"}
+	emailLabels    = []string{"home", "work", "other"}
+	addressLabels  = []string{"home", "work", "other"}
+	addressColumns = map[string]func(*Address, string){
+		"street":      func(address *Address, value string) { address.Street = value },
+		"city":        func(address *Address, value string) { address.City = value },
+		"region":      func(address *Address, value string) { address.Region = value },
+		"postal_code": func(address *Address, value string) { address.PostalCode = value },
+		"country":     func(address *Address, value string) { address.Country = strings.ToUpper(value) },
+	}
+	contactColumns = map[string]func(*Contact, string){
+		"name":         func(contact *Contact, value string) { contact.Name = value },
+		"name_prefix":  func(contact *Contact, value string) { contact.NamePrefix = value },
+		"given_name":   func(contact *Contact, value string) { contact.GivenName = value },
+		"family_name":  func(contact *Contact, value string) { contact.FamilyName = value },
+		"name_suffix":  func(contact *Contact, value string) { contact.NameSuffix = value },
+		"nickname":     func(contact *Contact, value string) { contact.Nickname = value },
+		"phone_number": func(contact *Contact, value string) { contact.PhoneNumber = value },
+		"company":      func(contact *Contact, value string) { contact.Company = value },
+		"notes":        func(contact *Contact, value string) { contact.Notes = value },
+	}
+)
+
+// ImportConfig bounds the size of the files clients can import.
+type ImportConfig struct {
+	MaxRows int
+}
+
+// ImportOptions are the options of an import. With DryRun nothing is saved, the
+// report tells what would have happened.
+type ImportOptions struct {
+	DryRun      bool
+	OnDuplicate string
+}
+
+// ParseImportOptions parses the dry_run and on_duplicate params of an import,
+// which default to false and DuplicateSkip.
+func ParseImportOptions(dryRun, onDuplicate string) (ImportOptions, error) {
+	options := ImportOptions{OnDuplicate: DuplicateSkip}
+
+	if dryRun != "" {
+		var err error
+		if options.DryRun, err = strconv.ParseBool(dryRun); err != nil {
+			return ImportOptions{}, invalidParam("dry_run", "boolean",
+				fmt.Sprintf("dry_run must be true or false, got %q", dryRun), err)
+		}
+	}
+
+	switch onDuplicate {
+	case "", DuplicateSkip:
+	case DuplicateUpdate:
+		options.OnDuplicate = DuplicateUpdate
+	default:
+		return ImportOptions{}, invalidParam("on_duplicate", "oneof",
+			fmt.Sprintf("on_duplicate must be one of [%s %s], got %q", DuplicateSkip, DuplicateUpdate, onDuplicate), nil)
+	}
+
+	return options, nil
+}
+
+// ImportMapping maps the columns of an imported file to the fields of a contact.
+// Besides the name fields, phone_number, company and notes, a column can hold a phone
+// ("phones.<label>"), an email ("emails.<label>") or part of an address
+// ("addresses.<label>.<street|city|region|postal_code|country>").
+type ImportMapping map[string]string
+
+// ParseImportMapping parses the JSON object sent as mapping, an empty value is an
+// empty mapping.
+func ParseImportMapping(value string) (ImportMapping, error) {
+	mapping := ImportMapping{}
+	if strings.TrimSpace(value) == "" {
+		return mapping, nil
+	}
+
+	if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+		return nil, invalidParam("mapping", "json",
+			`mapping must be a JSON object of column names to fields, e.g. {"Mobile": "phone_number"}`, err)
+	}
+
+	var fields []apperrors.FieldError
+	for column, field := range mapping {
+		if !isImportField(field) {
+			fields = append(fields, apperrors.FieldError{
+				Field:   "mapping",
+				Rule:    "oneof",
+				Message: fmt.Sprintf("the column %q is mapped to %q, which is not a contact field", column, field),
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Message < fields[j].Message })
+		return nil, apperrors.InvalidFields(invalidFieldsMessage, nil, fields)
+	}
+
+	return mapping, nil
+}
+
+// ImportRow is a contact read from a row of an imported file, Row is the line
+// where the row starts.
+type ImportRow struct {
+	Row     int
+	Contact Contact
+}
+
+// ParseCSV reads the contacts of a CSV file whose first row names its columns.
+// Columns are mapped to fields by mapping, columns missing from it are read as
+// the field they are named after, if any, and ignored otherwise. Blank rows are
+// skipped and a file with more than maxRows rows is rejected.
+func ParseCSV(file io.Reader, mapping ImportMapping, maxRows int) ([]ImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperrors.Validation("the file is empty", err)
+	}
+
+	if err != nil {
+		return nil, invalidCSV(err)
+	}
+
+	fields, err := columnFields(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []ImportRow
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, invalidCSV(err)
+		}
+
+		if isBlank(record) {
+			continue
+		}
+
+		if len(rows) == maxRows {
+			return nil, apperrors.Validation(fmt.Sprintf("the file has more than %d rows", maxRows), nil)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, ImportRow{Row: line, Contact: importContact(record, fields)})
+	}
+
+	return rows, nil
+}
+
+// ParseVCards reads the contacts of a vCard file, each card is a row. A file with
+// more than maxRows cards is rejected.
+func ParseVCards(file io.Reader, maxRows int) ([]ImportRow, error) {
+	cards, err := vcard.Decode(file)
+	if err != nil {
+		return nil, apperrors.Validation(fmt.Sprintf("the file is not a valid vCard: %v", err), err)
+	}
+
+	if len(cards) == 0 {
+		return nil, apperrors.Validation("the file has no cards", nil)
+	}
+
+	if len(cards) > maxRows {
+		return nil, apperrors.Validation(fmt.Sprintf("the file has more than %d rows", maxRows), nil)
+	}
+
+	rows := make([]ImportRow, len(cards))
+	for i, card := range cards {
+		rows[i] = ImportRow{Row: card.Line, Contact: NewContact(card.Contact)}
+	}
+
+	return rows, nil
+}
+
+// columnFields returns the field each column of header is read into, "" for the
+// ignored ones.
+func columnFields(header []string, mapping ImportMapping) ([]string, error) {
+	fields := make([]string, len(header))
+	found := map[string]bool{}
+
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		found[column] = true
+
+		if field, mapped := mapping[column]; mapped {
+			fields[i] = field
+		} else if field = strings.ToLower(column); isImportField(field) {
+			fields[i] = field
+		}
+	}
+
+	var missing []string
+	for column := range mapping {
+		if !found[column] {
+			missing = append(missing, strconv.Quote(column))
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+
+		return nil, invalidParam("mapping", "columns",
+			fmt.Sprintf("the file has no column named %s", strings.Join(missing, ", ")), nil)
+	}
+
+	return fields, nil
+}
+
+func importContact(record []string, fields []string) Contact {
+	var contact Contact
+
+	addresses := map[string]*Address{}
+	var addressOrder []string
+
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		if i >= len(fields) || fields[i] == "" || value == "" {
+			continue
+		}
+
+		field := fields[i]
+		if set, ok := contactColumns[field]; ok {
+			set(&contact, value)
+			continue
+		}
+
+		parts := strings.Split(field, ".")
+		switch parts[0] {
+		case "phones":
+			contact.Phones = append(contact.Phones, Phone{Label: parts[1], Number: value})
+		case "emails":
+			contact.Emails = append(contact.Emails, Email{Label: parts[1], Address: value})
+		case "addresses":
+			address, ok := addresses[parts[1]]
+			if !ok {
+				address = &Address{Label: parts[1]}
+				addresses[parts[1]] = address
+				addressOrder = append(addressOrder, parts[1])
+			}
+
+			addressColumns[parts[2]](address, value)
+		}
+	}
+
+	for _, label := range addressOrder {
+		contact.Addresses = append(contact.Addresses, *addresses[label])
+	}
+
+	return contact
+}
+
+func isImportField(field string) bool {
+	if _, ok := contactColumns[field]; ok {
+		return true
+	}
+
+	parts := strings.Split(field, ".")
+	switch {
+	case len(parts) == 2 && parts[0] == "phones":
+		return contains(phoneLabels, parts[1])
+	case len(parts) == 2 && parts[0] == "emails":
+		return contains(emailLabels, parts[1])
+	case len(parts) == 3 && parts[0] == "addresses":
+		_, ok := addressColumns[parts[2]]
+		return ok && contains(addressLabels, parts[1])
+	default:
+		return false
+	}
+}
+
+func isBlank(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func invalidCSV(err error) error {
+	return apperrors.Validation(fmt.Sprintf("the file is not valid CSV: %v", err), err)
+}
+
+// ImportResult tells what was done with a row of an import.
+type ImportResult struct {
+	Row       int      `json:"row"`
+	Status    string   `json:"status" enums:"created,updated,skipped,failed"`
+	ContactID uint     `json:"contact_id,omitempty"`
+	Reasons   []string `json:"reasons,omitempty"`
+}
+
+// ImportReport is the outcome of an import, row by row. StoppedAt is the row an
+// unexpected error stopped the import at, the rows saved before it are kept and
+// the ones after it failed without being tried.
+type ImportReport struct {
+	DryRun    bool           `json:"dry_run"`
+	Created   int            `json:"created"`
+	Updated   int            `json:"updated"`
+	Skipped   int            `json:"skipped"`
+	Failed    int            `json:"failed"`
+	StoppedAt int            `json:"stopped_at,omitempty"`
+	Rows      []ImportResult `json:"rows"`
+}
+
+// Add appends result to the report.
+func (report *ImportReport) Add(result ImportResult) {
+	switch result.Status {
+	case ImportCreated:
+		report.Created++
+	case ImportUpdated:
+		report.Updated++
+	case ImportSkipped:
+		report.Skipped++
+	case ImportFailed:
+		report.Failed++
+	}
+
+	report.Rows = append(report.Rows, result)
+}
+
+// ImportFailure is the result of a row rejected because of err, every rejected
+// field is a reason.
+func ImportFailure(row int, err error) ImportResult {
+	result := ImportResult{Row: row, Status: ImportFailed}
+
+	for _, field := range apperrors.FieldsOf(err) {
+		result.Reasons = append(result.Reasons, field.Message)
+	}
+
+	if len(result.Reasons) == 0 {
+		result.Reasons = []string{err.Error()}
+	}
+
+	return result
+}
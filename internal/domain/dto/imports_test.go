@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImportOptions(t *testing.T) {
+	options, err := ParseImportOptions("", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ImportOptions{OnDuplicate: DuplicateSkip}, options)
+
+	options, err = ParseImportOptions("true", "update")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ImportOptions{DryRun: true, OnDuplicate: DuplicateUpdate}, options)
+
+	_, err = ParseImportOptions("maybe", "")
+	assert.Equal(t, "dry_run", apperrors.FieldsOf(err)[0].Field)
+
+	_, err = ParseImportOptions("", "merge")
+	assert.Equal(t, "on_duplicate", apperrors.FieldsOf(err)[0].Field)
+}
+
+func TestParseImportMapping(t *testing.T) {
+	mapping, err := ParseImportMapping(`{"Mobile": "phone_number", "Work mail": "emails.work", "Town": "addresses.home.city"}`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ImportMapping{
+		"Mobile":    "phone_number",
+		"Work mail": "emails.work",
+		"Town":      "addresses.home.city",
+	}, mapping)
+
+	mapping, err = ParseImportMapping("")
+
+	assert.NoError(t, err)
+	assert.Empty(t, mapping)
+
+	_, err = ParseImportMapping(`["phone_number"]`)
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+
+	_, err = ParseImportMapping(`{"Mobile": "phones.car", "Id": "id", "Town": "addresses.home.town"}`)
+	assert.Len(t, apperrors.FieldsOf(err), 3)
+}
+
+func TestParseCSV(t *testing.T) {
+	file := "\ufeffFull name,Mobile,Email,addresses.home.street,addresses.home.city,Notes,Comments\n" +
+		"Juan Pérez,300 000 0000,juan@example.com,Calle 100 # 10-20,Bogotá,,ignored\n" +
+		",,,,,,\n" +
+		"\"Pérez, Ana\",+573100000000,,,,\"two\nlines\",\n" +
+		"Luis,3200000000\n"
+
+	rows, err := ParseCSV(strings.NewReader(file), ImportMapping{
+		"Full name": "name",
+		"Mobile":    "phone_number",
+		"Email":     "emails.home",
+	}, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ImportRow{
+		{Row: 2, Contact: Contact{
+			Name:        "Juan Pérez",
+			PhoneNumber: "300 000 0000",
+			Emails:      []Email{{Label: "home", Address: "juan@example.com"}},
+			Addresses:   []Address{{Label: "home", Street: "Calle 100 # 10-20", City: "Bogotá"}},
+		}},
+		{Row: 4, Contact: Contact{Name: "Pérez, Ana", PhoneNumber: "+573100000000", Notes: "two\nlines"}},
+		{Row: 6, Contact: Contact{Name: "Luis", PhoneNumber: "3200000000"}},
+	}, rows)
+}
+
+func TestParseCSV_WhenInvalid(t *testing.T) {
+	tests := map[string]struct {
+		file    string
+		mapping ImportMapping
+	}{
+		"empty":          {file: ""},
+		"malformed":      {file: "name,phone_number\n\"Juan,300\n"},
+		"missing column": {file: "name,phone_number\n", mapping: ImportMapping{"Mobile": "phone_number"}},
+		"too many rows":  {file: "name,phone_number\na,1\nb,2\nc,3\n"},
+	}
+
+	for name, test := range tests {
+		_, err := ParseCSV(strings.NewReader(test.file), test.mapping, 2)
+
+		assert.True(t, apperrors.Is(err, apperrors.KindValidation), name)
+	}
+}
+
+func TestImportReport_Add(t *testing.T) {
+	var report ImportReport
+
+	report.Add(ImportResult{Row: 2, Status: ImportCreated, ContactID: 1})
+	report.Add(ImportResult{Row: 3, Status: ImportSkipped})
+	report.Add(ImportFailure(4, errors.New("some error")))
+	report.Add(ImportFailure(5, apperrors.InvalidFields(invalidFieldsMessage, nil, []apperrors.FieldError{
+		{Field: "phone_number", Message: "phone_number is required"},
+	})))
+
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, []string{"some error"}, report.Rows[2].Reasons)
+	assert.Equal(t, []string{"phone_number is required"}, report.Rows[3].Reasons)
+}
+
+func TestParseVCards(t *testing.T) {
+	file := "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Juan Pérez\r\nN:Pérez;Juan;;;\r\nTEL;TYPE=CELL:300 000 0000\r\n" +
+		"NOTE:vip\r\nEND:VCARD\r\nBEGIN:VCARD\r\nVERSION:4.0\r\nFN:Ana\r\nEND:VCARD\r\n"
+
+	rows, err := ParseVCards(strings.NewReader(file), 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ImportRow{
+		{Row: 1, Contact: Contact{
+			Name:        "Juan Pérez",
+			GivenName:   "Juan",
+			FamilyName:  "Pérez",
+			PhoneNumber: "300 000 0000",
+			Notes:       "vip",
+		}},
+		{Row: 8, Contact: Contact{Name: "Ana"}},
+	}, rows)
+}
+
+func TestParseVCards_WhenInvalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":         "",
+		"malformed":     "BEGIN:VCARD\nVERSION:4.0\n",
+		"too many rows": strings.Repeat("BEGIN:VCARD\nVERSION:4.0\nFN:a\nEND:VCARD\n", 3),
+	}
+
+	for name, file := range tests {
+		_, err := ParseVCards(strings.NewReader(file), 2)
+
+		assert.True(t, apperrors.Is(err, apperrors.KindValidation), name)
+	}
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Merge merges the contacts of ContactIDs into the contact SurvivorID. Fields
+// names, for the fields that can be picked, the contact whose value is kept;
+// the rest keep the value of the survivor.
+type Merge struct {
+	SurvivorID uint            `json:"survivor_id" validate:"required" example:"1"`
+	ContactIDs []uint          `json:"contact_ids" validate:"required,min=1,max=20,dive,min=1"`
+	Fields     map[string]uint `json:"fields,omitempty" validate:"dive,keys,oneof=name phone_number company notes photo,endkeys"`
+}
+
+func (dto Merge) Validate() error {
+	if err := validateStruct(dto); err != nil {
+		return err
+	}
+
+	merged := map[uint]bool{dto.SurvivorID: true}
+	for _, id := range dto.ContactIDs {
+		if merged[id] {
+			return invalidParam("contact_ids", "unique",
+				"contact_ids must not repeat contacts nor include survivor_id", nil)
+		}
+
+		merged[id] = true
+	}
+
+	fields := make([]string, 0, len(dto.Fields))
+	for field := range dto.Fields {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		if !merged[dto.Fields[field]] {
+			return invalidParam("fields."+field, "oneof",
+				fmt.Sprintf("fields.%s must be survivor_id or one of contact_ids", field), nil)
+		}
+	}
+
+	return nil
+}
+
+// Picks are the contacts whose values are kept, by field.
+func (dto Merge) Picks() map[models.MergeField]uint {
+	picks := make(map[models.MergeField]uint, len(dto.Fields))
+	for field, id := range dto.Fields {
+		picks[models.MergeField(field)] = id
+	}
+
+	return picks
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge_Validate(t *testing.T) {
+	merge := Merge{SurvivorID: 1, ContactIDs: []uint{2, 3}, Fields: map[string]uint{"name": 2, "photo": 1}}
+
+	assert.NoError(t, merge.Validate())
+	assert.Equal(t, map[models.MergeField]uint{models.MergeName: 2, models.MergePhoto: 1}, merge.Picks())
+
+	err := Merge{ContactIDs: []uint{}}.Validate()
+	assert.Len(t, apperrors.FieldsOf(err), 2)
+
+	err = Merge{SurvivorID: 1, ContactIDs: []uint{2, 1}}.Validate()
+	assert.Equal(t, "contact_ids", apperrors.FieldsOf(err)[0].Field)
+
+	err = Merge{SurvivorID: 1, ContactIDs: []uint{2, 2}}.Validate()
+	assert.Equal(t, "contact_ids", apperrors.FieldsOf(err)[0].Field)
+
+	err = Merge{SurvivorID: 1, ContactIDs: []uint{2}, Fields: map[string]uint{"emails": 2}}.Validate()
+	assert.Equal(t, "oneof", apperrors.FieldsOf(err)[0].Rule)
+
+	err = Merge{SurvivorID: 1, ContactIDs: []uint{2}, Fields: map[string]uint{"notes": 3}}.Validate()
+	assert.Equal(t, apperrors.FieldError{
+		Field:   "fields.notes",
+		Rule:    "oneof",
+		Message: "fields.notes must be survivor_id or one of contact_ids",
+	}, apperrors.FieldsOf(err)[0])
+}
@@ -5,6 +5,19 @@ type Message struct {
 	Data    interface{} `json:"data"`
 }
 
-type MessageError struct {
-	Message string `json:"message"`
+// Problem is an RFC 7807 problem details document, sent as application/problem+json
+// whenever a request fails.
+type Problem struct {
+	Type     string       `json:"type" example:"/problems/validation"`
+	Title    string       `json:"title" example:"Bad Request"`
+	Status   int          `json:"status" example:"400"`
+	Detail   string       `json:"detail,omitempty" example:"the request has invalid fields"`
+	Instance string       `json:"instance,omitempty" example:"/api/contacts/"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field" example:"phone_number"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"phone_number is required"`
 }
@@ -1,8 +1,25 @@
 package dto
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// DefaultLimit is the page size of the listings requested without a limit.
+const DefaultLimit = 10
+
 type Paginate struct {
-	Page  int
-	Limit int
+	Page    int
+	Limit   int
+	Search  string
+	Filters []models.FieldFilter
+	Tags    []string
+	AnyTag  bool
+	Sort    []models.SortField
+	Cursor  *models.Cursor
 }
 
 func (p *Paginate) SetDefaultLimitAndPage() {
@@ -11,6 +28,118 @@ func (p *Paginate) SetDefaultLimitAndPage() {
 	}
 
 	if p.Limit == 0 {
-		p.Limit = 10
+		p.Limit = DefaultLimit
+	}
+}
+
+// Validate checks that only whitelisted fields are used to filter and sort.
+func (p Paginate) Validate() error {
+	var fields []apperrors.FieldError
+
+	for _, filter := range p.Filters {
+		if !contains(models.ContactFilterFields, filter.Field) {
+			fields = append(fields, apperrors.FieldError{
+				Field:   filter.Field,
+				Rule:    "filterable",
+				Message: fmt.Sprintf("%s can not be used as filter", filter.Field),
+			})
+		}
+	}
+
+	for _, sort := range p.Sort {
+		if !contains(models.ContactSortFields, sort.Field) {
+			fields = append(fields, apperrors.FieldError{
+				Field: "sort",
+				Rule:  "oneof",
+				Message: fmt.Sprintf("sort field %q must be one of [%s]", sort.Field,
+					strings.Join(models.ContactSortFields, " ")),
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return apperrors.InvalidFields(invalidFieldsMessage, nil, fields)
+	}
+
+	return nil
+}
+
+func (p Paginate) ToFilter() models.ContactFilter {
+	return models.ContactFilter{
+		Search:  p.Search,
+		Filters: p.Filters,
+		Tags:    p.Tags,
+		AnyTag:  p.AnyTag,
+		Sort:    p.Sort,
+	}
+}
+
+// ParseTags reads the tag query params, each one can hold several comma
+// separated tags: "tag=a&tag=b" is the same as "tag=a,b". match is "all", the
+// default, to keep the contacts having every tag or "any" to keep the ones having
+// at least one of them.
+func ParseTags(values []string, match string) ([]string, bool, error) {
+	var tags []string
+	for _, value := range values {
+		tags = append(tags, strings.Split(value, ",")...)
 	}
+
+	tags = uniqueTags(tags)
+	if len(tags) == 0 {
+		tags = nil
+	}
+
+	switch match {
+	case "", "all":
+		return tags, false, nil
+	case "any":
+		return tags, true, nil
+	default:
+		return nil, false, invalidParam("tag_match", "oneof",
+			fmt.Sprintf("tag_match must be one of [all any], got %q", match), nil)
+	}
+}
+
+// ParseSort parses a comma separated list of fields, fields prefixed with "-"
+// are sorted in descending order, e.g. "name,-id".
+func ParseSort(value string) []models.SortField {
+	var sort []models.SortField
+
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		sort = append(sort, models.SortField{
+			Field: strings.TrimPrefix(field, "-"),
+			Desc:  strings.HasPrefix(field, "-"),
+		})
+	}
+
+	return sort
+}
+
+// FormatSort is the inverse of ParseSort.
+func FormatSort(sort []models.SortField) string {
+	fields := make([]string, 0, len(sort))
+	for _, field := range sort {
+		if field.Desc {
+			fields = append(fields, "-"+field.Field)
+		} else {
+			fields = append(fields, field.Field)
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
+
+func contains(values []string, value string) bool {
+	for _, current := range values {
+		if current == value {
+			return true
+		}
+	}
+
+	return false
 }
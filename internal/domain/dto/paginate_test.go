@@ -3,6 +3,8 @@ package dto
 import (
 	"testing"
 
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +19,33 @@ func TestPaginate_SetDefaultLimitAndPage(t *testing.T) {
 
 	assert.Equal(t, expectedPaginate, paginate)
 }
+
+func TestParseSort(t *testing.T) {
+	expected := []models.SortField{
+		{Field: "name"},
+		{Field: "id", Desc: true},
+	}
+
+	assert.Equal(t, expected, ParseSort("name, -id,"))
+	assert.Nil(t, ParseSort(""))
+}
+
+func TestFormatSort(t *testing.T) {
+	assert.Equal(t, "name,-id", FormatSort(ParseSort("name,-id")))
+	assert.Equal(t, "", FormatSort(nil))
+}
+
+func TestPaginate_Validate(t *testing.T) {
+	assert.NoError(t, Paginate{
+		Filters: []models.FieldFilter{{Field: "name", Value: "Ju", Prefix: true}},
+		Sort:    []models.SortField{{Field: "phone_number", Desc: true}},
+	}.Validate())
+
+	err := Paginate{
+		Filters: []models.FieldFilter{{Field: "id", Value: "1"}},
+		Sort:    []models.SortField{{Field: "password"}},
+	}.Validate()
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Len(t, apperrors.FieldsOf(err), 2)
+}
@@ -0,0 +1,135 @@
+package dto
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+)
+
+// PaginateConfig bounds the page size clients can request.
+type PaginateConfig struct {
+	MaxLimit int
+}
+
+// ParseID parses a record id sent as path param. Ids are positive and fit in the
+// bigint primary keys of the database.
+func ParseID(field, value string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || id == 0 || id > math.MaxInt64 {
+		return 0, invalidParam(field, "id", fmt.Sprintf("%s must be a positive integer, got %q", field, value), err)
+	}
+
+	return uint(id), nil
+}
+
+// ParsePaginate parses the page and limit query params. Missing params take their
+// default value, sent ones must be positive integers and limit can not exceed
+// config.MaxLimit.
+func ParsePaginate(page, limit string, config PaginateConfig) (Paginate, error) {
+	var paginate Paginate
+	var err error
+
+	if page != "" {
+		if paginate.Page, err = strconv.Atoi(page); err != nil || paginate.Page < 1 {
+			return Paginate{}, invalidParam("page", "min",
+				fmt.Sprintf("page must be a positive integer, got %q", page), err)
+		}
+	}
+
+	if limit != "" {
+		paginate.Limit, err = strconv.Atoi(limit)
+		if err != nil || paginate.Limit < 1 || paginate.Limit > config.MaxLimit {
+			return Paginate{}, invalidParam("limit", "max",
+				fmt.Sprintf("limit must be an integer between 1 and %d, got %q", config.MaxLimit, limit), err)
+		}
+	}
+
+	paginate.SetDefaultLimitAndPage()
+
+	return paginate, nil
+}
+
+// ParseTime parses a RFC 3339 timestamp sent as query param, an empty value is
+// the zero time.
+func ParseTime(field, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, invalidParam(field, "datetime",
+			fmt.Sprintf("%s must be a RFC 3339 timestamp, got %q", field, value), err)
+	}
+
+	return parsed, nil
+}
+
+// ParseScore parses a score between 0 and 1 sent as query param, an empty value
+// is fallback.
+func ParseScore(field, value string, fallback float64) (float64, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	score, err := strconv.ParseFloat(value, 64)
+	if err != nil || score < 0 || score > 1 {
+		return 0, invalidParam(field, "score",
+			fmt.Sprintf("%s must be a number between 0 and 1, got %q", field, value), err)
+	}
+
+	return score, nil
+}
+
+// maxIdempotencyKeyLength bounds the length of idempotency keys, long enough for
+// the UUIDs and hashes clients usually send.
+const maxIdempotencyKeyLength = 255
+
+// ParseIdempotencyKey checks the value of the Idempotency-Key header: up to 255
+// visible ASCII characters.
+func ParseIdempotencyKey(value string) (string, error) {
+	valid := value != "" && len(value) <= maxIdempotencyKeyLength
+	for i := 0; valid && i < len(value); i++ {
+		valid = value[i] > ' ' && value[i] <= '~'
+	}
+
+	if !valid {
+		return "", invalidParam("Idempotency-Key", "idempotency_key", fmt.Sprintf(
+			"Idempotency-Key must be up to %d visible ASCII characters", maxIdempotencyKeyLength), nil)
+	}
+
+	return value, nil
+}
+
+// Bounds of the length, in characters, of the search sent to the search of
+// contacts. Shorter searches are similar to too many contacts to be useful.
+const (
+	minSearchLength = 2
+	maxSearchLength = 200
+)
+
+// ParseSearch trims the search sent as query param, it must be between 2 and
+// 200 characters long.
+func ParseSearch(field, value string) (string, error) {
+	search := strings.TrimSpace(value)
+
+	if length := utf8.RuneCountInString(search); length < minSearchLength || length > maxSearchLength {
+		return "", invalidParam(field, "len", fmt.Sprintf("%s must be between %d and %d characters long",
+			field, minSearchLength, maxSearchLength), nil)
+	}
+
+	return search, nil
+}
+
+func invalidParam(field, rule, message string, err error) error {
+	return apperrors.InvalidFields(message, err, []apperrors.FieldError{{
+		Field:   field,
+		Rule:    rule,
+		Message: message,
+	}})
+}
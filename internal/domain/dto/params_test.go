@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseID(t *testing.T) {
+	id, err := ParseID("id", "10")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), id)
+
+	for _, value := range []string{"", "abc", "0", "-5", "1.5", "9223372036854775808"} {
+		_, err = ParseID("id", value)
+
+		assert.True(t, apperrors.Is(err, apperrors.KindValidation), value)
+		assert.Equal(t, "id", apperrors.FieldsOf(err)[0].Field, value)
+	}
+}
+
+func TestParsePaginate(t *testing.T) {
+	config := PaginateConfig{MaxLimit: 100}
+
+	paginate, err := ParsePaginate("", "", config)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Paginate{Page: 1, Limit: 10}, paginate)
+
+	paginate, err = ParsePaginate("3", "100", config)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Paginate{Page: 3, Limit: 100}, paginate)
+
+	for _, values := range [][2]string{{"0", ""}, {"abc", ""}, {"", "0"}, {"", "-1"}, {"", "101"}, {"", "ten"}} {
+		_, err = ParsePaginate(values[0], values[1], config)
+
+		assert.True(t, apperrors.Is(err, apperrors.KindValidation), values)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	parsed, err := ParseTime("as_of", "2024-03-01T10:30:00-05:00")
+
+	assert.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Date(2024, time.March, 1, 15, 30, 0, 0, time.UTC)))
+
+	parsed, err = ParseTime("as_of", "")
+
+	assert.NoError(t, err)
+	assert.True(t, parsed.IsZero())
+
+	_, err = ParseTime("as_of", "2024-03-01")
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Equal(t, "as_of", apperrors.FieldsOf(err)[0].Field)
+}
+
+func TestParseIdempotencyKey(t *testing.T) {
+	key, err := ParseIdempotencyKey("8e03978e-40d5-43e8-bc93-6894a57f9324")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "8e03978e-40d5-43e8-bc93-6894a57f9324", key)
+
+	for _, value := range []string{"with space", "ñandú", strings.Repeat("k", 256)} {
+		_, err = ParseIdempotencyKey(value)
+
+		assert.Equal(t, "Idempotency-Key", apperrors.FieldsOf(err)[0].Field)
+	}
+}
+
+func TestParseScore(t *testing.T) {
+	score, err := ParseScore("min_score", "", 0.6)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.6, score)
+
+	score, err = ParseScore("min_score", "0.75", 0.6)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.75, score)
+
+	for _, value := range []string{"high", "1.5", "-0.1"} {
+		_, err = ParseScore("min_score", value, 0.6)
+
+		assert.Equal(t, "min_score", apperrors.FieldsOf(err)[0].Field)
+	}
+}
+
+func TestParseSearch(t *testing.T) {
+	search, err := ParseSearch("q", "  Pérez ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Pérez", search)
+
+	for _, value := range []string{"", " é ", strings.Repeat("a", 201)} {
+		_, err = ParseSearch("q", value)
+
+		assert.Equal(t, "q", apperrors.FieldsOf(err)[0].Field)
+	}
+}
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+const (
+	MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+	MIMEApplicationJSONPatchJSON  = "application/json-patch+json"
+)
+
+// ContactPatch is a partial update of a contact, either an RFC 7396 JSON Merge
+// Patch or an RFC 6902 JSON Patch document.
+type ContactPatch struct {
+	merge []byte
+	patch jsonpatch.Patch
+}
+
+func NewMergePatch(document []byte) (ContactPatch, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(document, &object); err != nil || object == nil {
+		return ContactPatch{}, apperrors.Validation("the merge patch must be a JSON object", err)
+	}
+
+	return ContactPatch{merge: document}, nil
+}
+
+func NewJSONPatch(document []byte) (ContactPatch, error) {
+	patch, err := jsonpatch.DecodePatch(document)
+	if err != nil {
+		return ContactPatch{}, apperrors.Validation("the JSON patch must be an array of operations", err)
+	}
+
+	return ContactPatch{patch: patch}, nil
+}
+
+// Apply returns contact with the patch applied. The result is not validated.
+func (p ContactPatch) Apply(contact Contact) (Contact, error) {
+	document, err := json.Marshal(contact)
+	if err != nil {
+		return Contact{}, apperrors.Internal(err)
+	}
+
+	if p.patch != nil {
+		document, err = p.patch.Apply(document)
+	} else {
+		document, err = jsonpatch.MergePatch(document, p.merge)
+	}
+
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return Contact{}, apperrors.Conflict("a test operation of the patch failed", err)
+		}
+
+		return Contact{}, apperrors.Validation("the patch can not be applied: "+err.Error(), err)
+	}
+
+	var patched Contact
+
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+
+	if err = decoder.Decode(&patched); err != nil {
+		return Contact{}, apperrors.Validation("the patched contact is invalid: "+err.Error(), err)
+	}
+
+	return patched, nil
+}
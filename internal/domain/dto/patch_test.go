@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMergePatch_WhenNotAnObject(t *testing.T) {
+	for _, document := range []string{"", "[]", "null", `"name"`} {
+		_, err := NewMergePatch([]byte(document))
+
+		assert.True(t, apperrors.Is(err, apperrors.KindValidation), document)
+	}
+}
+
+func TestNewJSONPatch_WhenNotAnArray(t *testing.T) {
+	_, err := NewJSONPatch([]byte(`{"name": "test"}`))
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestContactPatch_ApplyMergePatch(t *testing.T) {
+	patch, err := NewMergePatch([]byte(`{"name": "new name", "phone_number": null}`))
+	assert.NoError(t, err)
+
+	contact, err := patch.Apply(Contact{Name: "test", PhoneNumber: "+570000000"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Contact{Name: "new name"}, contact)
+}
+
+func TestContactPatch_ApplyJSONPatch(t *testing.T) {
+	patch, err := NewJSONPatch([]byte(`[
+		{"op": "test", "path": "/name", "value": "test"},
+		{"op": "replace", "path": "/phone_number", "value": "+570000001"}
+	]`))
+	assert.NoError(t, err)
+
+	contact, err := patch.Apply(Contact{Name: "test", PhoneNumber: "+570000000"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Contact{Name: "test", PhoneNumber: "+570000001"}, contact)
+}
+
+func TestContactPatch_ApplyWhenTestFails(t *testing.T) {
+	patch, _ := NewJSONPatch([]byte(`[{"op": "test", "path": "/name", "value": "other"}]`))
+
+	_, err := patch.Apply(Contact{Name: "test", PhoneNumber: "+570000000"})
+
+	assert.True(t, apperrors.Is(err, apperrors.KindConflict))
+}
+
+func TestContactPatch_ApplyWhenPathIsMissing(t *testing.T) {
+	patch, _ := NewJSONPatch([]byte(`[{"op": "remove", "path": "/nickname"}]`))
+
+	_, err := patch.Apply(Contact{Name: "test", PhoneNumber: "+570000000"})
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
+
+func TestContactPatch_ApplyWhenFieldIsUnknown(t *testing.T) {
+	patch, _ := NewMergePatch([]byte(`{"id": 20}`))
+
+	_, err := patch.Apply(Contact{Name: "test", PhoneNumber: "+570000000"})
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+}
@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/nyaruka/phonenumbers"
+)
+
+var phoneTypes = map[phonenumbers.PhoneNumberType]string{
+	phonenumbers.FIXED_LINE:           "fixed_line",
+	phonenumbers.MOBILE:               "mobile",
+	phonenumbers.FIXED_LINE_OR_MOBILE: "fixed_line_or_mobile",
+	phonenumbers.TOLL_FREE:            "toll_free",
+	phonenumbers.PREMIUM_RATE:         "premium_rate",
+	phonenumbers.SHARED_COST:          "shared_cost",
+	phonenumbers.VOIP:                 "voip",
+	phonenumbers.PERSONAL_NUMBER:      "personal_number",
+	phonenumbers.PAGER:                "pager",
+	phonenumbers.UAN:                  "uan",
+	phonenumbers.VOICEMAIL:            "voicemail",
+}
+
+// PhoneNumber is a phone number parsed from the text sent by a client.
+type PhoneNumber struct {
+	E164    string
+	Display string
+	Region  string
+	Type    string
+}
+
+// PhoneNormalizer parses phone numbers into E.164, numbers written without an
+// international prefix are read as numbers of the default region.
+type PhoneNormalizer struct {
+	region string
+}
+
+// NewPhoneNormalizer creates a normalizer for the ISO 3166-1 region code region.
+func NewPhoneNormalizer(region string) (PhoneNormalizer, error) {
+	region = strings.ToUpper(region)
+	if !phonenumbers.GetSupportedRegions()[region] {
+		return PhoneNormalizer{}, fmt.Errorf("unsupported phone region %q", region)
+	}
+
+	return PhoneNormalizer{region: region}, nil
+}
+
+// Parse validates number and returns its E.164 form along with the region and
+// type of line it belongs to. The text of number is kept as display format.
+func (normalizer PhoneNormalizer) Parse(number string) (PhoneNumber, error) {
+	display := strings.TrimSpace(number)
+
+	parsed, err := phonenumbers.Parse(display, normalizer.region)
+	if err != nil {
+		return PhoneNumber{}, err
+	}
+
+	if !phonenumbers.IsValidNumber(parsed) {
+		return PhoneNumber{}, fmt.Errorf("%q is not a valid phone number", number)
+	}
+
+	numberType, ok := phoneTypes[phonenumbers.GetNumberType(parsed)]
+	if !ok {
+		numberType = "unknown"
+	}
+
+	return PhoneNumber{
+		E164:    phonenumbers.Format(parsed, phonenumbers.E164),
+		Display: display,
+		Region:  phonenumbers.GetRegionCodeForNumber(parsed),
+		Type:    numberType,
+	}, nil
+}
+
+// Normalize replaces the phone numbers of contact by their E.164 form, keeping
+// the text sent by the client as display format. Every invalid number is
+// reported as a field error.
+func (normalizer PhoneNormalizer) Normalize(contact *models.Contact) error {
+	var fields []apperrors.FieldError
+
+	number, err := normalizer.Parse(contact.PhoneNumber)
+	if err != nil {
+		fields = append(fields, invalidPhone("phone_number"))
+	} else {
+		contact.PhoneNumber = number.E164
+		contact.PhoneDisplay = number.Display
+		contact.PhoneRegion = number.Region
+		contact.PhoneType = number.Type
+	}
+
+	for i := range contact.Phones {
+		phone := &contact.Phones[i]
+
+		number, err = normalizer.Parse(phone.Number)
+		if err != nil {
+			fields = append(fields, invalidPhone(fmt.Sprintf("phones[%d].number", i)))
+			continue
+		}
+
+		phone.Number = number.E164
+		phone.Display = number.Display
+		phone.Region = number.Region
+		phone.Type = number.Type
+	}
+
+	if len(fields) > 0 {
+		return apperrors.InvalidFields(invalidFieldsMessage, nil, fields)
+	}
+
+	return nil
+}
+
+// NormalizeFilters rewrites the exact phone number filters in E.164 so the
+// number is found whatever its formatting. Values that are not valid numbers
+// are left as sent.
+func (normalizer PhoneNormalizer) NormalizeFilters(filters []models.FieldFilter) []models.FieldFilter {
+	if len(filters) == 0 {
+		return filters
+	}
+
+	normalized := make([]models.FieldFilter, len(filters))
+
+	for i, filter := range filters {
+		if filter.Field == "phone_number" && !filter.Prefix {
+			if number, err := normalizer.Parse(filter.Value); err == nil {
+				filter.Value = number.E164
+			}
+		}
+
+		normalized[i] = filter
+	}
+
+	return normalized
+}
+
+func invalidPhone(field string) apperrors.FieldError {
+	return apperrors.FieldError{
+		Field:   field,
+		Rule:    "phone",
+		Message: fmt.Sprintf("%s must be a valid phone number", field),
+	}
+}
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPhoneNormalizer(t *testing.T) {
+	_, err := NewPhoneNormalizer("co")
+	assert.NoError(t, err)
+
+	_, err = NewPhoneNormalizer("XX")
+	assert.Error(t, err)
+}
+
+func TestPhoneNormalizer_Parse(t *testing.T) {
+	normalizer, _ := NewPhoneNormalizer("CO")
+
+	for _, number := range []string{"+573000000000", "+57 300 000 0000", "573000000000", " 300-000-0000 "} {
+		parsed, err := normalizer.Parse(number)
+
+		assert.NoError(t, err, number)
+		assert.Equal(t, "+573000000000", parsed.E164, number)
+		assert.Equal(t, "CO", parsed.Region, number)
+		assert.Equal(t, "mobile", parsed.Type, number)
+	}
+
+	parsed, err := normalizer.Parse("+1 650 253 0000")
+	assert.NoError(t, err)
+	assert.Equal(t, PhoneNumber{E164: "+16502530000", Display: "+1 650 253 0000", Region: "US",
+		Type: "fixed_line_or_mobile"}, parsed)
+
+	_, err = normalizer.Parse("+570000000")
+	assert.Error(t, err)
+
+	_, err = normalizer.Parse("not a number")
+	assert.Error(t, err)
+}
+
+func TestPhoneNormalizer_Normalize(t *testing.T) {
+	normalizer, _ := NewPhoneNormalizer("CO")
+
+	contact := models.Contact{
+		PhoneNumber: "300 000 0000",
+		Phones:      []models.Phone{{Label: "work", Number: "(601) 555 1234"}},
+	}
+
+	assert.NoError(t, normalizer.Normalize(&contact))
+	assert.Equal(t, models.Contact{
+		PhoneNumber:  "+573000000000",
+		PhoneDisplay: "300 000 0000",
+		PhoneRegion:  "CO",
+		PhoneType:    "mobile",
+		Phones: []models.Phone{{
+			Label:   "work",
+			Number:  "+576015551234",
+			Display: "(601) 555 1234",
+			Region:  "CO",
+			Type:    "fixed_line",
+		}},
+	}, contact)
+
+	err := normalizer.Normalize(&models.Contact{
+		PhoneNumber: "123",
+		Phones:      []models.Phone{{Label: "work", Number: "300 000 0000"}, {Label: "home", Number: "abc"}},
+	})
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "phone_number", Rule: "phone", Message: "phone_number must be a valid phone number"},
+		{Field: "phones[1].number", Rule: "phone", Message: "phones[1].number must be a valid phone number"},
+	}, apperrors.FieldsOf(err))
+}
+
+func TestContact_NewContactKeepsPhoneDisplay(t *testing.T) {
+	contact := NewContact(models.Contact{
+		PhoneNumber:  "+573000000000",
+		PhoneDisplay: "300 000 0000",
+		Phones:       []models.Phone{{Label: "work", Number: "+576015551234", Display: "(601) 555 1234"}},
+	})
+
+	assert.Equal(t, "300 000 0000", contact.PhoneNumber)
+	assert.Equal(t, "(601) 555 1234", contact.Phones[0].Number)
+}
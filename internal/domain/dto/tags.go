@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+type Tag struct {
+	Name  string `json:"name" validate:"required,max=50" example:"family"`
+	Color string `json:"color,omitempty" validate:"omitempty,hexcolor" example:"#ff8800"`
+}
+
+func (dto Tag) ToModel() models.Tag {
+	return models.Tag{
+		Name:  strings.TrimSpace(dto.Name),
+		Color: dto.Color,
+	}
+}
+
+func (dto Tag) Validate() error {
+	return validateStruct(dto)
+}
+
+// TagAssignment adds or removes Tags, by name, on every contact in ContactIDs.
+type TagAssignment struct {
+	ContactIDs []uint   `json:"contact_ids" validate:"required,min=1,max=1000,dive,min=1"`
+	Tags       []string `json:"tags" validate:"required,min=1,max=50,dive,required,max=50"`
+}
+
+func (dto TagAssignment) Validate() error {
+	return validateStruct(dto)
+}
+
+// UniqueContactIDs returns ContactIDs without repetitions, in their first order.
+func (dto TagAssignment) UniqueContactIDs() []uint {
+	return uniqueIDs(dto.ContactIDs)
+}
+
+// UniqueTags returns the trimmed Tags without repetitions, in their first order.
+func (dto TagAssignment) UniqueTags() []string {
+	return uniqueTags(dto.Tags)
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+func uniqueTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	unique := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+
+	return unique
+}
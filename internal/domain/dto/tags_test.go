@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTag_Validate(t *testing.T) {
+	assert.NoError(t, Tag{Name: "family", Color: "#ff8800"}.Validate())
+
+	err := Tag{Color: "orange"}.Validate()
+
+	assert.True(t, apperrors.Is(err, apperrors.KindValidation))
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "color", Rule: "hexcolor", Message: "color failed on the hexcolor rule"},
+	}, apperrors.FieldsOf(err))
+}
+
+func TestTagAssignment_Validate(t *testing.T) {
+	assert.NoError(t, TagAssignment{ContactIDs: []uint{1}, Tags: []string{"family"}}.Validate())
+
+	err := TagAssignment{ContactIDs: []uint{0}}.Validate()
+
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "contact_ids[0]", Rule: "min", Message: "contact_ids[0] must be at least 1"},
+		{Field: "tags", Rule: "required", Message: "tags is required"},
+	}, apperrors.FieldsOf(err))
+}
+
+func TestParseTags(t *testing.T) {
+	tags, any, err := ParseTags([]string{"family, work", "family", ""}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"family", "work"}, tags)
+	assert.False(t, any)
+
+	tags, any, err = ParseTags(nil, "any")
+
+	assert.NoError(t, err)
+	assert.Nil(t, tags)
+	assert.True(t, any)
+
+	_, _, err = ParseTags([]string{"family"}, "some")
+
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "tag_match", Rule: "oneof", Message: `tag_match must be one of [all any], got "some"`},
+	}, apperrors.FieldsOf(err))
+}
@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/go-playground/validator/v10"
+)
+
+const invalidFieldsMessage = "the request has invalid fields"
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+	_ = validate.RegisterValidation("one_primary", onePrimary)
+	validate.RegisterAlias("contact_name", "required_without_all=GivenName FamilyName Nickname")
+
+	return validate
+}
+
+// onePrimary accepts slices of structs where at most one element has its
+// Primary field set.
+func onePrimary(fieldLevel validator.FieldLevel) bool {
+	field := fieldLevel.Field()
+	if field.Kind() != reflect.Slice {
+		return true
+	}
+
+	primaries := 0
+	for i := 0; i < field.Len(); i++ {
+		primary := reflect.Indirect(field.Index(i)).FieldByName("Primary")
+		if primary.IsValid() && primary.Kind() == reflect.Bool && primary.Bool() {
+			primaries++
+		}
+	}
+
+	return primaries <= 1
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+// validateStruct validates value and reports every rejected field in an
+// apperrors validation error.
+func validateStruct(value interface{}) error {
+	if err := validate.Struct(value); err != nil {
+		return validationError(err)
+	}
+
+	return nil
+}
+
+func validationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return apperrors.Validation(err.Error(), err)
+	}
+
+	fields := make([]apperrors.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, apperrors.FieldError{
+			Field:   fieldPath(fieldError),
+			Rule:    fieldError.Tag(),
+			Message: fieldMessage(fieldError),
+		})
+	}
+
+	return apperrors.InvalidFields(invalidFieldsMessage, err, fields)
+}
+
+// fieldPath drops the name of the validated struct from the namespace, so
+// "Contact.phone_number" is reported as "phone_number".
+func fieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if index := strings.Index(namespace, "."); index >= 0 {
+		return namespace[index+1:]
+	}
+
+	return namespace
+}
+
+func fieldMessage(fieldError validator.FieldError) string {
+	field := fieldPath(fieldError)
+
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, fieldError.Param(), sizeUnit(fieldError.Kind()))
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, fieldError.Param(), sizeUnit(fieldError.Kind()))
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, fieldError.Param())
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "url":
+		return fmt.Sprintf("%s must be a URL or a data URI", field)
+	case "required_unless":
+		name, value, _ := strings.Cut(fieldError.Param(), " ")
+		return fmt.Sprintf("%s is required unless %s is %s", field, strings.ToLower(name), value)
+	case "excluded_if":
+		name, value, _ := strings.Cut(fieldError.Param(), " ")
+		return fmt.Sprintf("%s must be empty when %s is %s", field, strings.ToLower(name), value)
+	case "contact_name":
+		return fmt.Sprintf("%s is required when given_name, family_name and nickname are empty", field)
+	case "one_primary":
+		return fmt.Sprintf("%s must have at most one primary item", field)
+	default:
+		return fmt.Sprintf("%s failed on the %s rule", field, fieldError.Tag())
+	}
+}
+
+// sizeUnit is the unit of the min and max rules for values of kind.
+func sizeUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
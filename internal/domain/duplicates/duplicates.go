@@ -0,0 +1,272 @@
+// Package duplicates finds contacts that are likely the same person, scoring
+// pairs of contacts on how similar their names are and the phone numbers and
+// email addresses they share, and merges them.
+//
+// Only contacts sharing a name word, a phone number or an email address are
+// compared, so finding duplicates does not compare every pair of contacts.
+package duplicates
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Weights of the name similarity, a shared phone number and a shared email
+// address in the score of a pair.
+const (
+	nameWeight  = 0.6
+	phoneWeight = 0.25
+	emailWeight = 0.15
+)
+
+// DefaultMinScore is the score from which a pair is suspected to be a duplicate:
+// reached by equal names, or by similar ones sharing a phone number or an email.
+const DefaultMinScore = 0.6
+
+// maxBlockSize bounds the contacts compared with each other for sharing a name
+// word, phone or email. Larger blocks, like the one of a very common first name,
+// are skipped as they tell little about the contacts in them.
+const maxBlockSize = 500
+
+// Phone numbers are compared by their last phoneKeyDigits digits, so a number
+// with or without its country code is the same; numbers with fewer than
+// minPhoneDigits digits are not compared.
+const (
+	minPhoneDigits = 7
+	phoneKeyDigits = 10
+)
+
+// Score scores the pair of contacts a and b, whose phones and emails must be
+// loaded.
+func Score(a, b models.Contact) models.DuplicatePair {
+	pair := models.DuplicatePair{
+		ContactID:      a.ID,
+		OtherID:        b.ID,
+		NameSimilarity: round(NameSimilarity(a.Name, b.Name)),
+		SharedPhones:   shared(phoneKeys(a), phoneKeys(b)),
+		SharedEmails:   shared(emailKeys(a), emailKeys(b)),
+	}
+
+	score := nameWeight * pair.NameSimilarity
+
+	if len(pair.SharedPhones) > 0 {
+		score += phoneWeight
+	}
+
+	if len(pair.SharedEmails) > 0 {
+		score += emailWeight
+	}
+
+	pair.Score = round(score)
+
+	return pair
+}
+
+// Find groups contacts in clusters of suspected duplicates, linked by pairs that
+// score at least minScore. Clusters come the most likely first, with their
+// contacts by id and their pairs by score.
+func Find(contacts []models.Contact, minScore float64) []models.DuplicateCluster {
+	compared := map[[2]int]bool{}
+	parents := make([]int, len(contacts))
+
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var pairs []models.DuplicatePair
+	var pairIndexes [][2]int
+
+	for _, block := range blocks(contacts) {
+		if len(block) < 2 || len(block) > maxBlockSize {
+			continue
+		}
+
+		for i, first := range block {
+			for _, second := range block[i+1:] {
+				key := [2]int{first, second}
+				if compared[key] {
+					continue
+				}
+
+				compared[key] = true
+
+				pair := Score(contacts[first], contacts[second])
+				if pair.Score < minScore {
+					continue
+				}
+
+				pairs = append(pairs, pair)
+				pairIndexes = append(pairIndexes, key)
+				parents[root(parents, first)] = root(parents, second)
+			}
+		}
+	}
+
+	byRoot := map[int]*models.DuplicateCluster{}
+	for i, pair := range pairs {
+		cluster, found := byRoot[root(parents, pairIndexes[i][0])]
+		if !found {
+			cluster = &models.DuplicateCluster{}
+			byRoot[root(parents, pairIndexes[i][0])] = cluster
+		}
+
+		cluster.Pairs = append(cluster.Pairs, pair)
+		if pair.Score > cluster.Score {
+			cluster.Score = pair.Score
+		}
+	}
+
+	for i, contact := range contacts {
+		if cluster, found := byRoot[root(parents, i)]; found {
+			cluster.Contacts = append(cluster.Contacts, contact)
+		}
+	}
+
+	clusters := make([]models.DuplicateCluster, 0, len(byRoot))
+	for _, cluster := range byRoot {
+		sort.Slice(cluster.Contacts, func(i, j int) bool {
+			return cluster.Contacts[i].ID < cluster.Contacts[j].ID
+		})
+
+		sort.SliceStable(cluster.Pairs, func(i, j int) bool {
+			return cluster.Pairs[i].Score > cluster.Pairs[j].Score
+		})
+
+		clusters = append(clusters, *cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Score != clusters[j].Score {
+			return clusters[i].Score > clusters[j].Score
+		}
+
+		return clusters[i].Contacts[0].ID < clusters[j].Contacts[0].ID
+	})
+
+	return clusters
+}
+
+// blocks lists, for every name word, phone and email, the indexes of the
+// contacts having it.
+func blocks(contacts []models.Contact) map[string][]int {
+	blocks := map[string][]int{}
+
+	for i, contact := range contacts {
+		keys := map[string]bool{}
+
+		for _, word := range strings.Fields(NormalizeName(contact.Name)) {
+			if len([]rune(word)) > 1 {
+				keys["name:"+word] = true
+			}
+		}
+
+		for key := range phoneKeys(contact) {
+			keys["phone:"+key] = true
+		}
+
+		for key := range emailKeys(contact) {
+			keys["email:"+key] = true
+		}
+
+		for key := range keys {
+			blocks[key] = append(blocks[key], i)
+		}
+	}
+
+	return blocks
+}
+
+// root finds the representative of the cluster of i, flattening the path to it.
+func root(parents []int, i int) int {
+	for parents[i] != i {
+		parents[i] = parents[parents[i]]
+		i = parents[i]
+	}
+
+	return i
+}
+
+// phoneKeys maps the comparable form of every phone number of the contact to
+// the number.
+func phoneKeys(contact models.Contact) map[string]string {
+	keys := map[string]string{}
+
+	add := func(number string) {
+		if key := phoneKey(number); key != "" {
+			if _, found := keys[key]; !found {
+				keys[key] = number
+			}
+		}
+	}
+
+	add(contact.PhoneNumber)
+
+	for _, phone := range contact.Phones {
+		add(phone.Number)
+	}
+
+	return keys
+}
+
+// phoneKey is the comparable form of number, empty when it is too short.
+func phoneKey(number string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, number)
+
+	if len(digits) < minPhoneDigits {
+		return ""
+	}
+
+	if len(digits) > phoneKeyDigits {
+		digits = digits[len(digits)-phoneKeyDigits:]
+	}
+
+	return digits
+}
+
+// emailKeys maps the comparable form of every email of the contact to its
+// address.
+func emailKeys(contact models.Contact) map[string]string {
+	keys := map[string]string{}
+
+	for _, email := range contact.Emails {
+		key := emailKey(email.Address)
+		if _, found := keys[key]; !found && key != "" {
+			keys[key] = email.Address
+		}
+	}
+
+	return keys
+}
+
+func emailKey(address string) string {
+	return strings.ToLower(strings.TrimSpace(address))
+}
+
+// shared returns, sorted, the values of a whose keys are also in b.
+func shared(a, b map[string]string) []string {
+	var values []string
+
+	for key, value := range a {
+		if _, found := b[key]; found {
+			values = append(values, value)
+		}
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
@@ -0,0 +1,128 @@
+package duplicates
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "juan perez", NormalizeName("  Juan   Pérez. "))
+	assert.Equal(t, "jose maria o neill", NormalizeName("JOSÉ-MARÍA O'Neill"))
+	assert.Equal(t, "", NormalizeName("..."))
+}
+
+func TestNameSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, NameSimilarity("Juan Perez", "Juan Pérez"))
+	assert.Equal(t, 1.0, NameSimilarity("Pérez Juan", "juan perez"))
+	assert.InDelta(t, 0.92, NameSimilarity("Juan P", "Juan Pérez"), 0.001)
+	assert.InDelta(t, 0.9, NameSimilarity("J Pérez", "Pérez Juan"), 0.001)
+	assert.Greater(t, NameSimilarity("Jaun Perez", "Juan Perez"), 0.9)
+	assert.Equal(t, 0.0, NameSimilarity("Juan Perez", "Ana Gómez"))
+	assert.Equal(t, 0.0, NameSimilarity("", "Juan"))
+}
+
+func TestScore(t *testing.T) {
+	juan := models.Contact{ID: 1, Name: "Juan Pérez", PhoneNumber: "+573000000000",
+		Emails: []models.Email{{Address: "juan@example.com"}}}
+	other := models.Contact{ID: 2, Name: "Juan P", PhoneNumber: "+573100000000",
+		Phones: []models.Phone{{Number: "300 000 0000"}}, Emails: []models.Email{{Address: "JUAN@example.com"}}}
+
+	assert.Equal(t, models.DuplicatePair{
+		ContactID:      1,
+		OtherID:        2,
+		Score:          0.952,
+		NameSimilarity: 0.92,
+		SharedPhones:   []string{"+573000000000"},
+		SharedEmails:   []string{"juan@example.com"},
+	}, Score(juan, other))
+
+	assert.Equal(t, 0.552, Score(juan, models.Contact{ID: 3, Name: "Juan P", PhoneNumber: "+573200000000"}).Score)
+}
+
+func TestFind(t *testing.T) {
+	contacts := []models.Contact{
+		{ID: 1, Name: "Juan Pérez", PhoneNumber: "+573000000000"},
+		{ID: 2, Name: "Ana Gómez", PhoneNumber: "+573100000000"},
+		{ID: 3, Name: "Juan P", PhoneNumber: "+573200000000", Phones: []models.Phone{{Number: "3000000000"}}},
+		{ID: 4, Name: "Ana Gomez", PhoneNumber: "+573300000000"},
+		{ID: 5, Name: "Juan Perez", PhoneNumber: "+573400000000"},
+		{ID: 6, Name: "Luis Díaz", PhoneNumber: "+573500000000"},
+	}
+
+	clusters := Find(contacts, DefaultMinScore)
+
+	assert.Len(t, clusters, 2)
+	assert.Equal(t, 0.802, clusters[0].Score)
+	assert.Equal(t, []uint{1, 3, 5}, contactIDs(clusters[0]))
+	assert.Equal(t, []uint{2, 4}, contactIDs(clusters[1]))
+	assert.Equal(t, []models.DuplicatePair{{ContactID: 2, OtherID: 4, Score: 0.6, NameSimilarity: 1}}, clusters[1].Pairs)
+
+	assert.Empty(t, Find(contacts, 1.1))
+}
+
+func TestMerge(t *testing.T) {
+	survivor := models.Contact{
+		ID:             1,
+		Name:           "Juan P",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "P"},
+		PhoneNumber:    "+573000000000",
+		Version:        3,
+		Phones:         []models.Phone{{ID: 10, ContactID: 1, Label: "work", Number: "+576010000000", Primary: true}},
+		Emails:         []models.Email{{ID: 11, ContactID: 1, Label: "home", Address: "juan@example.com"}},
+		Tags:           []models.Tag{{ID: 1, Name: "friends"}},
+	}
+
+	other := models.Contact{
+		ID:             2,
+		Name:           "Juan Pérez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Pérez"},
+		PhoneNumber:    "+573100000000",
+		PhoneDisplay:   "310 000 0000",
+		Notes:          "met at the fair",
+		Photo:          "https://example.com/juan.jpg",
+		Phones:         []models.Phone{{ID: 20, ContactID: 2, Label: "home", Number: "+576010000000", Primary: true}},
+		Emails: []models.Email{
+			{ID: 21, ContactID: 2, Label: "home", Address: "JUAN@example.com"},
+			{ID: 22, ContactID: 2, Label: "work", Address: "juan@work.example.com", Primary: true},
+		},
+		Addresses: []models.Address{{ID: 23, ContactID: 2, Label: "home", Street: "Calle 100", Primary: true}},
+	}
+
+	merged := Merge(survivor, []models.Contact{other}, map[models.MergeField]uint{models.MergeName: 2})
+
+	assert.Equal(t, models.Contact{
+		ID:             1,
+		Name:           "Juan Pérez",
+		StructuredName: models.StructuredName{GivenName: "Juan", FamilyName: "Pérez"},
+		PhoneNumber:    "+573000000000",
+		Version:        3,
+		Notes:          "met at the fair",
+		Photo:          "https://example.com/juan.jpg",
+		Phones: []models.Phone{
+			{Label: "work", Number: "+576010000000", Primary: true},
+			{Label: "other", Number: "+573100000000", Display: "310 000 0000"},
+		},
+		Emails: []models.Email{
+			{Label: "home", Address: "juan@example.com"},
+			{Label: "work", Address: "juan@work.example.com", Primary: true},
+		},
+		Addresses: []models.Address{{Label: "home", Street: "Calle 100", Primary: true}},
+	}, merged)
+
+	merged = Merge(survivor, []models.Contact{other}, map[models.MergeField]uint{models.MergePhoneNumber: 2})
+
+	assert.Equal(t, "+573100000000", merged.PhoneNumber)
+	assert.Equal(t, "Juan P", merged.Name)
+	assert.Equal(t, "+573000000000", merged.Phones[1].Number)
+}
+
+func contactIDs(cluster models.DuplicateCluster) []uint {
+	ids := make([]uint, len(cluster.Contacts))
+	for i, contact := range cluster.Contacts {
+		ids[i] = contact.ID
+	}
+
+	return ids
+}
@@ -0,0 +1,164 @@
+package duplicates
+
+import (
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// mergedPhoneLabel is the label of the phones made of the phone numbers that no
+// longer identify a contact after a merge.
+const mergedPhoneLabel = "other"
+
+// Merge returns the content survivor is left with once others are merged into
+// it. picks names, for some fields, the contact whose value is kept; the other
+// fields keep the value of survivor or, when it has none, the first one set
+// among others. Phones, emails and addresses are joined without repetitions,
+// keeping the first primary item, and the phone numbers of the contacts that are
+// not kept as the phone number become additional phones.
+func Merge(survivor models.Contact, others []models.Contact, picks map[models.MergeField]uint) models.Contact {
+	contacts := append([]models.Contact{survivor}, others...)
+
+	source := func(field models.MergeField) (models.Contact, bool) {
+		for _, contact := range contacts {
+			if id, picked := picks[field]; picked && contact.ID == id {
+				return contact, true
+			}
+		}
+
+		return survivor, false
+	}
+
+	text := func(field models.MergeField, value func(contact models.Contact) string) string {
+		if contact, picked := source(field); picked {
+			return value(contact)
+		}
+
+		for _, contact := range contacts {
+			if value(contact) != "" {
+				return value(contact)
+			}
+		}
+
+		return ""
+	}
+
+	merged := survivor
+	merged.Tags = nil
+
+	named, _ := source(models.MergeName)
+	merged.Name = named.Name
+	merged.StructuredName = named.StructuredName
+
+	phoned, _ := source(models.MergePhoneNumber)
+	merged.PhoneNumber = phoned.PhoneNumber
+	merged.PhoneDisplay = phoned.PhoneDisplay
+	merged.PhoneRegion = phoned.PhoneRegion
+	merged.PhoneType = phoned.PhoneType
+
+	merged.Company = text(models.MergeCompany, func(contact models.Contact) string { return contact.Company })
+	merged.Notes = text(models.MergeNotes, func(contact models.Contact) string { return contact.Notes })
+	merged.Photo = text(models.MergePhoto, func(contact models.Contact) string { return contact.Photo })
+
+	merged.Phones = mergePhones(merged.PhoneNumber, contacts)
+	merged.Emails = mergeEmails(contacts)
+	merged.Addresses = mergeAddresses(contacts)
+
+	return merged
+}
+
+func mergePhones(number string, contacts []models.Contact) []models.Phone {
+	seen := map[string]bool{comparablePhone(number): true}
+	primary := false
+
+	var phones []models.Phone
+
+	add := func(phone models.Phone) {
+		key := comparablePhone(phone.Number)
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+		phone.ID, phone.ContactID = 0, 0
+		phone.Primary = phone.Primary && !primary
+		primary = primary || phone.Primary
+		phones = append(phones, phone)
+	}
+
+	for _, contact := range contacts {
+		for _, phone := range contact.Phones {
+			add(phone)
+		}
+	}
+
+	for _, contact := range contacts {
+		add(models.Phone{
+			Label:   mergedPhoneLabel,
+			Number:  contact.PhoneNumber,
+			Display: contact.PhoneDisplay,
+			Region:  contact.PhoneRegion,
+			Type:    contact.PhoneType,
+		})
+	}
+
+	return phones
+}
+
+// comparablePhone is the form phone numbers are told apart by in a merge.
+func comparablePhone(number string) string {
+	if key := phoneKey(number); key != "" {
+		return key
+	}
+
+	return number
+}
+
+func mergeEmails(contacts []models.Contact) []models.Email {
+	seen := map[string]bool{}
+	primary := false
+
+	var emails []models.Email
+
+	for _, contact := range contacts {
+		for _, email := range contact.Emails {
+			key := emailKey(email.Address)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			email.ID, email.ContactID = 0, 0
+			email.Primary = email.Primary && !primary
+			primary = primary || email.Primary
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+func mergeAddresses(contacts []models.Contact) []models.Address {
+	seen := map[string]bool{}
+	primary := false
+
+	var addresses []models.Address
+
+	for _, contact := range contacts {
+		for _, address := range contact.Addresses {
+			key := strings.ToLower(strings.Join([]string{address.Street, address.City, address.Region,
+				address.PostalCode, address.Country}, "\x00"))
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			address.ID, address.ContactID = 0, 0
+			address.Primary = address.Primary && !primary
+			primary = primary || address.Primary
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
@@ -0,0 +1,197 @@
+package duplicates
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Minimum similarity for two different names, or words of a name, to count as
+// similar at all, and the similarity given to a word that is the initial or a
+// prefix of another.
+const (
+	similarThreshold = 0.85
+	prefixTokenMatch = 0.8
+)
+
+// NormalizeName lowers name, strips its accents and keeps its words separated by
+// single spaces, so "Juan  Pérez." becomes "juan perez".
+func NormalizeName(name string) string {
+	var builder strings.Builder
+
+	space := false
+
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && builder.Len() > 0 {
+				builder.WriteByte(' ')
+			}
+
+			builder.WriteRune(unicode.ToLower(r))
+			space = false
+		default:
+			space = true
+		}
+	}
+
+	return builder.String()
+}
+
+// NameSimilarity scores, between 0 and 1, how similar two names are once
+// normalized. Names are compared as a whole and word by word, the best of both
+// is kept: the first catches typos and the second reordered words, initials and
+// missing words. Names that are barely similar score 0.
+func NameSimilarity(a, b string) float64 {
+	a, b = NormalizeName(a), NormalizeName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+
+	if a == b {
+		return 1
+	}
+
+	whole := jaroWinkler(a, b)
+	if whole < similarThreshold {
+		whole = 0
+	}
+
+	if tokens := tokenSimilarity(strings.Fields(a), strings.Fields(b)); tokens > whole {
+		return tokens
+	}
+
+	return whole
+}
+
+// tokenSimilarity matches every word of the shorter name with its best match in
+// the longer one, the words left unmatched count as different.
+func tokenSimilarity(a, b []string) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	used := make([]bool, len(b))
+
+	var total float64
+
+	for _, token := range a {
+		best, bestIndex := 0.0, -1
+
+		for i, other := range b {
+			if used[i] {
+				continue
+			}
+
+			if similarity := tokenMatch(token, other); similarity > best {
+				best, bestIndex = similarity, i
+			}
+		}
+
+		if bestIndex >= 0 {
+			used[bestIndex] = true
+			total += best
+		}
+	}
+
+	return total / float64(len(b))
+}
+
+func tokenMatch(a, b string) float64 {
+	switch {
+	case a == b:
+		return 1
+	case strings.HasPrefix(a, b) || strings.HasPrefix(b, a):
+		return prefixTokenMatch
+	}
+
+	if similarity := jaroWinkler(a, b); similarity >= similarThreshold {
+		return similarity
+	}
+
+	return 0
+}
+
+// jaroWinkler is the Jaro-Winkler similarity of a and b, which favors strings
+// sharing a prefix.
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+
+	jaro := jaroSimilarity(ra, rb)
+
+	prefix := 0
+	for prefix < len(ra) && prefix < len(rb) && prefix < 4 && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+func jaroSimilarity(a, b []rune) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+
+	window := longest/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+
+	for i := range a {
+		from, to := i-window, i+window+1
+		if from < 0 {
+			from = 0
+		}
+
+		if to > len(b) {
+			to = len(b)
+		}
+
+		for j := from; j < to; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+
+				break
+			}
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+
+		for !matchedB[j] {
+			j++
+		}
+
+		if a[i] != b[j] {
+			transpositions++
+		}
+
+		j++
+	}
+
+	m := float64(matches)
+
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+}
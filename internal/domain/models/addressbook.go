@@ -0,0 +1,38 @@
+package models
+
+// CardResource is a contact seen as a resource of the CardDAV address book.
+// Name is the last segment of its URL, chosen by the client that created it, and
+// UID the UID of its vCard. Contacts that no client named are served under the
+// default name of their id.
+type CardResource struct {
+	Name      string   `json:"name" gorm:"primaryKey"`
+	ContactID uint     `json:"contact_id" gorm:"not null;uniqueIndex"`
+	UID       string   `json:"uid" gorm:"not null;default:''"`
+	Contact   *Contact `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// CardTombstone keeps the name of the resource of a purged contact, so clients
+// syncing the address book are told the card is gone under the name they gave.
+type CardTombstone struct {
+	Name      string `json:"name" gorm:"primaryKey"`
+	ContactID uint   `json:"contact_id" gorm:"not null;index"`
+}
+
+// AddressBookChanges are the contacts changed after a sync token, in the order
+// of their last change, and the token that follows that change.
+type AddressBookChanges struct {
+	ContactIDs []uint
+	Token      uint
+}
+
+// SyncCounter hands out the sync tokens of the address book from its single row.
+// Every transaction writing audit entries bumps it last and stamps the entries
+// with its new value, the row staying locked until the transaction commits, so
+// tokens follow the order changes are committed in.
+type SyncCounter struct {
+	ID    uint `gorm:"primaryKey;autoIncrement:false"`
+	Token uint `gorm:"not null;default:0"`
+}
+
+// SyncCounterID is the id of the row of SyncCounter.
+const SyncCounterID = 1
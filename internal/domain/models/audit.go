@@ -0,0 +1,178 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// AuditAction is the kind of change recorded by an AuditEntry.
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+	AuditPurge   AuditAction = "purge"
+	AuditRevert  AuditAction = "revert"
+	AuditMerge   AuditAction = "merge"
+)
+
+// AuditActions lists every AuditAction.
+var AuditActions = []AuditAction{AuditCreate, AuditUpdate, AuditDelete, AuditRestore, AuditPurge, AuditRevert,
+	AuditMerge}
+
+// Origin identifies who asked for a change and the request that carried it.
+type Origin struct {
+	Actor     string
+	RequestID string
+}
+
+// AuditEntry records a change made to a contact. Entries are never updated nor
+// deleted, they outlive the contact when it is purged. SyncToken is the sync
+// token of the address book the change was committed with, see SyncCounter.
+type AuditEntry struct {
+	ID        uint        `json:"id" gorm:"primaryKey;autoIncrement"`
+	ContactID uint        `json:"contact_id" gorm:"not null;index"`
+	Action    AuditAction `json:"action" gorm:"not null"`
+	Actor     string      `json:"actor" gorm:"not null;index"`
+	RequestID string      `json:"request_id" gorm:"not null;default:''"`
+	Changes   Changes     `json:"changes" gorm:"type:jsonb;not null"`
+	CreatedAt time.Time   `json:"created_at" gorm:"not null;index"`
+	SyncToken uint        `json:"-" gorm:"not null;default:0;index"`
+}
+
+// AuditFilter narrows the audit entries returned by a query, zero fields match
+// every entry. From and To bound the creation time, both included.
+type AuditFilter struct {
+	ContactID uint
+	Actor     string
+	Action    AuditAction
+	RequestID string
+	From      time.Time
+	To        time.Time
+}
+
+// Change holds the value of a field before and after a change, a field that did
+// not exist on one side is null there.
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Changes maps the JSON name of each changed field to its Change.
+type Changes map[string]Change
+
+func (changes Changes) Value() (driver.Value, error) {
+	if changes == nil {
+		changes = Changes{}
+	}
+
+	encoded, err := json.Marshal(changes)
+
+	return string(encoded), err
+}
+
+func (changes *Changes) Scan(value interface{}) error {
+	var encoded []byte
+
+	switch value := value.(type) {
+	case []byte:
+		encoded = value
+	case string:
+		encoded = []byte(value)
+	case nil:
+		*changes = nil
+		return nil
+	default:
+		return fmt.Errorf("can not scan %T into Changes", value)
+	}
+
+	scanned := Changes{}
+	if err := json.Unmarshal(encoded, &scanned); err != nil {
+		return err
+	}
+
+	*changes = scanned
+
+	return nil
+}
+
+// DiffContacts returns the fields of the JSON form of a contact that differ
+// between before and after, either of them is nil when the contact did not exist
+// on that side of the change. Ids are left out: phones, emails and addresses get
+// new ones on every update, which is not a change of their content.
+func DiffContacts(before, after *Contact) (Changes, error) {
+	oldFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	newFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := Changes{}
+
+	for field, value := range newFields {
+		if !reflect.DeepEqual(oldFields[field], value) {
+			changes[field] = Change{Before: oldFields[field], After: value}
+		}
+	}
+
+	for field, value := range oldFields {
+		if _, found := newFields[field]; !found && value != nil {
+			changes[field] = Change{Before: value}
+		}
+	}
+
+	return changes, nil
+}
+
+func auditFields(contact *Contact) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if contact == nil {
+		return fields, nil
+	}
+
+	encoded, err := json.Marshal(contact)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(encoded, &fields); err != nil {
+		return nil, err
+	}
+
+	delete(fields, "id")
+
+	for field, value := range fields {
+		if list, ok := value.([]interface{}); ok && len(list) == 0 {
+			// an empty list and a missing one are the same content.
+			fields[field] = nil
+		}
+
+		dropIDs(fields[field])
+	}
+
+	return fields, nil
+}
+
+func dropIDs(value interface{}) {
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			dropIDs(item)
+		}
+	case map[string]interface{}:
+		delete(value, "id")
+
+		for _, item := range value {
+			dropIDs(item)
+		}
+	}
+}
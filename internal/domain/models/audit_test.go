@@ -0,0 +1,65 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffContacts_WhenCreated(t *testing.T) {
+	changes, err := DiffContacts(nil, &Contact{ID: 1, Name: "Juan", PhoneNumber: "+573000000000", Version: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Change{After: "Juan"}, changes["name"])
+	assert.Equal(t, Change{After: "+573000000000"}, changes["phone_number"])
+	assert.Equal(t, Change{After: float64(1)}, changes["version"])
+	assert.NotContains(t, changes, "id")
+	assert.NotContains(t, changes, "phones")
+}
+
+func TestDiffContacts_WhenUpdated(t *testing.T) {
+	before := &Contact{
+		ID:      1,
+		Name:    "Juan",
+		Version: 1,
+		Phones:  []Phone{{ID: 7, Label: "home", Number: "+5716000000"}},
+		Emails:  []Email{},
+	}
+	after := &Contact{
+		ID:      1,
+		Name:    "Juan Pérez",
+		Version: 2,
+		Phones:  []Phone{{ID: 9, Label: "home", Number: "+5716000000"}},
+	}
+
+	changes, err := DiffContacts(before, after)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Changes{
+		"name":    {Before: "Juan", After: "Juan Pérez"},
+		"version": {Before: float64(1), After: float64(2)},
+	}, changes)
+}
+
+func TestDiffContacts_WhenPurged(t *testing.T) {
+	changes, err := DiffContacts(&Contact{ID: 1, Name: "Juan"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Change{Before: "Juan"}, changes["name"])
+	assert.NotContains(t, changes, "deleted_at")
+}
+
+func TestChanges_ValueAndScan(t *testing.T) {
+	changes := Changes{"name": {Before: "Juan", After: "Juan Pérez"}}
+
+	value, err := changes.Value()
+	assert.NoError(t, err)
+
+	var scanned Changes
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, changes, scanned)
+
+	assert.NoError(t, scanned.Scan(`{}`))
+	assert.Equal(t, Changes{}, scanned)
+	assert.Error(t, scanned.Scan(1))
+}
@@ -0,0 +1,27 @@
+package models
+
+// BatchAction is the kind of write made by a BatchOperation.
+type BatchAction string
+
+const (
+	BatchCreate BatchAction = "create"
+	BatchUpdate BatchAction = "update"
+	BatchDelete BatchAction = "delete"
+)
+
+// BatchOperation is one of the writes of a batch. ID names the contact updated
+// or deleted, and Version, when it is not 0, the version it must still be at.
+// Contact is the content of creates and updates.
+type BatchOperation struct {
+	Action  BatchAction
+	ID      uint
+	Version uint
+	Contact Contact
+}
+
+// BatchResult is the outcome of a BatchOperation: the contact as it was left by
+// the operation, or the error that kept it from being applied.
+type BatchResult struct {
+	Contact Contact
+	Err     error
+}
@@ -1,9 +1,76 @@
 package models
 
+import "gorm.io/gorm"
+
+// Contact is a stored contact. Name is the display name, built from the
+// StructuredName components when the contact is saved. PhoneNumber is stored in
+// E.164 and PhoneDisplay keeps the number as the client wrote it. Company is the
+// organization the contact works for. Photo is the
+// URL of the picture of the contact, or the picture itself as a data URI. Deleted
+// contacts stay in the trash, with DeletedAt set, until they are purged; the
+// phone number is only unique among the contacts that are not in the trash.
 type Contact struct {
-	ID          uint   `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name        string `json:"name" gorm:"not null"`
-	PhoneNumber string `json:"phone_number" gorm:"unique;not null"`
+	ID   uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name string `json:"name" gorm:"not null"`
+	StructuredName
+	PhoneNumber  string         `json:"phone_number" gorm:"not null;uniqueIndex:idx_contacts_phone_number,where:deleted_at IS NULL"`
+	PhoneDisplay string         `json:"phone_number_display" gorm:"not null;default:''"`
+	PhoneRegion  string         `json:"phone_region" gorm:"not null;default:''"`
+	PhoneType    string         `json:"phone_type" gorm:"not null;default:''"`
+	Company      string         `json:"company" gorm:"not null;default:''"`
+	Notes        string         `json:"notes" gorm:"not null;default:''"`
+	Photo        string         `json:"photo" gorm:"not null;default:''"`
+	Version      uint           `json:"version" gorm:"not null;default:1"`
+	Phones       []Phone        `json:"phones" gorm:"constraint:OnDelete:CASCADE"`
+	Emails       []Email        `json:"emails" gorm:"constraint:OnDelete:CASCADE"`
+	Addresses    []Address      `json:"addresses" gorm:"constraint:OnDelete:CASCADE"`
+	Tags         []Tag          `json:"tags" gorm:"many2many:contact_tags;constraint:OnDelete:CASCADE"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index" swaggertype:"string" format:"date-time"`
+}
+
+// NormalizeName fills the name components from Name when none is set and then
+// rebuilds Name from them using format.
+func (contact *Contact) NormalizeName(format NameFormat) {
+	if contact.StructuredName.IsZero() {
+		contact.StructuredName = SplitName(contact.Name)
+	}
+
+	if name := format.Format(contact.StructuredName); name != "" {
+		contact.Name = name
+	}
+}
+
+// Phone is an additional phone number of a contact, PhoneNumber stays the number
+// that identifies it. Number is stored in E.164 like PhoneNumber.
+type Phone struct {
+	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ContactID uint   `json:"-" gorm:"not null;index"`
+	Label     string `json:"label" gorm:"not null"`
+	Number    string `json:"number" gorm:"not null"`
+	Display   string `json:"display" gorm:"not null;default:''"`
+	Region    string `json:"region" gorm:"not null;default:''"`
+	Type      string `json:"type" gorm:"not null;default:''"`
+	Primary   bool   `json:"primary" gorm:"not null;default:false"`
+}
+
+type Email struct {
+	ID        uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ContactID uint   `json:"-" gorm:"not null;index"`
+	Label     string `json:"label" gorm:"not null"`
+	Address   string `json:"address" gorm:"not null"`
+	Primary   bool   `json:"primary" gorm:"not null;default:false"`
+}
+
+type Address struct {
+	ID         uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	ContactID  uint   `json:"-" gorm:"not null;index"`
+	Label      string `json:"label" gorm:"not null"`
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	Primary    bool   `json:"primary" gorm:"not null;default:false"`
 }
 
 type Paginator struct {
@@ -15,4 +82,9 @@ type Paginator struct {
 	Page        int         `json:"page"`
 	PrevPage    int         `json:"prev_page"`
 	NextPage    int         `json:"next_page"`
+	NextCursor  string      `json:"next_cursor,omitempty"`
+	PrevCursor  string      `json:"prev_cursor,omitempty"`
+	Cursor      *Cursor     `json:"-"`
+	Next        *Cursor     `json:"-"`
+	Prev        *Cursor     `json:"-"`
 }
@@ -0,0 +1,40 @@
+package models
+
+// DuplicatePair is a pair of contacts suspected to be the same person. Score,
+// between 0 and 1, weighs how similar their names are and the phone numbers and
+// email addresses they share.
+type DuplicatePair struct {
+	ContactID      uint     `json:"contact_id"`
+	OtherID        uint     `json:"other_id"`
+	Score          float64  `json:"score" example:"0.85"`
+	NameSimilarity float64  `json:"name_similarity" example:"0.9"`
+	SharedPhones   []string `json:"shared_phones,omitempty"`
+	SharedEmails   []string `json:"shared_emails,omitempty"`
+}
+
+// DuplicateCluster is a set of contacts linked by suspected duplicate pairs,
+// Score is the score of its most likely pair.
+type DuplicateCluster struct {
+	Score    float64         `json:"score" example:"0.85"`
+	Contacts []Contact       `json:"contacts"`
+	Pairs    []DuplicatePair `json:"pairs"`
+}
+
+// MergeField is a field a merge can take from any of the merged contacts.
+type MergeField string
+
+const (
+	MergeName        MergeField = "name"
+	MergePhoneNumber MergeField = "phone_number"
+	MergeCompany     MergeField = "company"
+	MergeNotes       MergeField = "notes"
+	MergePhoto       MergeField = "photo"
+)
+
+// Merge is a merge of contacts into Survivor: Survivor is the content it is left
+// with and Merged the contacts merged into it, which go to the trash. Both hold
+// the versions the contacts were read at.
+type Merge struct {
+	Survivor Contact
+	Merged   []Contact
+}
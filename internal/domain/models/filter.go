@@ -0,0 +1,42 @@
+package models
+
+// ContactSortFields are the contact fields that listings can be sorted by.
+var ContactSortFields = []string{
+	"id", "name", "name_prefix", "given_name", "family_name", "name_suffix", "nickname", "phone_number",
+}
+
+// ContactFilterFields are the contact fields that listings can be filtered by.
+// name_prefix is left out as its query param would clash with the prefix filter
+// of name.
+var ContactFilterFields = []string{"name", "given_name", "family_name", "nickname", "phone_number"}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// FieldFilter matches the contacts whose Field is equal to Value, or starts with
+// it when Prefix is set.
+type FieldFilter struct {
+	Field  string
+	Value  string
+	Prefix bool
+}
+
+// ContactFilter selects the contacts of a listing. Contacts must have every tag
+// in Tags, or any of them when AnyTag is set.
+type ContactFilter struct {
+	Search  string
+	Filters []FieldFilter
+	Tags    []string
+	AnyTag  bool
+	Sort    []SortField
+}
+
+// Cursor is a position in a keyset paginated listing: Keys are the sort key of
+// the row the page starts after, or ends before when Backward is set. A Cursor
+// without Keys points to the first page.
+type Cursor struct {
+	Keys     []string
+	Backward bool
+}
@@ -0,0 +1,11 @@
+package models
+
+// Group is a named list of contacts that can also contain other groups. Contacts
+// and Groups are the direct members of the group.
+type Group struct {
+	ID          uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        string    `json:"name" gorm:"unique;not null"`
+	Description string    `json:"description" gorm:"not null;default:''"`
+	Contacts    []Contact `json:"contacts,omitempty" gorm:"many2many:group_contacts;constraint:OnDelete:CASCADE"`
+	Groups      []Group   `json:"groups,omitempty" gorm:"many2many:group_subgroups;joinForeignKey:GroupID;joinReferences:MemberGroupID;constraint:OnDelete:CASCADE"`
+}
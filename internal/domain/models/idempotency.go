@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// IdempotentRequest is a request sent with an Idempotency-Key. Fingerprint tells
+// it apart from other requests reusing the key. Status is 0 while the request is
+// processed and then, along with Header and Body, the response replayed to its
+// retries until ExpiresAt. The key is leased to the request being processed until
+// LockedUntil, a retry after that takes it over, as the one processing it is
+// taken to have died.
+type IdempotentRequest struct {
+	Key         string              `json:"key" gorm:"primaryKey"`
+	Fingerprint string              `json:"fingerprint" gorm:"not null"`
+	Status      int                 `json:"status" gorm:"not null;default:0"`
+	Header      map[string][]string `json:"header" gorm:"serializer:json;type:jsonb"`
+	Body        []byte              `json:"body"`
+	ExpiresAt   time.Time           `json:"expires_at" gorm:"not null;index"`
+	LockedUntil time.Time           `json:"locked_until" gorm:"not null;default:'1970-01-01 00:00:00+00:00'"`
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NameFormat decides how the display name of a contact is built from the
+// components of its name.
+type NameFormat string
+
+const (
+	// NameFormatGivenFirst renders "Dr. Juan Pérez Jr.".
+	NameFormatGivenFirst NameFormat = "first_last"
+	// NameFormatFamilyFirst renders "Pérez, Dr. Juan Jr.".
+	NameFormatFamilyFirst NameFormat = "last_first"
+)
+
+// ParseNameFormat reads a NameFormat, an empty value is NameFormatGivenFirst.
+func ParseNameFormat(value string) (NameFormat, error) {
+	switch format := NameFormat(value); format {
+	case "":
+		return NameFormatGivenFirst, nil
+	case NameFormatGivenFirst, NameFormatFamilyFirst:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown name format %q, expected %q or %q",
+			value, NameFormatGivenFirst, NameFormatFamilyFirst)
+	}
+}
+
+// Format returns the display name of name, falling back to the nickname when the
+// name has neither given nor family name.
+func (format NameFormat) Format(name StructuredName) string {
+	if name.GivenName == "" && name.FamilyName == "" {
+		return name.Nickname
+	}
+
+	if format == NameFormatFamilyFirst && name.FamilyName != "" {
+		rest := joinNonEmpty(name.Prefix, name.GivenName, name.Suffix)
+		if rest == "" {
+			return name.FamilyName
+		}
+
+		return name.FamilyName + ", " + rest
+	}
+
+	return joinNonEmpty(name.Prefix, name.GivenName, name.FamilyName, name.Suffix)
+}
+
+// StructuredName holds the components of the name of a contact.
+type StructuredName struct {
+	Prefix     string `json:"name_prefix" gorm:"column:name_prefix;not null;default:''"`
+	GivenName  string `json:"given_name" gorm:"not null;default:''"`
+	FamilyName string `json:"family_name" gorm:"not null;default:''"`
+	Suffix     string `json:"name_suffix" gorm:"column:name_suffix;not null;default:''"`
+	Nickname   string `json:"nickname" gorm:"not null;default:''"`
+}
+
+// IsZero reports whether no component is set.
+func (name StructuredName) IsZero() bool {
+	return name == StructuredName{}
+}
+
+var (
+	namePrefixes = map[string]bool{
+		"mr": true, "mrs": true, "ms": true, "miss": true, "mx": true, "dr": true,
+		"prof": true, "rev": true, "sir": true, "dame": true, "sra": true, "srta": true,
+	}
+	nameSuffixes = map[string]bool{
+		"jr": true, "sr": true, "ii": true, "iii": true, "iv": true,
+		"phd": true, "md": true, "esq": true,
+	}
+	familyParticles = map[string]bool{
+		"de": true, "del": true, "la": true, "las": true, "los": true, "van": true, "von": true,
+		"der": true, "den": true, "da": true, "di": true, "du": true, "le": true, "dos": true,
+	}
+)
+
+// SplitName guesses the components of a free text name. "Last, First" is read as
+// family and given name; otherwise the last word, together with the particles
+// before it ("de la Cruz", "van Dyke"), is the family name. Well known honorifics
+// and generational suffixes are moved to Prefix and Suffix.
+func SplitName(name string) StructuredName {
+	var structured StructuredName
+
+	if family, given, found := strings.Cut(name, ","); found {
+		words := strings.Fields(given)
+		if isAffixes(words, nameSuffixes) {
+			// "Juan Pérez, Jr." only has a suffix after the comma.
+			structured = SplitName(family)
+			structured.Suffix = joinNonEmpty(structured.Suffix, strings.Join(words, " "))
+
+			return structured
+		}
+
+		structured.Prefix, words = takeAffixes(words, namePrefixes, true)
+		structured.Suffix, words = takeAffixes(words, nameSuffixes, false)
+		structured.FamilyName = strings.Join(strings.Fields(family), " ")
+		structured.GivenName = strings.Join(words, " ")
+
+		return structured
+	}
+
+	words := strings.Fields(name)
+	structured.Prefix, words = takeAffixes(words, namePrefixes, true)
+	structured.Suffix, words = takeAffixes(words, nameSuffixes, false)
+
+	if len(words) <= 1 {
+		structured.GivenName = strings.Join(words, " ")
+		return structured
+	}
+
+	familyStart := len(words) - 1
+	for familyStart > 1 && familyParticles[strings.ToLower(words[familyStart-1])] {
+		familyStart--
+	}
+
+	structured.GivenName = strings.Join(words[:familyStart], " ")
+	structured.FamilyName = strings.Join(words[familyStart:], " ")
+
+	return structured
+}
+
+// takeAffixes removes from the start (or the end) of words the ones found in
+// affixes, ignoring case and trailing dots, and returns them joined.
+func takeAffixes(words []string, affixes map[string]bool, leading bool) (string, []string) {
+	var taken []string
+
+	for len(words) > 1 {
+		index := len(words) - 1
+		if leading {
+			index = 0
+		}
+
+		word := words[index]
+		if !affixes[strings.ToLower(strings.TrimRight(word, ".,"))] {
+			break
+		}
+
+		if leading {
+			taken = append(taken, word)
+			words = words[1:]
+		} else {
+			taken = append([]string{strings.TrimRight(word, ",")}, taken...)
+			words = words[:index]
+		}
+	}
+
+	if !leading && len(words) > 0 {
+		words[len(words)-1] = strings.TrimRight(words[len(words)-1], ",")
+	}
+
+	return strings.Join(taken, " "), words
+}
+
+func isAffixes(words []string, affixes map[string]bool) bool {
+	for _, word := range words {
+		if !affixes[strings.ToLower(strings.TrimRight(word, ".,"))] {
+			return false
+		}
+	}
+
+	return len(words) > 0
+}
+
+func joinNonEmpty(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+
+	return strings.Join(nonEmpty, " ")
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitName(t *testing.T) {
+	tests := map[string]StructuredName{
+		"":                         {},
+		"Juan":                     {GivenName: "Juan"},
+		"Juan Pérez":               {GivenName: "Juan", FamilyName: "Pérez"},
+		"  Juan   Carlos  Pérez  ": {GivenName: "Juan Carlos", FamilyName: "Pérez"},
+		"Pérez, Juan Carlos":       {GivenName: "Juan Carlos", FamilyName: "Pérez"},
+		"Dr. Juan Pérez Jr.":       {Prefix: "Dr.", GivenName: "Juan", FamilyName: "Pérez", Suffix: "Jr."},
+		"Juan Pérez, Jr.":          {GivenName: "Juan", FamilyName: "Pérez", Suffix: "Jr."},
+		"Pérez, Dr. Juan III":      {Prefix: "Dr.", GivenName: "Juan", FamilyName: "Pérez", Suffix: "III"},
+		"Juana de la Cruz":         {GivenName: "Juana", FamilyName: "de la Cruz"},
+		"Ludwig van Beethoven":     {GivenName: "Ludwig", FamilyName: "van Beethoven"},
+		"Dr":                       {GivenName: "Dr"},
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, SplitName(name), name)
+	}
+}
+
+func TestNameFormat_Format(t *testing.T) {
+	name := StructuredName{Prefix: "Dr.", GivenName: "Juan", FamilyName: "Pérez", Suffix: "Jr.", Nickname: "Juancho"}
+
+	assert.Equal(t, "Dr. Juan Pérez Jr.", NameFormatGivenFirst.Format(name))
+	assert.Equal(t, "Pérez, Dr. Juan Jr.", NameFormatFamilyFirst.Format(name))
+	assert.Equal(t, "Pérez", NameFormatFamilyFirst.Format(StructuredName{FamilyName: "Pérez"}))
+	assert.Equal(t, "Juan", NameFormatFamilyFirst.Format(StructuredName{GivenName: "Juan"}))
+	assert.Equal(t, "Juancho", NameFormatGivenFirst.Format(StructuredName{Nickname: "Juancho"}))
+}
+
+func TestParseNameFormat(t *testing.T) {
+	format, err := ParseNameFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, NameFormatGivenFirst, format)
+
+	format, err = ParseNameFormat("last_first")
+	assert.NoError(t, err)
+	assert.Equal(t, NameFormatFamilyFirst, format)
+
+	_, err = ParseNameFormat("first")
+	assert.Error(t, err)
+}
+
+func TestContact_NormalizeName(t *testing.T) {
+	contact := Contact{Name: "Juan Pérez"}
+	contact.NormalizeName(NameFormatFamilyFirst)
+
+	assert.Equal(t, "Pérez, Juan", contact.Name)
+	assert.Equal(t, StructuredName{GivenName: "Juan", FamilyName: "Pérez"}, contact.StructuredName)
+
+	contact = Contact{Name: "old name", StructuredName: StructuredName{GivenName: "Ana"}}
+	contact.NormalizeName(NameFormatGivenFirst)
+
+	assert.Equal(t, "Ana", contact.Name)
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// Revision is the full state of a contact right after one of its writes. Its
+// Version is the version the write gave to the contact, so it identifies the
+// revision among the ones of the contact.
+type Revision struct {
+	ID        uint      `json:"id" gorm:"primaryKey;autoIncrement"`
+	ContactID uint      `json:"contact_id" gorm:"not null;uniqueIndex:idx_revisions_contact_version"`
+	Version   uint      `json:"version" gorm:"not null;uniqueIndex:idx_revisions_contact_version"`
+	Contact   Contact   `json:"contact" gorm:"serializer:json;type:jsonb;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;index"`
+}
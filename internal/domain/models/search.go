@@ -0,0 +1,11 @@
+package models
+
+// SearchResult is a contact found by a search, the best ranked first. Highlights
+// has, for the fields where the search terms were found word by word, an excerpt
+// of the field with those words wrapped in <mark> tags; fields matched only by
+// their similarity to the search are not highlighted.
+type SearchResult struct {
+	Contact    Contact           `json:"contact"`
+	Rank       float64           `json:"rank" example:"0.92"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Tag labels contacts, a contact can have many tags and a tag many contacts.
+type Tag struct {
+	ID    uint   `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name  string `json:"name" gorm:"unique;not null"`
+	Color string `json:"color" gorm:"not null;default:''"`
+}
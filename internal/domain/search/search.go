@@ -0,0 +1,227 @@
+// Package search matches contacts against a search in Go, for the storage
+// backends that have no full-text search of their own. It follows the search of
+// Postgres: words are compared regardless of case and accents, names and company
+// weigh more than notes, and words similar enough to the ones searched tolerate
+// typos.
+package search
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/duplicates"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// similarThreshold is the share of the trigrams of a searched word that a word
+// of a field must have to match it, as the word similarity threshold of pg_trgm.
+const similarThreshold = 0.6
+
+// Weights of the fields in the rank: a word found in the notes counts half of one
+// found in the names, the company or an email address.
+const (
+	namesWeight = 1
+	notesWeight = 0.5
+)
+
+// Query is a search split in words, folded as the fields are.
+type Query struct {
+	words []string
+}
+
+func NewQuery(text string) Query {
+	return Query{
+		words: strings.Fields(duplicates.NormalizeName(text)),
+	}
+}
+
+// field is a searchable text of a contact and the words it holds.
+type field struct {
+	name   string
+	text   string
+	weight float64
+	words  []string
+}
+
+// Match tells whether every word of the query is in a field of the contact, or
+// is similar enough to one of them, and returns the result found. Its rank is
+// the mean, over the words of the query, of the best weighted similarity found.
+func (query Query) Match(contact models.Contact) (models.SearchResult, bool) {
+	if len(query.words) == 0 {
+		return models.SearchResult{}, false
+	}
+
+	fields := []field{
+		newField("name", contact.Name, namesWeight),
+		newField("nickname", contact.Nickname, namesWeight),
+		newField("company", contact.Company, namesWeight),
+		newField("notes", contact.Notes, notesWeight),
+	}
+
+	for _, email := range contact.Emails {
+		fields = append(fields, newField("email", email.Address, namesWeight))
+	}
+
+	var rank float64
+
+	for _, word := range query.words {
+		best := 0.0
+
+		for _, field := range fields {
+			for _, candidate := range field.words {
+				if score := similarity(word, candidate) * field.weight; score > best {
+					best = score
+				}
+			}
+		}
+
+		if best == 0 {
+			return models.SearchResult{}, false
+		}
+
+		rank += best
+	}
+
+	return models.SearchResult{
+		Contact:    contact,
+		Rank:       rank / float64(len(query.words)),
+		Highlights: query.highlights(fields),
+	}, true
+}
+
+// highlights returns the fields where a word of the query was found as such, with
+// those words wrapped in <mark> tags. Only the first email address found is
+// kept.
+func (query Query) highlights(fields []field) map[string]string {
+	highlights := map[string]string{}
+
+	for _, field := range fields {
+		if _, done := highlights[field.name]; done {
+			continue
+		}
+
+		if excerpt, found := query.highlight(field.text); found {
+			highlights[field.name] = excerpt
+		}
+	}
+
+	if len(highlights) == 0 {
+		return nil
+	}
+
+	return highlights
+}
+
+// highlight escapes text and wraps in <mark> tags the words of the query it
+// holds, it reports whether there was any.
+func (query Query) highlight(text string) (string, bool) {
+	var builder strings.Builder
+
+	found := false
+	runes := []rune(text)
+
+	for start := 0; start < len(runes); {
+		end := start + 1
+
+		if !isWordRune(runes[start]) {
+			for end < len(runes) && !isWordRune(runes[end]) {
+				end++
+			}
+
+			builder.WriteString(html.EscapeString(string(runes[start:end])))
+			start = end
+
+			continue
+		}
+
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+
+		word := html.EscapeString(string(runes[start:end]))
+
+		if query.contains(duplicates.NormalizeName(string(runes[start:end]))) {
+			builder.WriteString("<mark>" + word + "</mark>")
+			found = true
+		} else {
+			builder.WriteString(word)
+		}
+
+		start = end
+	}
+
+	return builder.String(), found
+}
+
+func (query Query) contains(word string) bool {
+	for _, searched := range query.words {
+		if searched == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sort orders results the best ranked first, and by id among equal ranks.
+func Sort(results []models.SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+
+		return results[i].Contact.ID < results[j].Contact.ID
+	})
+}
+
+func newField(name, text string, weight float64) field {
+	return field{
+		name:   name,
+		text:   text,
+		weight: weight,
+		words:  strings.Fields(duplicates.NormalizeName(text)),
+	}
+}
+
+// similarity is 1 for equal words and otherwise the share of the trigrams of
+// word that candidate has, when it reaches similarThreshold.
+func similarity(word, candidate string) float64 {
+	if word == candidate {
+		return 1
+	}
+
+	wanted := trigrams(word)
+	have := trigrams(candidate)
+
+	shared := 0
+	for trigram := range wanted {
+		if have[trigram] {
+			shared++
+		}
+	}
+
+	if score := float64(shared) / float64(len(wanted)); score >= similarThreshold {
+		return score
+	}
+
+	return 0
+}
+
+// trigrams are the sequences of three runes of word padded as pg_trgm does, with
+// two spaces before and one after.
+func trigrams(word string) map[string]bool {
+	runes := []rune("  " + word + " ")
+
+	found := make(map[string]bool, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		found[string(runes[i:i+3])] = true
+	}
+
+	return found
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	contact := models.Contact{ID: 1, Name: "Ana Pérez", Notes: "met at <the> gym",
+		Emails: []models.Email{{Address: "ana.perez@example.com"}}}
+
+	result, found := NewQuery("perez").Match(contact)
+	assert.True(t, found)
+	assert.Equal(t, 1.0, result.Rank)
+	assert.Equal(t, map[string]string{
+		"name":  "Ana <mark>Pérez</mark>",
+		"email": "ana.<mark>perez</mark>@example.com",
+	}, result.Highlights)
+
+	result, found = NewQuery("GYM").Match(contact)
+	assert.True(t, found)
+	assert.Equal(t, 0.5, result.Rank)
+	assert.Equal(t, map[string]string{"notes": "met at &lt;the&gt; <mark>gym</mark>"}, result.Highlights)
+
+	result, found = NewQuery("acme").Match(models.Contact{Name: "Bob", Company: "Acme Corp"})
+	assert.True(t, found)
+	assert.Equal(t, 1.0, result.Rank)
+	assert.Equal(t, map[string]string{"company": "<mark>Acme</mark> Corp"}, result.Highlights)
+
+	result, found = NewQuery("anabel").Match(models.Contact{Name: "Anabela"})
+	assert.True(t, found)
+	assert.InDelta(t, 0.857, result.Rank, 0.001)
+	assert.Nil(t, result.Highlights)
+
+	_, found = NewQuery("ana gomez").Match(contact)
+	assert.False(t, found)
+
+	_, found = NewQuery("...").Match(contact)
+	assert.False(t, found)
+}
+
+func TestSort(t *testing.T) {
+	results := []models.SearchResult{
+		{Contact: models.Contact{ID: 3}, Rank: 0.5},
+		{Contact: models.Contact{ID: 2}, Rank: 1},
+		{Contact: models.Contact{ID: 1}, Rank: 0.5},
+	}
+
+	Sort(results)
+
+	assert.Equal(t, uint(2), results[0].Contact.ID)
+	assert.Equal(t, uint(1), results[1].Contact.ID)
+	assert.Equal(t, uint(3), results[2].Contact.ID)
+}
@@ -0,0 +1,401 @@
+package vcard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// SyntaxError is a malformed line of a vCard file.
+type SyntaxError struct {
+	Line    int
+	Message string
+}
+
+func (err *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", err.Line, err.Message)
+}
+
+// property is a content line: NAME;PARAM=value,value:value.
+type property struct {
+	name   string
+	params map[string][]string
+	value  string
+}
+
+// line is a content line unfolded, number is the line where it begins.
+type line struct {
+	number int
+	text   string
+}
+
+// Decode reads every card of r. Properties that contacts have no field for are
+// ignored, malformed files are reported with a *SyntaxError.
+func Decode(r io.Reader) ([]Card, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []Card
+	var current *decoder
+
+	for _, contentLine := range lines {
+		if strings.TrimSpace(contentLine.text) == "" {
+			continue
+		}
+
+		prop, err := parseProperty(contentLine.text)
+		if err != nil {
+			return nil, &SyntaxError{Line: contentLine.number, Message: err.Error()}
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCARD"):
+			if current != nil {
+				return nil, &SyntaxError{Line: contentLine.number,
+					Message: fmt.Sprintf("the card beginning at line %d is not ended", current.line)}
+			}
+
+			current = &decoder{line: contentLine.number}
+		case prop.name == "END" && strings.EqualFold(prop.value, "VCARD"):
+			if current == nil {
+				return nil, &SyntaxError{Line: contentLine.number, Message: "END:VCARD without BEGIN:VCARD"}
+			}
+
+			cards = append(cards, current.card())
+			current = nil
+		case current == nil:
+			return nil, &SyntaxError{Line: contentLine.number, Message: "the line is not inside a card"}
+		default:
+			if err := current.add(prop); err != nil {
+				return nil, &SyntaxError{Line: contentLine.number, Message: err.Error()}
+			}
+		}
+	}
+
+	if current != nil {
+		return nil, &SyntaxError{Line: current.line,
+			Message: fmt.Sprintf("the card beginning at line %d is not ended", current.line)}
+	}
+
+	return cards, nil
+}
+
+// unfold joins the lines that start with a space or a tab to the line before them.
+func unfold(r io.Reader) ([]line, error) {
+	reader := bufio.NewReader(r)
+
+	var lines []line
+
+	for number := 1; ; number++ {
+		text, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		text = strings.TrimRight(text, "\r\n")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].text += text[1:]
+		} else if text != "" || err == nil {
+			lines = append(lines, line{number: number, text: text})
+		}
+
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+	}
+}
+
+// parseProperty splits a content line in its name, its parameters and its value.
+// Groups are dropped from the name and parameters without a name, from vCard 2.1,
+// are read as types.
+func parseProperty(text string) (property, error) {
+	colon := indexUnquoted(text, ':')
+	if colon < 0 {
+		return property{}, fmt.Errorf("the line %q has no value", text)
+	}
+
+	parts := splitUnquoted(text[:colon], ';')
+
+	name := parts[0]
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+
+	if name == "" {
+		return property{}, fmt.Errorf("the line %q has no property name", text)
+	}
+
+	prop := property{name: strings.ToUpper(name), params: map[string][]string{}, value: text[colon+1:]}
+
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			key, value = "TYPE", part
+		}
+
+		key = strings.ToUpper(strings.TrimSpace(key))
+		for _, item := range splitUnquoted(value, ',') {
+			item = strings.Trim(strings.TrimSpace(item), `"`)
+			if key == "TYPE" {
+				item = strings.ToLower(item)
+			}
+
+			prop.params[key] = append(prop.params[key], item)
+		}
+	}
+
+	return prop, nil
+}
+
+// decoder builds the contact of a card from its properties.
+type decoder struct {
+	line    int
+	uid     string
+	contact models.Contact
+	notes   []string
+}
+
+func (card *decoder) add(prop property) error {
+	switch prop.name {
+	case "VERSION":
+		if !IsVersion(strings.TrimSpace(prop.value)) {
+			return fmt.Errorf("unsupported vCard version %q", prop.value)
+		}
+	case "UID":
+		card.uid = strings.TrimSpace(unescapeText(prop.value))
+	case "FN":
+		card.contact.Name = unescapeText(prop.value)
+	case "N":
+		components := splitCompound(prop.value, 5)
+		card.contact.FamilyName = components[0]
+		card.contact.GivenName = strings.TrimSpace(components[1] + " " + components[2])
+		card.contact.Prefix = components[3]
+		card.contact.Suffix = components[4]
+	case "NICKNAME":
+		card.contact.Nickname = unescapeText(prop.value)
+	case "TEL":
+		card.phone(prop)
+	case "EMAIL":
+		if address := strings.TrimSpace(unescapeText(prop.value)); address != "" {
+			card.contact.Emails = append(card.contact.Emails, models.Email{
+				Label:   placeLabel(prop.params["TYPE"]),
+				Address: address,
+				Primary: preferred(prop),
+			})
+		}
+	case "ADR":
+		card.address(prop)
+	case "ORG":
+		// the organizational units that may follow the name are left out
+		card.contact.Company = splitCompound(prop.value, 1)[0]
+	case "NOTE":
+		card.notes = append(card.notes, unescapeText(prop.value))
+	case "PHOTO":
+		card.contact.Photo = photoURI(prop)
+	}
+
+	return nil
+}
+
+// phone sets the phone number of the contact from its first TEL, the rest are
+// its additional phones.
+func (card *decoder) phone(prop property) {
+	number := prop.value
+	if hasType(prop.params["VALUE"], "uri") || strings.HasPrefix(strings.ToLower(number), "tel:") {
+		number, _, _ = strings.Cut(number[strings.Index(number, ":")+1:], ";")
+	} else {
+		number = unescapeText(number)
+	}
+
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return
+	}
+
+	if card.contact.PhoneNumber == "" {
+		card.contact.PhoneNumber = number
+		return
+	}
+
+	card.contact.Phones = append(card.contact.Phones, models.Phone{
+		Label:   phoneLabel(prop.params["TYPE"]),
+		Number:  number,
+		Primary: preferred(prop),
+	})
+}
+
+// address reads an ADR, whose components are: post office box; extended
+// address; street; locality; region; postal code; country. The extended address
+// is appended to the street and the post office box is ignored.
+func (card *decoder) address(prop property) {
+	components := splitCompound(prop.value, 7)
+
+	address := models.Address{
+		Label:      placeLabel(prop.params["TYPE"]),
+		Street:     components[2],
+		City:       components[3],
+		Region:     components[4],
+		PostalCode: components[5],
+		Country:    components[6],
+		Primary:    preferred(prop),
+	}
+
+	if components[1] != "" {
+		address.Street = strings.TrimPrefix(address.Street+", "+components[1], ", ")
+	}
+
+	if len(address.Country) == 2 {
+		address.Country = strings.ToUpper(address.Country)
+	}
+
+	if address != (models.Address{Label: address.Label, Primary: address.Primary}) {
+		card.contact.Addresses = append(card.contact.Addresses, address)
+	}
+}
+
+func (card *decoder) card() Card {
+	contact := card.contact
+	contact.Notes = strings.Join(card.notes, "\n")
+
+	onePrimary(len(contact.Phones), func(i int) *bool { return &contact.Phones[i].Primary })
+	onePrimary(len(contact.Emails), func(i int) *bool { return &contact.Emails[i].Primary })
+	onePrimary(len(contact.Addresses), func(i int) *bool { return &contact.Addresses[i].Primary })
+
+	return Card{Line: card.line, UID: card.uid, Contact: contact}
+}
+
+// onePrimary keeps the first primary item of a list, cards can prefer many.
+func onePrimary(length int, primary func(i int) *bool) {
+	found := false
+
+	for i := 0; i < length; i++ {
+		if *primary(i) && found {
+			*primary(i) = false
+		}
+
+		found = found || *primary(i)
+	}
+}
+
+// preferred reports whether the property is preferred: with a pref type in 3.0
+// or with the highest preference, PREF=1, in 4.0.
+func preferred(prop property) bool {
+	return hasType(prop.params["TYPE"], "pref") || hasType(prop.params["PREF"], "1")
+}
+
+// photoURI is the photo as a URI. The base64 content of vCard 3.0 photos is
+// turned into a data URI of the media type given by their type.
+func photoURI(prop property) string {
+	if !hasType(prop.params["ENCODING"], "b", "base64") {
+		return strings.TrimSpace(prop.value)
+	}
+
+	mediaType := "image/jpeg"
+	if types := prop.params["TYPE"]; len(types) > 0 && types[0] != "" {
+		mediaType = types[0]
+		if !strings.Contains(mediaType, "/") {
+			mediaType = "image/" + mediaType
+		}
+	}
+
+	data := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+
+		return r
+	}, prop.value)
+
+	return "data:" + mediaType + ";base64," + data
+}
+
+// splitCompound splits a structured value in its components, always returning
+// at least size of them.
+func splitCompound(value string, size int) []string {
+	var components []string
+
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ';':
+			components = append(components, strings.TrimSpace(unescapeText(value[start:i])))
+			start = i + 1
+		}
+	}
+
+	components = append(components, strings.TrimSpace(unescapeText(value[start:])))
+
+	for len(components) < size {
+		components = append(components, "")
+	}
+
+	return components
+}
+
+func unescapeText(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var builder strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			builder.WriteByte(value[i])
+			continue
+		}
+
+		i++
+		if value[i] == 'n' || value[i] == 'N' {
+			builder.WriteByte('\n')
+		} else {
+			builder.WriteByte(value[i])
+		}
+	}
+
+	return builder.String()
+}
+
+// indexUnquoted is the index of the first sep of text outside double quotes.
+func indexUnquoted(text string, sep byte) int {
+	quoted := false
+
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '"':
+			quoted = !quoted
+		case text[i] == sep && !quoted:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// splitUnquoted splits text around the seps placed outside double quotes.
+func splitUnquoted(text string, sep byte) []string {
+	var parts []string
+
+	for {
+		index := indexUnquoted(text, sep)
+		if index < 0 {
+			return append(parts, text)
+		}
+
+		parts = append(parts, text[:index])
+		text = text[index+1:]
+	}
+}
@@ -0,0 +1,122 @@
+package vcard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_Version3FromPhone(t *testing.T) {
+	file := "\ufeffBEGIN:VCARD\n" +
+		"VERSION:3.0\n" +
+		"N:Pérez;Juan;Carlos;;\n" +
+		"FN:Juan Carlos Pérez\n" +
+		"item1.TEL;type=CELL;type=VOICE;type=pref:+57 300 000 0000\n" +
+		"item1.X-ABLabel:mobile\n" +
+		"TEL;HOME:601 000 0000\n" +
+		"EMAIL;type=INTERNET;type=WORK;type=pref:juan@example.com\n" +
+		"ADR;TYPE=home:;Apto 301;Calle 100 # 10-20;Bogotá;;110111;co\n" +
+		"ORG:Acme\\, Inc.;Sales\n" +
+		"NOTE:first line\\nsecond\\, line\n" +
+		"PHOTO;ENCODING=b;TYPE=JPEG:/9j/4AAQ\n" +
+		"  SkZJRg==\n" +
+		"X-SOCIALPROFILE;type=twitter:x.com/juan\n" +
+		"END:VCARD\n"
+
+	cards, err := Decode(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, models.Contact{
+		Name:           "Juan Carlos Pérez",
+		StructuredName: models.StructuredName{GivenName: "Juan Carlos", FamilyName: "Pérez"},
+		PhoneNumber:    "+57 300 000 0000",
+		Phones:         []models.Phone{{Label: "home", Number: "601 000 0000"}},
+		Emails:         []models.Email{{Label: "work", Address: "juan@example.com", Primary: true}},
+		Addresses: []models.Address{{Label: "home", Street: "Calle 100 # 10-20, Apto 301", City: "Bogotá",
+			PostalCode: "110111", Country: "CO"}},
+		Company: "Acme, Inc.",
+		Notes:   "first line\nsecond, line",
+		Photo:   "data:image/jpeg;base64,/9j/4AAQSkZJRg==",
+	}, cards[0].Contact)
+}
+
+func TestDecode_Version4(t *testing.T) {
+	file := "BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"FN:Juan\r\n" +
+		"TEL;VALUE=uri;TYPE=\"voice,cell\":tel:+573000000000;ext=12\r\n" +
+		"TEL;VALUE=uri;TYPE=work;PREF=2:tel:+576010000000\r\n" +
+		"TEL;VALUE=uri;TYPE=fax,work;PREF=1:tel:+576010000001\r\n" +
+		"EMAIL;PREF=1:juan@example.com\r\n" +
+		"EMAIL;TYPE=home;PREF=1:juan@home.example.com\r\n" +
+		"NOTE:one\r\n" +
+		"NOTE:two\r\n" +
+		"END:VCARD\r\n"
+
+	cards, err := Decode(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, models.Contact{
+		Name:        "Juan",
+		PhoneNumber: "+573000000000",
+		Phones: []models.Phone{
+			{Label: "work", Number: "+576010000000"},
+			{Label: "fax", Number: "+576010000001", Primary: true},
+		},
+		Emails: []models.Email{
+			{Label: "other", Address: "juan@example.com", Primary: true},
+			{Label: "home", Address: "juan@home.example.com"},
+		},
+		Notes: "one\ntwo",
+	}, cards[0].Contact)
+}
+
+func TestDecode_LinesOfCards(t *testing.T) {
+	file := "BEGIN:VCARD\nVERSION:4.0\nFN:Juan\nEND:VCARD\n\nBEGIN:VCARD\nVERSION:3.0\nFN:Ana\nEND:VCARD"
+
+	cards, err := Decode(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+	assert.Equal(t, 1, cards[0].Line)
+	assert.Equal(t, "Juan", cards[0].Contact.Name)
+	assert.Equal(t, 6, cards[1].Line)
+	assert.Equal(t, "Ana", cards[1].Contact.Name)
+}
+
+func TestDecode_WhenEmpty(t *testing.T) {
+	cards, err := Decode(strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Empty(t, cards)
+}
+
+func TestDecode_WhenMalformed(t *testing.T) {
+	cases := map[string]struct {
+		file string
+		line int
+	}{
+		"unsupported version": {"BEGIN:VCARD\nVERSION:2.1\nEND:VCARD\n", 2},
+		"not ended":           {"BEGIN:VCARD\nVERSION:4.0\nFN:Juan\n", 1},
+		"nested card":         {"BEGIN:VCARD\nBEGIN:VCARD\n", 2},
+		"end without begin":   {"END:VCARD\n", 1},
+		"outside a card":      {"FN:Juan\n", 1},
+		"without value":       {"BEGIN:VCARD\nFN Juan\nEND:VCARD\n", 2},
+	}
+
+	for name, test := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(test.file))
+
+			var syntaxError *SyntaxError
+			require.True(t, errors.As(err, &syntaxError), "got %v", err)
+			assert.Equal(t, test.line, syntaxError.Line)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package vcard
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// maxLineLength is the length, in octets, past which content lines are folded.
+const maxLineLength = 75
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, ",", `\,`, ";", `\;`)
+
+// param is a parameter of a property, written as NAME=value,value.
+type param struct {
+	name   string
+	values []string
+}
+
+// Encode writes contact to w as a vCard of version.
+func Encode(w io.Writer, contact models.Contact, version string) error {
+	return Card{Contact: contact}.Encode(w, version)
+}
+
+// Encode writes the card to w as a vCard of version, with its UID when it has one.
+func (vCard Card) Encode(w io.Writer, version string) error {
+	if !IsVersion(version) {
+		return fmt.Errorf("unsupported vCard version %q", version)
+	}
+
+	contact := vCard.Contact
+	card := &encoder{version: version}
+
+	card.property("BEGIN", nil, "VCARD")
+	card.property("VERSION", nil, version)
+
+	if vCard.UID != "" {
+		card.property("UID", nil, escapeText(vCard.UID))
+	}
+
+	card.property("FN", nil, escapeText(formattedName(contact)))
+	card.property("N", nil, strings.Join([]string{
+		escapeText(contact.FamilyName),
+		escapeText(contact.GivenName),
+		"",
+		escapeText(contact.Prefix),
+		escapeText(contact.Suffix),
+	}, ";"))
+
+	if contact.Nickname != "" {
+		card.property("NICKNAME", nil, escapeText(contact.Nickname))
+	}
+
+	if contact.PhoneNumber != "" {
+		types := []string{"voice"}
+		if contact.PhoneType == "mobile" {
+			types = []string{"cell"}
+		}
+
+		card.phone(contact.PhoneNumber, types, false)
+	}
+
+	for _, phone := range contact.Phones {
+		card.phone(phone.Number, phoneTypes[phone.Label], phone.Primary)
+	}
+
+	for _, email := range contact.Emails {
+		types := placeTypes(email.Label)
+		if version == Version3 {
+			types = append([]string{"internet"}, types...)
+		}
+
+		card.property("EMAIL", card.typeParams(types, email.Primary), escapeText(email.Address))
+	}
+
+	for _, address := range contact.Addresses {
+		card.property("ADR", card.typeParams(placeTypes(address.Label), address.Primary), strings.Join([]string{
+			"",
+			"",
+			escapeText(address.Street),
+			escapeText(address.City),
+			escapeText(address.Region),
+			escapeText(address.PostalCode),
+			escapeText(address.Country),
+		}, ";"))
+	}
+
+	if contact.Company != "" {
+		card.property("ORG", nil, escapeText(contact.Company))
+	}
+
+	if contact.Notes != "" {
+		card.property("NOTE", nil, escapeText(contact.Notes))
+	}
+
+	if contact.Photo != "" {
+		card.photo(contact.Photo)
+	}
+
+	card.property("END", nil, "VCARD")
+
+	_, err := io.WriteString(w, card.String())
+
+	return err
+}
+
+type encoder struct {
+	strings.Builder
+	version string
+}
+
+// property writes a content line, folded so that no line is longer than
+// maxLineLength octets.
+func (card *encoder) property(name string, params []param, value string) {
+	line := name
+	for _, parameter := range params {
+		line += ";" + parameter.name
+		if len(parameter.values) > 0 {
+			line += "=" + strings.Join(parameter.values, ",")
+		}
+	}
+
+	line += ":" + value
+
+	// continuation lines start with a space, which counts towards their length
+	for limit := maxLineLength; len(line) > limit; limit = maxLineLength - 1 {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		card.WriteString(line[:cut])
+		card.WriteString("\r\n ")
+		line = line[cut:]
+	}
+
+	card.WriteString(line)
+	card.WriteString("\r\n")
+}
+
+func (card *encoder) phone(number string, types []string, primary bool) {
+	params := card.typeParams(types, primary)
+
+	if card.version == Version4 {
+		card.property("TEL", append([]param{{name: "VALUE", values: []string{"uri"}}}, params...), "tel:"+number)
+		return
+	}
+
+	card.property("TEL", params, escapeText(number))
+}
+
+// typeParams are the TYPE parameters of types, plus the preference of primary
+// items: a pref type in 3.0 and PREF=1 in 4.0.
+func (card *encoder) typeParams(types []string, primary bool) []param {
+	if primary && card.version == Version3 {
+		types = append(append([]string{}, types...), "pref")
+	}
+
+	var params []param
+	if len(types) > 0 {
+		params = append(params, param{name: "TYPE", values: types})
+	}
+
+	if primary && card.version == Version4 {
+		params = append(params, param{name: "PREF", values: []string{"1"}})
+	}
+
+	return params
+}
+
+// photo writes the photo of a contact. vCard 4.0 takes the URI as it is, 3.0
+// wants the content of data URIs inlined as base64.
+func (card *encoder) photo(uri string) {
+	if card.version == Version3 {
+		if mediaType, data, ok := base64DataURI(uri); ok {
+			params := []param{{name: "ENCODING", values: []string{"b"}}}
+			if imageType := strings.TrimPrefix(mediaType, "image/"); imageType != "" {
+				params = append(params, param{name: "TYPE", values: []string{strings.ToUpper(imageType)}})
+			}
+
+			card.property("PHOTO", params, data)
+
+			return
+		}
+
+		card.property("PHOTO", []param{{name: "VALUE", values: []string{"uri"}}}, uri)
+
+		return
+	}
+
+	card.property("PHOTO", nil, uri)
+}
+
+// base64DataURI splits a data URI holding base64 content into its media type and
+// its data.
+func base64DataURI(uri string) (string, string, bool) {
+	if !strings.HasPrefix(uri, "data:") {
+		return "", "", false
+	}
+
+	header, data, found := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !found || !strings.HasSuffix(header, ";base64") {
+		return "", "", false
+	}
+
+	return strings.TrimSuffix(header, ";base64"), data, true
+}
+
+// formattedName is the FN of a contact, which can not be empty.
+func formattedName(contact models.Contact) string {
+	if contact.Name != "" {
+		return contact.Name
+	}
+
+	return contact.PhoneNumber
+}
+
+func escapeText(value string) string {
+	return textEscaper.Replace(strings.ReplaceAll(value, "\r\n", "\n"))
+}
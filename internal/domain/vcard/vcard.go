@@ -0,0 +1,102 @@
+// Package vcard encodes contacts as vCards, versions 3.0 (RFC 2426) and 4.0
+// (RFC 6350), and decodes them back.
+//
+// The phone number that identifies a contact is written as its first TEL and,
+// when decoding, the first TEL of a card becomes the phone number of the
+// contact; the rest are its additional phones. Labels are mapped to the TYPE
+// parameters closest to them and primary items are marked as preferred.
+package vcard
+
+import (
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Versions of the vCard format.
+const (
+	Version3 = "3.0"
+	Version4 = "4.0"
+)
+
+// MIMEType is the media type of vCard files and Extension the extension of their
+// names.
+const (
+	MIMEType  = "text/vcard"
+	Extension = ".vcf"
+)
+
+// Card is a contact as a vCard. UID is the UID property, which contacts have no
+// field for, and Line is the line where a decoded card begins.
+type Card struct {
+	Line    int
+	UID     string
+	Contact models.Contact
+}
+
+// IsVersion reports whether version is a supported version of the format.
+func IsVersion(version string) bool {
+	return version == Version3 || version == Version4
+}
+
+// phoneTypes are the TYPE parameters written for each phone label.
+var phoneTypes = map[string][]string{
+	"mobile": {"cell"},
+	"work":   {"work", "voice"},
+	"home":   {"home", "voice"},
+	"main":   {"main-number"},
+	"fax":    {"fax"},
+	"other":  {"voice"},
+}
+
+// phoneLabel is the label of a phone with types, the first type found in the
+// order of the switch wins, so a work fax is a fax.
+func phoneLabel(types []string) string {
+	switch {
+	case hasType(types, "fax"):
+		return "fax"
+	case hasType(types, "cell", "mobile", "iphone"):
+		return "mobile"
+	case hasType(types, "main-number", "main"):
+		return "main"
+	case hasType(types, "work"):
+		return "work"
+	case hasType(types, "home"):
+		return "home"
+	default:
+		return "other"
+	}
+}
+
+// placeLabel is the label of an email or an address with types.
+func placeLabel(types []string) string {
+	switch {
+	case hasType(types, "work"):
+		return "work"
+	case hasType(types, "home"):
+		return "home"
+	default:
+		return "other"
+	}
+}
+
+// placeTypes are the TYPE parameters written for an email or an address label.
+func placeTypes(label string) []string {
+	if label == "work" || label == "home" {
+		return []string{label}
+	}
+
+	return nil
+}
+
+func hasType(types []string, wanted ...string) bool {
+	for _, current := range types {
+		for _, value := range wanted {
+			if strings.EqualFold(current, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
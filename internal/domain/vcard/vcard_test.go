@@ -0,0 +1,164 @@
+package vcard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var roundTripContacts = map[string]models.Contact{
+	"minimal": {
+		Name:           "Juan",
+		StructuredName: models.StructuredName{GivenName: "Juan"},
+		PhoneNumber:    "+573000000000",
+	},
+	"full": {
+		Name: "Dr. Juan Pérez Jr.",
+		StructuredName: models.StructuredName{
+			Prefix:     "Dr.",
+			GivenName:  "Juan",
+			FamilyName: "Pérez",
+			Suffix:     "Jr.",
+			Nickname:   "Juancho",
+		},
+		PhoneNumber: "+573000000000",
+		Phones: []models.Phone{
+			{Label: "mobile", Number: "+573000000001"},
+			{Label: "work", Number: "+576010000000", Primary: true},
+			{Label: "home", Number: "+576010000001"},
+			{Label: "main", Number: "+576010000002"},
+			{Label: "fax", Number: "+576010000003"},
+			{Label: "other", Number: "+576010000004"},
+		},
+		Emails: []models.Email{
+			{Label: "work", Address: "juan@example.com", Primary: true},
+			{Label: "home", Address: "juan@home.example.com"},
+			{Label: "other", Address: "juan@other.example.com"},
+		},
+		Addresses: []models.Address{
+			{Label: "home", Street: "Calle 100 # 10-20", City: "Bogotá", Region: "Cundinamarca",
+				PostalCode: "110111", Country: "CO", Primary: true},
+			{Label: "work", Street: "Carrera 7", City: "Bogotá", Country: "CO"},
+			{Label: "other", Street: "Avenida 68"},
+		},
+		Company: "Acme; Sales",
+		Notes:   "met at the conference\nlikes coffee",
+		Photo:   "https://example.com/juan.jpg",
+	},
+	"escaped text": {
+		Name:           `Pérez; Juan, the \ one`,
+		StructuredName: models.StructuredName{GivenName: "Juan, the", FamilyName: `Pérez; \`},
+		PhoneNumber:    "+573000000000",
+		Notes:          "semicolons; commas, backslashes \\ and\nnew lines",
+	},
+	"data uri photo": {
+		Name:           "Juan",
+		StructuredName: models.StructuredName{GivenName: "Juan"},
+		PhoneNumber:    "+573000000000",
+		Photo:          "data:image/png;base64," + strings.Repeat("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk", 20),
+	},
+	"long unicode lines": {
+		Name:           strings.Repeat("Ñandú ", 30) + "Ñandú",
+		StructuredName: models.StructuredName{GivenName: strings.Repeat("Ñandú ", 30) + "Ñandú"},
+		PhoneNumber:    "+573000000000",
+		Notes:          strings.Repeat("日本語のメモ ", 40),
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, version := range []string{Version3, Version4} {
+		for name, contact := range roundTripContacts {
+			t.Run(version+"/"+name, func(t *testing.T) {
+				var file bytes.Buffer
+
+				require.NoError(t, Encode(&file, contact, version))
+
+				cards, err := Decode(&file)
+
+				require.NoError(t, err)
+				require.Len(t, cards, 1)
+				assert.Equal(t, 1, cards[0].Line)
+				assert.Equal(t, contact, cards[0].Contact)
+			})
+		}
+	}
+}
+
+func TestRoundTrip_ManyCards(t *testing.T) {
+	var file bytes.Buffer
+
+	require.NoError(t, Encode(&file, roundTripContacts["full"], Version4))
+	require.NoError(t, Encode(&file, roundTripContacts["minimal"], Version3))
+
+	cards, err := Decode(&file)
+
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+	assert.Equal(t, roundTripContacts["full"], cards[0].Contact)
+	assert.Equal(t, roundTripContacts["minimal"], cards[1].Contact)
+	assert.Greater(t, cards[1].Line, cards[0].Line)
+}
+
+func TestRoundTrip_UID(t *testing.T) {
+	var file bytes.Buffer
+
+	card := Card{UID: "urn:uuid:4fbe8971-0bc3-424c-9c26-36c3e1eff6b1", Contact: roundTripContacts["minimal"]}
+
+	require.NoError(t, card.Encode(&file, Version4))
+	assert.True(t, strings.HasPrefix(file.String(),
+		"BEGIN:VCARD\r\nVERSION:4.0\r\nUID:urn:uuid:4fbe8971-0bc3-424c-9c26-36c3e1eff6b1\r\n"))
+
+	cards, err := Decode(&file)
+
+	require.NoError(t, err)
+	require.Len(t, cards, 1)
+	assert.Equal(t, card.UID, cards[0].UID)
+	assert.Equal(t, card.Contact, cards[0].Contact)
+}
+
+func TestEncode_FoldsLongLines(t *testing.T) {
+	var file bytes.Buffer
+
+	require.NoError(t, Encode(&file, roundTripContacts["long unicode lines"], Version4))
+
+	for _, line := range strings.Split(strings.TrimSuffix(file.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineLength)
+		assert.True(t, strings.ToValidUTF8(line, "?") == line, "the line %q splits a character", line)
+	}
+}
+
+func TestEncode_Version3(t *testing.T) {
+	var file bytes.Buffer
+
+	require.NoError(t, Encode(&file, roundTripContacts["full"], Version3))
+
+	card := file.String()
+	assert.True(t, strings.HasPrefix(card, "BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Dr. Juan Pérez Jr.\r\n"))
+	assert.Contains(t, card, "N:Pérez;Juan;;Dr.;Jr.\r\n")
+	assert.Contains(t, card, "TEL;TYPE=work,voice,pref:+576010000000\r\n")
+	assert.Contains(t, card, "EMAIL;TYPE=internet,work,pref:juan@example.com\r\n")
+	assert.Contains(t, card, "ORG:Acme\\; Sales\r\n")
+	assert.Contains(t, card, "NOTE:met at the conference\\nlikes coffee\r\n")
+	assert.Contains(t, card, "PHOTO;VALUE=uri:https://example.com/juan.jpg\r\n")
+	assert.True(t, strings.HasSuffix(card, "END:VCARD\r\n"))
+}
+
+func TestEncode_Version4(t *testing.T) {
+	var file bytes.Buffer
+
+	require.NoError(t, Encode(&file, roundTripContacts["full"], Version4))
+
+	card := file.String()
+	assert.Contains(t, card, "VERSION:4.0\r\n")
+	assert.Contains(t, card, "TEL;VALUE=uri;TYPE=work,voice;PREF=1:tel:+576010000000\r\n")
+	assert.Contains(t, card, "EMAIL;TYPE=work;PREF=1:juan@example.com\r\n")
+	assert.Contains(t, card, "PHOTO:https://example.com/juan.jpg\r\n")
+}
+
+func TestEncode_WhenVersionIsNotSupported(t *testing.T) {
+	assert.Error(t, Encode(&bytes.Buffer{}, roundTripContacts["minimal"], "2.1"))
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type addressBook struct {
+	store *Store
+}
+
+func NewAddressBook(store *Store) repository.AddressBook {
+	return &addressBook{
+		store,
+	}
+}
+
+func (repo *addressBook) GetResource(name string) (models.CardResource, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	resource, found := repo.store.resources[name]
+	if !found {
+		return models.CardResource{}, apperrors.NotFound(fmt.Sprintf("the resource %s was not found", name), nil)
+	}
+
+	return resource, nil
+}
+
+// GetResources returns the named resources of the contacts, contacts without a
+// name are left out. Purged contacts keep the name they had.
+func (repo *addressBook) GetResources(contactIDs []uint) ([]models.CardResource, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	var resources []models.CardResource
+
+	for _, contactID := range contactIDs {
+		if resource, found := repo.store.resourceOf(contactID); found {
+			resources = append(resources, resource)
+		} else if tombstone, found := repo.store.tombstoneOf(contactID); found {
+			resources = append(resources, models.CardResource{Name: tombstone.Name, ContactID: contactID})
+		}
+	}
+
+	return resources, nil
+}
+
+func (repo *addressBook) HasResource(contactID uint) (bool, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	_, found := repo.store.resourceOf(contactID)
+
+	return found, nil
+}
+
+// SaveResource names a contact. A name given before to another contact, which
+// can only be in the trash or purged by then, moves to this one, and the contact
+// loses the name it had.
+func (repo *addressBook) SaveResource(resource models.CardResource) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if previous, found := repo.store.resourceOf(resource.ContactID); found {
+		delete(repo.store.resources, previous.Name)
+	}
+
+	delete(repo.store.tombstones, resource.Name)
+
+	resource.Contact = nil
+	repo.store.resources[resource.Name] = resource
+
+	return nil
+}
+
+// SyncToken is the id of the last audit entry: every change made to a contact is
+// audited, so the token moves with each of them.
+func (repo *addressBook) SyncToken() (uint, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	if len(repo.store.entries) == 0 {
+		return 0, nil
+	}
+
+	return repo.store.entries[len(repo.store.entries)-1].ID, nil
+}
+
+// Changes returns the contacts with audit entries after the since token, in the
+// order of their last change.
+func (repo *addressBook) Changes(since uint) (models.AddressBookChanges, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	lastIDs := map[uint]uint{}
+
+	for _, entry := range repo.store.entries {
+		if entry.ID > since {
+			lastIDs[entry.ContactID] = entry.ID
+		}
+	}
+
+	changes := models.AddressBookChanges{Token: since}
+
+	for contactID := range lastIDs {
+		changes.ContactIDs = append(changes.ContactIDs, contactID)
+	}
+
+	sort.Slice(changes.ContactIDs, func(i, j int) bool {
+		return lastIDs[changes.ContactIDs[i]] < lastIDs[changes.ContactIDs[j]]
+	})
+
+	if len(changes.ContactIDs) > 0 {
+		changes.Token = lastIDs[changes.ContactIDs[len(changes.ContactIDs)-1]]
+	}
+
+	return changes, nil
+}
+
+// resourceOf returns the resource naming the contact.
+func (store *Store) resourceOf(contactID uint) (models.CardResource, bool) {
+	for _, resource := range store.resources {
+		if resource.ContactID == contactID {
+			return resource, true
+		}
+	}
+
+	return models.CardResource{}, false
+}
+
+// tombstoneOf returns the tombstone keeping the name of the purged contact.
+func (store *Store) tombstoneOf(contactID uint) (models.CardTombstone, bool) {
+	for _, tombstone := range store.tombstones {
+		if tombstone.ContactID == contactID {
+			return tombstone, true
+		}
+	}
+
+	return models.CardTombstone{}, false
+}
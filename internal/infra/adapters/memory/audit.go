@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type audit struct {
+	store *Store
+}
+
+func NewAudit(store *Store) repository.Audit {
+	return &audit{
+		store,
+	}
+}
+
+// History returns the audit entries of a contact, the newest first. Contacts
+// that were purged keep their history.
+func (repo *audit) History(contactID uint, paginate models.Paginator) (*models.Paginator, error) {
+	history, err := repo.Get(paginate, models.AuditFilter{ContactID: contactID})
+	if err != nil || history.TotalRecord > 0 {
+		return history, err
+	}
+
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	if _, found := repo.store.contact(contactID); !found {
+		return nil, contactNotFound(contactID)
+	}
+
+	return history, nil
+}
+
+// Get returns the audit entries matching filter, the newest first.
+func (repo *audit) Get(paginate models.Paginator, filter models.AuditFilter) (*models.Paginator, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	entries := []models.AuditEntry{}
+
+	for _, entry := range repo.store.entries {
+		if matchesAudit(entry, filter) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+
+		return entries[i].ID > entries[j].ID
+	})
+
+	from, to := pageBounds(paginate, len(entries))
+
+	return offsetPage(paginate, int64(len(entries)), entries[from:to]), nil
+}
+
+func matchesAudit(entry models.AuditEntry, filter models.AuditFilter) bool {
+	return (filter.ContactID == 0 || entry.ContactID == filter.ContactID) &&
+		(filter.Actor == "" || entry.Actor == filter.Actor) &&
+		(filter.Action == "" || entry.Action == filter.Action) &&
+		(filter.RequestID == "" || entry.RequestID == filter.RequestID) &&
+		(filter.From.IsZero() || !entry.CreatedAt.Before(filter.From)) &&
+		(filter.To.IsZero() || !entry.CreatedAt.After(filter.To))
+}
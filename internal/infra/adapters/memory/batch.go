@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Batch applies operations at once and returns their results in the same order.
+// Every operation is checked before anything is written: updates and deletes
+// need a contact at the expected version, creates and updates a phone number no
+// other contact keeps. When atomic, one failed check leaves every contact
+// untouched; otherwise only the operations that passed are applied. Deletes are
+// applied first, so their numbers can be taken by the rest of the batch.
+func (repo *contacts) Batch(origin models.Origin, operations []models.BatchOperation,
+	atomic bool) ([]models.BatchResult, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	results := make([]models.BatchResult, len(operations))
+	current := map[uint]models.Contact{}
+
+	for _, operation := range operations {
+		if contact, found := repo.store.active(operation.ID); found && operation.Action != models.BatchCreate {
+			current[operation.ID] = contact
+		}
+	}
+
+	repo.store.checkBatch(operations, current, results)
+
+	if atomic {
+		for _, result := range results {
+			if result.Err != nil {
+				return results, nil
+			}
+		}
+	}
+
+	if err := repo.store.applyBatch(origin, operations, current, results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// checkBatch sets the error of the results whose operation can not be applied
+// to the current contacts.
+func (store *Store) checkBatch(operations []models.BatchOperation, current map[uint]models.Contact,
+	results []models.BatchResult) {
+	deleted := make(map[uint]bool)
+
+	for i, operation := range operations {
+		if operation.Action == models.BatchCreate {
+			continue
+		}
+
+		contact, found := current[operation.ID]
+
+		switch {
+		case !found:
+			results[i].Err = contactNotFound(operation.ID)
+		case operation.Version != 0 && contact.Version != operation.Version:
+			results[i].Err = contactModified(operation.ID)
+		case operation.Action == models.BatchDelete:
+			deleted[operation.ID] = true
+		}
+	}
+
+	// a number is taken by the contact keeping it, unless it is deleted by the
+	// batch, and by the first operation of the batch claiming it
+	claimed := make(map[string]bool)
+
+	for i, operation := range operations {
+		if operation.Action == models.BatchDelete || results[i].Err != nil {
+			continue
+		}
+
+		number := operation.Contact.PhoneNumber
+		owner, owned := store.phoneOwner(number)
+
+		if claimed[number] || (owned && owner != operation.ID && !deleted[owner]) {
+			results[i].Err = apperrors.Conflict(fmt.Sprintf("your contact number %s already exists", number), nil)
+			continue
+		}
+
+		claimed[number] = true
+	}
+}
+
+// applyBatch writes the operations whose results have no error, keeps a revision
+// of the contacts created and updated and audits every write on behalf of origin.
+func (store *Store) applyBatch(origin models.Origin, operations []models.BatchOperation,
+	current map[uint]models.Contact, results []models.BatchResult) error {
+	now := time.Now()
+	ids := make([]uint, len(operations))
+
+	for _, action := range []models.BatchAction{models.BatchDelete, models.BatchUpdate, models.BatchCreate} {
+		for i, operation := range operations {
+			if results[i].Err != nil || operation.Action != action {
+				continue
+			}
+
+			contact := operation.Contact
+
+			switch action {
+			case models.BatchCreate:
+				contact.ID = store.nextID("contacts")
+				contact.Version = 1
+				store.save(&contact)
+			case models.BatchUpdate:
+				store.replace(&contact, current[operation.ID])
+			case models.BatchDelete:
+				contact.ID = operation.ID
+				store.trash(operation.ID, now)
+			}
+
+			ids[i] = contact.ID
+		}
+	}
+
+	var entries []models.AuditEntry
+
+	for i, operation := range operations {
+		if results[i].Err != nil {
+			continue
+		}
+
+		after, _ := store.contact(ids[i])
+		results[i].Contact = after
+
+		var before *models.Contact
+		if contact, found := current[ids[i]]; found {
+			before = &contact
+		}
+
+		action := models.AuditCreate
+
+		switch operation.Action {
+		case models.BatchUpdate:
+			action = models.AuditUpdate
+		case models.BatchDelete:
+			action = models.AuditDelete
+		}
+
+		entry, err := auditEntry(origin, action, before, &after)
+		if err != nil {
+			return err
+		}
+
+		if operation.Action != models.BatchDelete {
+			store.recordRevision(after, now)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	store.recordEntries(now, entries...)
+
+	return nil
+}
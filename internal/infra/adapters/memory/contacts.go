@@ -0,0 +1,407 @@
+package memory
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+	"gorm.io/gorm"
+)
+
+// exportBatchSize is how many contacts an export hands to its writer at once.
+const exportBatchSize = 500
+
+type contacts struct {
+	store *Store
+}
+
+func NewContacts(store *Store) repository.Contacts {
+	return &contacts{
+		store,
+	}
+}
+
+// Create saves the contact and audits its creation on behalf of origin.
+func (repo *contacts) Create(origin models.Origin, contact models.Contact) (models.Contact, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if _, taken := repo.store.phoneOwner(contact.PhoneNumber); taken {
+		return models.Contact{}, phoneExists(contact.PhoneNumber)
+	}
+
+	now := time.Now()
+
+	contact.ID = repo.store.nextID("contacts")
+	contact.Version = 1
+	contact.DeletedAt = gorm.DeletedAt{}
+	repo.store.save(&contact)
+
+	after, _ := repo.store.contact(contact.ID)
+	repo.store.recordRevision(after, now)
+
+	if err := repo.store.recordChange(origin, models.AuditCreate, nil, &after, now); err != nil {
+		return models.Contact{}, err
+	}
+
+	return after, nil
+}
+
+func (repo *contacts) GetByID(id uint) (models.Contact, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	contact, found := repo.store.active(id)
+	if !found {
+		return models.Contact{}, contactNotFound(id)
+	}
+
+	return contact, nil
+}
+
+// GetByPhoneNumbers returns the id, phone number and version of the contacts,
+// out of the trash, that have one of numbers as their phone number.
+func (repo *contacts) GetByPhoneNumbers(numbers []string) ([]models.Contact, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	var contacts []models.Contact
+
+	for _, number := range numbers {
+		if id, found := repo.store.phoneOwner(number); found {
+			stored := repo.store.contacts[id]
+			contacts = append(contacts, models.Contact{ID: id, PhoneNumber: number, Version: stored.Version})
+		}
+	}
+
+	return contacts, nil
+}
+
+// Update replaces every field of the contact, fields left empty in contact are
+// cleared. When version is not 0 the contact is only written if it is still at
+// that version; every write bumps the version, keeps a revision of the contact
+// and is audited on behalf of origin.
+func (repo *contacts) Update(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error) {
+	return repo.update(origin, models.AuditUpdate, id, contact, version)
+}
+
+// Revert writes contact, the content of a previous revision, as Update does but
+// audits the write as a revert.
+func (repo *contacts) Revert(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error) {
+	return repo.update(origin, models.AuditRevert, id, contact, version)
+}
+
+func (repo *contacts) update(origin models.Origin, action models.AuditAction, id uint, contact models.Contact,
+	version uint) (models.Contact, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	before, err := repo.store.current(id, version)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	if err = repo.store.write(&contact, before); err != nil {
+		return models.Contact{}, err
+	}
+
+	now := time.Now()
+
+	after, _ := repo.store.contact(id)
+	repo.store.recordRevision(after, now)
+
+	if err = repo.store.recordChange(origin, action, &before, &after, now); err != nil {
+		return models.Contact{}, err
+	}
+
+	return after, nil
+}
+
+// current returns the contact out of the trash and checks that it is at version,
+// unless version is 0.
+func (store *Store) current(id uint, version uint) (models.Contact, error) {
+	contact, found := store.active(id)
+	if !found {
+		return models.Contact{}, contactNotFound(id)
+	}
+
+	if version != 0 && contact.Version != version {
+		return models.Contact{}, contactModified(id)
+	}
+
+	return contact, nil
+}
+
+// write replaces every field and child of current with the ones of contact, and
+// bumps its version. Its tags are left as they are.
+func (store *Store) write(contact *models.Contact, current models.Contact) error {
+	if owner, taken := store.phoneOwner(contact.PhoneNumber); taken && owner != current.ID {
+		return phoneExists(contact.PhoneNumber)
+	}
+
+	store.replace(contact, current)
+
+	return nil
+}
+
+// replace writes contact in place of current, with a new version.
+func (store *Store) replace(contact *models.Contact, current models.Contact) {
+	contact.ID = current.ID
+	contact.Version = current.Version + 1
+	contact.DeletedAt = current.DeletedAt
+	store.save(contact)
+}
+
+// Delete moves the contact to the trash, when version is not 0 only if it is
+// still at that version.
+func (repo *contacts) Delete(origin models.Origin, id uint, version uint) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	before, err := repo.store.current(id, version)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	repo.store.trash(id, now)
+
+	after, _ := repo.store.contact(id)
+
+	return repo.store.recordChange(origin, models.AuditDelete, &before, &after, now)
+}
+
+func (repo *contacts) Get(paginate models.Paginator, filter models.ContactFilter) (*models.Paginator, error) {
+	order, err := contactsOrder(filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := repo.list(filter, order)
+	if err != nil {
+		return nil, err
+	}
+
+	if paginate.Cursor != nil {
+		return getByCursor(paginate, found, order)
+	}
+
+	from, to := pageBounds(paginate, len(found))
+
+	return offsetPage(paginate, int64(len(found)), found[from:to]), nil
+}
+
+// list returns the contacts out of the trash matching filter, sorted by order.
+func (repo *contacts) list(filter models.ContactFilter, order []models.SortField) ([]models.Contact, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	found := []models.Contact{}
+
+	for id := range repo.store.contacts {
+		contact, active := repo.store.active(id)
+		if !active {
+			continue
+		}
+
+		match, err := matches(contact, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		if match {
+			found = append(found, contact)
+		}
+	}
+
+	sortContacts(found, order)
+
+	return found, nil
+}
+
+// getByCursor returns the page of found, sorted by order, placed after (or
+// before) paginate.Cursor.
+func getByCursor(paginate models.Paginator, found []models.Contact, order []models.SortField) (*models.Paginator,
+	error) {
+	cursor := paginate.Cursor
+
+	sought, err := seekContacts(found, order, cursor.Keys, cursor.Backward)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(sought) > paginate.Limit
+
+	page := sought
+	if hasMore && cursor.Backward {
+		page = sought[len(sought)-paginate.Limit:]
+	} else if hasMore {
+		page = sought[:paginate.Limit]
+	}
+
+	contacts := append([]models.Contact{}, page...)
+
+	paginator := &models.Paginator{
+		TotalRecord: int64(len(found)),
+		TotalPage:   int(math.Ceil(float64(len(found)) / float64(paginate.Limit))),
+		Records:     contacts,
+		Limit:       paginate.Limit,
+	}
+
+	if len(contacts) == 0 {
+		return paginator, nil
+	}
+
+	if hasMore || cursor.Backward {
+		paginator.Next = &models.Cursor{Keys: contactKeys(contacts[len(contacts)-1], order)}
+	}
+
+	if (hasMore && cursor.Backward) || (!cursor.Backward && len(cursor.Keys) > 0) {
+		paginator.Prev = &models.Cursor{Keys: contactKeys(contacts[0], order), Backward: true}
+	}
+
+	return paginator, nil
+}
+
+// Export walks the contacts matching filter, in its order. write is called with
+// every batch of contacts; an error returned by it stops the export. The
+// contacts are read at once, so they all come from the same state of the store.
+func (repo *contacts) Export(filter models.ContactFilter, write func(contacts []models.Contact) error) error {
+	order, err := contactsOrder(filter.Sort)
+	if err != nil {
+		return err
+	}
+
+	found, err := repo.list(filter, order)
+	if err != nil {
+		return err
+	}
+
+	for from := 0; from < len(found); from += exportBatchSize {
+		to := from + exportBatchSize
+		if to > len(found) {
+			to = len(found)
+		}
+
+		if err = write(found[from:to]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetTrash lists the contacts in the trash, the most recently deleted first.
+func (repo *contacts) GetTrash(paginate models.Paginator) (*models.Paginator, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	trash := repo.store.trashed(func(models.Contact) bool { return true })
+
+	sort.Slice(trash, func(i, j int) bool {
+		if !trash[i].DeletedAt.Time.Equal(trash[j].DeletedAt.Time) {
+			return trash[i].DeletedAt.Time.After(trash[j].DeletedAt.Time)
+		}
+
+		return trash[i].ID > trash[j].ID
+	})
+
+	from, to := pageBounds(paginate, len(trash))
+
+	return offsetPage(paginate, int64(len(trash)), trash[from:to]), nil
+}
+
+// trashed returns the contacts in the trash that keep returns true for, by id.
+func (store *Store) trashed(keep func(contact models.Contact) bool) []models.Contact {
+	trash := []models.Contact{}
+
+	for id, stored := range store.contacts {
+		if !stored.DeletedAt.Valid {
+			continue
+		}
+
+		if contact, _ := store.contact(id); keep(contact) {
+			trash = append(trash, contact)
+		}
+	}
+
+	sort.Slice(trash, func(i, j int) bool { return trash[i].ID < trash[j].ID })
+
+	return trash
+}
+
+// Restore takes the contact out of the trash with a new version. It fails with a
+// conflict when another contact took its phone number in the meantime.
+func (repo *contacts) Restore(origin models.Origin, id uint) (models.Contact, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	before, found := repo.store.contact(id)
+	if !found || !before.DeletedAt.Valid {
+		return models.Contact{}, contactNotInTrash(id)
+	}
+
+	if _, taken := repo.store.phoneOwner(before.PhoneNumber); taken {
+		return models.Contact{}, apperrors.Conflict(fmt.Sprintf(
+			"the contact: %v can not be restored, the number %s belongs to another contact",
+			id, before.PhoneNumber), nil)
+	}
+
+	stored := repo.store.contacts[id]
+	stored.DeletedAt = gorm.DeletedAt{}
+	stored.Version++
+
+	now := time.Now()
+
+	after, _ := repo.store.contact(id)
+	repo.store.recordRevision(after, now)
+
+	if err := repo.store.recordChange(origin, models.AuditRestore, &before, &after, now); err != nil {
+		return models.Contact{}, err
+	}
+
+	return after, nil
+}
+
+// Purge deletes for good a contact that is in the trash, along with its phones,
+// emails, addresses and memberships. Its history is kept.
+func (repo *contacts) Purge(origin models.Origin, id uint) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	contact, found := repo.store.contact(id)
+	if !found || !contact.DeletedAt.Valid {
+		return contactNotInTrash(id)
+	}
+
+	repo.store.purge(id)
+
+	return repo.store.recordChange(origin, models.AuditPurge, &contact, nil, time.Now())
+}
+
+// PurgeDeletedBefore deletes for good the contacts moved to the trash before
+// before and returns how many were deleted.
+func (repo *contacts) PurgeDeletedBefore(origin models.Origin, before time.Time) (int64, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	expired := repo.store.trashed(func(contact models.Contact) bool {
+		return contact.DeletedAt.Time.Before(before)
+	})
+
+	now := time.Now()
+
+	for i := range expired {
+		repo.store.purge(expired[i].ID)
+
+		if err := repo.store.recordChange(origin, models.AuditPurge, &expired[i], nil, now); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(expired)), nil
+}
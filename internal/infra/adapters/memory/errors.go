@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+)
+
+// The errors of the in-memory repositories carry the messages of the database
+// ones, so clients can not tell the backends apart.
+
+func contactNotFound(id uint) error {
+	return apperrors.NotFound(fmt.Sprintf("the contact: %v does not exist", id), nil)
+}
+
+func contactNotInTrash(id uint) error {
+	return apperrors.NotFound(fmt.Sprintf("the contact: %v is not in the trash", id), nil)
+}
+
+func contactModified(id uint) error {
+	return apperrors.PreconditionFailed(
+		fmt.Sprintf("the contact: %v was modified by someone else, reload it and try again", id), nil)
+}
+
+func phoneExists(number string) error {
+	return apperrors.Conflict(fmt.Sprintf("your contact number %s already exists", number), nil)
+}
+
+func tagNotFound(id uint) error {
+	return apperrors.NotFound(fmt.Sprintf("the tag: %v does not exist", id), nil)
+}
+
+func tagExists(name string) error {
+	return apperrors.Conflict(fmt.Sprintf("the tag %s already exists", name), nil)
+}
+
+func groupNotFound(id uint) error {
+	return apperrors.NotFound(fmt.Sprintf("the group: %v does not exist", id), nil)
+}
+
+func groupExists(name string) error {
+	return apperrors.Conflict(fmt.Sprintf("the group %s already exists", name), nil)
+}
+
+func unknownField(field string) error {
+	return apperrors.Validation(fmt.Sprintf("unknown contact field %q", field), nil)
+}
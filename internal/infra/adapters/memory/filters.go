@@ -0,0 +1,233 @@
+package memory
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// contactField is a field that listings can filter and sort by. key reads it
+// from a contact as a cursor keeps it, numeric fields are compared as numbers.
+type contactField struct {
+	key     func(contact models.Contact) string
+	numeric bool
+}
+
+// contactFields are the fields that listings can filter and sort by, keyed by
+// the field name used by the api.
+var contactFields = map[string]contactField{
+	"id": {
+		key:     func(contact models.Contact) string { return strconv.FormatUint(uint64(contact.ID), 10) },
+		numeric: true,
+	},
+	"name":         {key: func(contact models.Contact) string { return contact.Name }},
+	"name_prefix":  {key: func(contact models.Contact) string { return contact.Prefix }},
+	"given_name":   {key: func(contact models.Contact) string { return contact.GivenName }},
+	"family_name":  {key: func(contact models.Contact) string { return contact.FamilyName }},
+	"name_suffix":  {key: func(contact models.Contact) string { return contact.Suffix }},
+	"nickname":     {key: func(contact models.Contact) string { return contact.Nickname }},
+	"phone_number": {key: func(contact models.Contact) string { return contact.PhoneNumber }},
+}
+
+// compare orders two keys of the field.
+func (field contactField) compare(a, b string) int {
+	if !field.numeric {
+		return strings.Compare(a, b)
+	}
+
+	x, _ := strconv.ParseUint(a, 10, 64)
+	y, _ := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// matches reports whether contact, read with its tags, matches filter.
+func matches(contact models.Contact, filter models.ContactFilter) (bool, error) {
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+
+		found := false
+		for _, value := range []string{contact.Name, contact.Nickname, contact.PhoneNumber} {
+			found = found || strings.Contains(strings.ToLower(value), search)
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	for _, fieldFilter := range filter.Filters {
+		field, ok := contactFields[fieldFilter.Field]
+		if !ok {
+			return false, unknownField(fieldFilter.Field)
+		}
+
+		value := field.key(contact)
+
+		if fieldFilter.Prefix && !strings.HasPrefix(value, fieldFilter.Value) {
+			return false, nil
+		}
+
+		if !fieldFilter.Prefix && value != fieldFilter.Value {
+			return false, nil
+		}
+	}
+
+	if len(filter.Tags) == 0 {
+		return true, nil
+	}
+
+	tagged := 0
+
+	for _, name := range filter.Tags {
+		for _, tag := range contact.Tags {
+			if tag.Name == name {
+				tagged++
+				break
+			}
+		}
+	}
+
+	if filter.AnyTag {
+		return tagged > 0, nil
+	}
+
+	return tagged == len(filter.Tags), nil
+}
+
+// contactsOrder returns the fields of sort, the id is always appended as the last
+// criteria so the order is total and pages are stable.
+func contactsOrder(sort []models.SortField) ([]models.SortField, error) {
+	order := make([]models.SortField, 0, len(sort)+1)
+	sortedByID := false
+
+	for _, sortField := range sort {
+		if _, ok := contactFields[sortField.Field]; !ok {
+			return nil, unknownField(sortField.Field)
+		}
+
+		sortedByID = sortedByID || sortField.Field == "id"
+		order = append(order, sortField)
+	}
+
+	if !sortedByID {
+		order = append(order, models.SortField{Field: "id"})
+	}
+
+	return order, nil
+}
+
+// compareContacts orders the keys of two contacts by order.
+func compareContacts(a, b []string, order []models.SortField) int {
+	for i, sortField := range order {
+		comparison := contactFields[sortField.Field].compare(a[i], b[i])
+		if sortField.Desc {
+			comparison = -comparison
+		}
+
+		if comparison != 0 {
+			return comparison
+		}
+	}
+
+	return 0
+}
+
+// sortContacts orders contacts by order.
+func sortContacts(contacts []models.Contact, order []models.SortField) {
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return compareContacts(contactKeys(contacts[i], order), contactKeys(contacts[j], order), order) < 0
+	})
+}
+
+// seekContacts keeps the contacts, sorted by order, placed after keys, or before
+// them when backward is set.
+func seekContacts(contacts []models.Contact, order []models.SortField, keys []string,
+	backward bool) ([]models.Contact, error) {
+	if len(keys) == 0 {
+		return contacts, nil
+	}
+
+	if len(keys) != len(order) {
+		return nil, apperrors.Validation("the cursor does not match the sort of the listing", nil)
+	}
+
+	for i, key := range keys {
+		if _, err := strconv.ParseUint(key, 10, 64); contactFields[order[i].Field].numeric && err != nil {
+			return nil, apperrors.Validation("the cursor is invalid", err)
+		}
+	}
+
+	var sought []models.Contact
+
+	for _, contact := range contacts {
+		comparison := compareContacts(contactKeys(contact, order), keys, order)
+		if (comparison > 0 && !backward) || (comparison < 0 && backward) {
+			sought = append(sought, contact)
+		}
+	}
+
+	return sought, nil
+}
+
+// contactKeys returns the cursor keys of contact for order.
+func contactKeys(contact models.Contact, order []models.SortField) []string {
+	keys := make([]string, len(order))
+	for i, sortField := range order {
+		keys[i] = contactFields[sortField.Field].key(contact)
+	}
+
+	return keys
+}
+
+// pageBounds returns the indexes, out of total records, where the page of
+// paginate starts and ends.
+func pageBounds(paginate models.Paginator, total int) (int, int) {
+	from := (paginate.Page - 1) * paginate.Limit
+	if from > total {
+		from = total
+	}
+
+	to := from + paginate.Limit
+	if to > total {
+		to = total
+	}
+
+	return from, to
+}
+
+// offsetPage builds the page of records found at the offset of paginate, out of
+// total records.
+func offsetPage(paginate models.Paginator, total int64, records interface{}) *models.Paginator {
+	paginator := &models.Paginator{
+		TotalRecord: total,
+		TotalPage:   int(math.Ceil(float64(total) / float64(paginate.Limit))),
+		Records:     records,
+		Offset:      (paginate.Page - 1) * paginate.Limit,
+		Limit:       paginate.Limit,
+		Page:        paginate.Page,
+		PrevPage:    paginate.Page,
+		NextPage:    paginate.Page,
+	}
+
+	if paginate.Page > 1 {
+		paginator.PrevPage = paginate.Page - 1
+	}
+
+	if paginate.Page < paginator.TotalPage {
+		paginator.NextPage = paginate.Page + 1
+	}
+
+	return paginator
+}
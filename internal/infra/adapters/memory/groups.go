@@ -0,0 +1,279 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type groups struct {
+	store *Store
+}
+
+func NewGroups(store *Store) repository.Groups {
+	return &groups{
+		store,
+	}
+}
+
+func (repo *groups) Create(group models.Group) (models.Group, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if _, taken := repo.store.groupNamed(group.Name); taken {
+		return models.Group{}, groupExists(group.Name)
+	}
+
+	group.ID = repo.store.nextID("groups")
+	repo.store.saveGroup(group, map[uint]bool{}, map[uint]bool{})
+
+	return group, nil
+}
+
+// GetByID returns the group with its direct members.
+func (repo *groups) GetByID(id uint) (models.Group, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	stored, found := repo.store.groups[id]
+	if !found {
+		return models.Group{}, groupNotFound(id)
+	}
+
+	group := stored.Group
+
+	for contactID := range stored.contacts {
+		// members are listed without their phones, emails, addresses and tags
+		if contact, active := repo.store.active(contactID); active {
+			contact.Phones, contact.Emails, contact.Addresses, contact.Tags = nil, nil, nil, nil
+			group.Contacts = append(group.Contacts, contact)
+		}
+	}
+
+	sortByName(group.Contacts)
+
+	for groupID := range stored.groups {
+		group.Groups = append(group.Groups, repo.store.groups[groupID].Group)
+	}
+
+	sort.Slice(group.Groups, func(i, j int) bool { return group.Groups[i].Name < group.Groups[j].Name })
+
+	return group, nil
+}
+
+func (repo *groups) Update(id uint, group models.Group) (models.Group, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, found := repo.store.groups[id]
+	if !found {
+		return models.Group{}, groupNotFound(id)
+	}
+
+	if owner, taken := repo.store.groupNamed(group.Name); taken && owner != id {
+		return models.Group{}, groupExists(group.Name)
+	}
+
+	group.ID = id
+	repo.store.saveGroup(group, stored.contacts, stored.groups)
+
+	return group, nil
+}
+
+// Delete removes the group and its memberships, including the ones in the groups
+// it was nested in.
+func (repo *groups) Delete(id uint) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if _, found := repo.store.groups[id]; !found {
+		return groupNotFound(id)
+	}
+
+	delete(repo.store.groups, id)
+
+	for _, stored := range repo.store.groups {
+		delete(stored.groups, id)
+	}
+
+	return nil
+}
+
+func (repo *groups) Get() ([]models.Group, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	groups := make([]models.Group, 0, len(repo.store.groups))
+	for _, stored := range repo.store.groups {
+		groups = append(groups, stored.Group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	return groups, nil
+}
+
+// AddMembers adds the contacts and subgroups to the group, members already in it
+// are left as they are. A subgroup that contains the group, at any depth, is
+// rejected because it would create a cycle.
+func (repo *groups) AddMembers(id uint, contactIDs []uint, groupIDs []uint) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if err := repo.store.checkMembers(id, contactIDs, groupIDs); err != nil {
+		return err
+	}
+
+	for _, groupID := range groupIDs {
+		if id == groupID {
+			return apperrors.Conflict(fmt.Sprintf("the group: %v can not contain itself", id), nil)
+		}
+
+		if repo.store.descendants(groupID)[id] {
+			return apperrors.Conflict(fmt.Sprintf(
+				"adding the group: %v to the group: %v would create a cycle, it already contains it", groupID, id), nil)
+		}
+	}
+
+	stored := repo.store.groups[id]
+
+	for _, contactID := range contactIDs {
+		stored.contacts[contactID] = true
+	}
+
+	for _, groupID := range groupIDs {
+		stored.groups[groupID] = true
+	}
+
+	return nil
+}
+
+// RemoveMembers removes the contacts and subgroups from the group, the ones that
+// are not members are ignored.
+func (repo *groups) RemoveMembers(id uint, contactIDs []uint, groupIDs []uint) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if err := repo.store.checkMembers(id, contactIDs, groupIDs); err != nil {
+		return err
+	}
+
+	stored := repo.store.groups[id]
+
+	for _, contactID := range contactIDs {
+		delete(stored.contacts, contactID)
+	}
+
+	for _, groupID := range groupIDs {
+		delete(stored.groups, groupID)
+	}
+
+	return nil
+}
+
+// Members returns every contact of the group and of the groups nested in it, at
+// any depth. A contact reached through several groups is returned once.
+func (repo *groups) Members(id uint) ([]models.Contact, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	if _, found := repo.store.groups[id]; !found {
+		return nil, groupNotFound(id)
+	}
+
+	groupIDs := repo.store.descendants(id)
+	groupIDs[id] = true
+
+	seen := map[uint]bool{}
+	contacts := []models.Contact{}
+
+	for groupID := range groupIDs {
+		for contactID := range repo.store.groups[groupID].contacts {
+			if seen[contactID] {
+				continue
+			}
+
+			seen[contactID] = true
+
+			if contact, active := repo.store.active(contactID); active {
+				contacts = append(contacts, contact)
+			}
+		}
+	}
+
+	sortByName(contacts)
+
+	return contacts, nil
+}
+
+// checkMembers checks that the group and the members being added or removed
+// exist.
+func (store *Store) checkMembers(id uint, contactIDs []uint, groupIDs []uint) error {
+	if _, found := store.groups[id]; !found {
+		return groupNotFound(id)
+	}
+
+	if !store.allContactsExist(contactIDs) {
+		return apperrors.Validation("some of the contacts do not exist", nil)
+	}
+
+	for _, groupID := range groupIDs {
+		if _, found := store.groups[groupID]; !found {
+			return apperrors.Validation("some of the groups do not exist", nil)
+		}
+	}
+
+	return nil
+}
+
+// descendants returns the ids of every group nested, at any depth, in the group.
+func (store *Store) descendants(id uint) map[uint]bool {
+	found := map[uint]bool{}
+	pending := []uint{id}
+
+	for len(pending) > 0 {
+		next := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for groupID := range store.groups[next].groups {
+			if !found[groupID] {
+				found[groupID] = true
+				pending = append(pending, groupID)
+			}
+		}
+	}
+
+	return found
+}
+
+// saveGroup stores the group, without its members, along with the ids of them.
+func (store *Store) saveGroup(group models.Group, contactIDs, groupIDs map[uint]bool) {
+	group.Contacts, group.Groups = nil, nil
+
+	store.groups[group.ID] = &groupRecord{Group: group, contacts: contactIDs, groups: groupIDs}
+}
+
+// groupNamed returns the id of the group with name.
+func (store *Store) groupNamed(name string) (uint, bool) {
+	for id, stored := range store.groups {
+		if stored.Name == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// sortByName orders contacts by name and then by id.
+func sortByName(contacts []models.Contact) {
+	sort.Slice(contacts, func(i, j int) bool {
+		if contacts[i].Name != contacts[j].Name {
+			return contacts[i].Name < contacts[j].Name
+		}
+
+		return contacts[i].ID < contacts[j].ID
+	})
+}
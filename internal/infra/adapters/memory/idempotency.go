@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type idempotency struct {
+	store *Store
+}
+
+func NewIdempotency(store *Store) repository.Idempotency {
+	return &idempotency{
+		store,
+	}
+}
+
+// Reserve saves request unless its key is already taken by a request that did
+// not expire at now and is not still processed past its lease. It reports if
+// the key was reserved and, when it was not, returns the request holding it.
+func (repo *idempotency) Reserve(request models.IdempotentRequest, now time.Time) (models.IdempotentRequest,
+	bool, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	stored, taken := repo.store.requests[request.Key]
+	if taken && stored.ExpiresAt.After(now) && (stored.Status != 0 || stored.LockedUntil.After(now)) {
+		return stored, false, nil
+	}
+
+	repo.store.requests[request.Key] = request
+
+	return request, true, nil
+}
+
+// Complete keeps the response of a reserved request.
+func (repo *idempotency) Complete(request models.IdempotentRequest) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if stored, found := repo.store.requests[request.Key]; found {
+		stored.Status, stored.Header, stored.Body = request.Status, request.Header, request.Body
+		repo.store.requests[request.Key] = stored
+	}
+
+	return nil
+}
+
+// Release frees the key of a request, so it can be sent again.
+func (repo *idempotency) Release(key string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	delete(repo.store.requests, key)
+
+	return nil
+}
+
+// PurgeExpired deletes the requests that expired at now and returns how many.
+func (repo *idempotency) PurgeExpired(now time.Time) (int64, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	var purged int64
+
+	for key, stored := range repo.store.requests {
+		if !stored.ExpiresAt.After(now) {
+			delete(repo.store.requests, key)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestContacts(t *testing.T) {
+	suite.Run(t, &repositorytest.ContactsSuite{New: newRepositories})
+}
+
+func TestGroups(t *testing.T) {
+	suite.Run(t, &repositorytest.GroupsSuite{New: newRepositories})
+}
+
+func TestTags(t *testing.T) {
+	suite.Run(t, &repositorytest.TagsSuite{New: newRepositories})
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, &repositorytest.AuditSuite{New: newRepositories})
+}
+
+func TestAddressBook(t *testing.T) {
+	suite.Run(t, &repositorytest.AddressBookSuite{New: newRepositories})
+}
+
+func TestIdempotency(t *testing.T) {
+	suite.Run(t, &repositorytest.IdempotencySuite{New: newRepositories})
+}
+
+func newRepositories() repositorytest.Repositories {
+	store := NewStore()
+
+	return repositorytest.Repositories{
+		Contacts:    NewContacts(store),
+		Tags:        NewTags(store),
+		Groups:      NewGroups(store),
+		Audit:       NewAudit(store),
+		AddressBook: NewAddressBook(store),
+		Idempotency: NewIdempotency(store),
+	}
+}
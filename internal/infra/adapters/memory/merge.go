@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Audited fields that tell, in the merge entries, which contacts were merged.
+const (
+	mergedContactsField = "merged_contacts"
+	mergedIntoField     = "merged_into"
+)
+
+// Merge writes the merged content of the survivor, moves the merged contacts to
+// the trash and gives their tags and groups to the survivor. Every contact must
+// still be at the version it was read at. The survivor gets a new version and
+// revision, and the merge is audited on behalf of origin for every contact.
+func (repo *contacts) Merge(origin models.Origin, merge models.Merge) (models.Contact, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	survivor := merge.Survivor
+
+	before, err := repo.store.current(survivor.ID, survivor.Version)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	ids := make([]uint, len(merge.Merged))
+	others := make(map[uint]models.Contact, len(merge.Merged))
+
+	for i, contact := range merge.Merged {
+		other, err := repo.store.current(contact.ID, contact.Version)
+		if err != nil {
+			return models.Contact{}, err
+		}
+
+		ids[i] = contact.ID
+		others[contact.ID] = other
+	}
+
+	if owner, taken := repo.store.phoneOwner(survivor.PhoneNumber); taken && owner != survivor.ID && !inIDs(owner, ids) {
+		return models.Contact{}, phoneExists(survivor.PhoneNumber)
+	}
+
+	now := time.Now()
+
+	for _, id := range ids {
+		repo.store.trash(id, now)
+		repo.store.repoint(id, survivor.ID)
+	}
+
+	repo.store.replace(&survivor, before)
+
+	merged, _ := repo.store.contact(survivor.ID)
+	repo.store.recordRevision(merged, now)
+
+	entry, err := auditEntry(origin, models.AuditMerge, &before, &merged)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	entry.Changes[mergedContactsField] = models.Change{After: ids}
+	entries := []models.AuditEntry{entry}
+
+	for _, id := range ids {
+		other := others[id]
+		trashed, _ := repo.store.contact(id)
+
+		if entry, err = auditEntry(origin, models.AuditMerge, &other, &trashed); err != nil {
+			return models.Contact{}, err
+		}
+
+		entry.Changes[mergedIntoField] = models.Change{After: merged.ID}
+		entries = append(entries, entry)
+	}
+
+	repo.store.recordEntries(now, entries...)
+
+	return merged, nil
+}
+
+// repoint moves the tags and group memberships of the contact from to the
+// contact to.
+func (store *Store) repoint(from, to uint) {
+	for tagID := range store.contactTags[from] {
+		store.link(to, tagID)
+	}
+
+	delete(store.contactTags, from)
+
+	for _, stored := range store.groups {
+		if stored.contacts[from] {
+			delete(stored.contacts, from)
+			stored.contacts[to] = true
+		}
+	}
+}
+
+func inIDs(id uint, ids []uint) bool {
+	for _, other := range ids {
+		if other == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// GetRevisions lists the revisions of a contact, the newest first. Contacts in
+// the trash keep theirs until they are purged.
+func (repo *contacts) GetRevisions(id uint, paginate models.Paginator) (*models.Paginator, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	if _, found := repo.store.contact(id); !found {
+		return nil, contactNotFound(id)
+	}
+
+	revisions := repo.store.revisionsOf(id)
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Version > revisions[j].Version })
+
+	from, to := pageBounds(paginate, len(revisions))
+
+	return offsetPage(paginate, int64(len(revisions)), revisions[from:to]), nil
+}
+
+// GetRevision returns the revision of the contact at version.
+func (repo *contacts) GetRevision(id uint, version uint) (models.Revision, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	for _, revision := range repo.store.revisionsOf(id) {
+		if revision.Version == version {
+			return revision, nil
+		}
+	}
+
+	return models.Revision{}, apperrors.NotFound(fmt.Sprintf("the contact: %v has no revision %v", id, version), nil)
+}
+
+// GetRevisionAt returns the last revision of the contact written at or before at.
+func (repo *contacts) GetRevisionAt(id uint, at time.Time) (models.Revision, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	var last *models.Revision
+
+	revisions := repo.store.revisionsOf(id)
+	for i, revision := range revisions {
+		if revision.CreatedAt.After(at) {
+			continue
+		}
+
+		if last == nil || revision.CreatedAt.After(last.CreatedAt) ||
+			(revision.CreatedAt.Equal(last.CreatedAt) && revision.Version > last.Version) {
+			last = &revisions[i]
+		}
+	}
+
+	if last == nil {
+		return models.Revision{}, apperrors.NotFound(
+			fmt.Sprintf("the contact: %v did not exist at %s", id, at.Format(time.RFC3339)), nil)
+	}
+
+	return *last, nil
+}
+
+// revisionsOf returns copies of the revisions of the contact, in the order they
+// were written.
+func (store *Store) revisionsOf(id uint) []models.Revision {
+	revisions := []models.Revision{}
+
+	for _, revision := range store.revisions {
+		if revision.ContactID == id {
+			revision.Contact = copyContact(revision.Contact)
+			revisions = append(revisions, revision)
+		}
+	}
+
+	return revisions
+}
@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/search"
+)
+
+// Search finds the contacts out of the trash matching query, the best ranked
+// first.
+func (repo *contacts) Search(paginate models.Paginator, query string) (*models.Paginator, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	matcher := search.NewQuery(query)
+	results := []models.SearchResult{}
+
+	for id := range repo.store.contacts {
+		contact, active := repo.store.active(id)
+		if !active {
+			continue
+		}
+
+		if result, found := matcher.Match(contact); found {
+			results = append(results, result)
+		}
+	}
+
+	search.Sort(results)
+
+	from, to := pageBounds(paginate, len(results))
+
+	return offsetPage(paginate, int64(len(results)), results[from:to]), nil
+}
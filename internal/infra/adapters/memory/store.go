@@ -0,0 +1,225 @@
+// Package memory implements the repositories over data held in memory, for
+// running the service locally and testing it without a database. Nothing is
+// kept once the process ends.
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// Store holds the data of every in-memory repository, so they see each other as
+// the tables of a database do. A single lock guards it: every call of a
+// repository is atomic and sees the changes made by the previous ones.
+type Store struct {
+	mu sync.RWMutex
+
+	lastIDs     map[string]uint
+	contacts    map[uint]*models.Contact
+	contactTags map[uint]map[uint]bool
+	tags        map[uint]*models.Tag
+	groups      map[uint]*groupRecord
+	revisions   []models.Revision
+	entries     []models.AuditEntry
+	resources   map[string]models.CardResource
+	tombstones  map[string]models.CardTombstone
+	requests    map[string]models.IdempotentRequest
+}
+
+// groupRecord is a stored group with the ids of its direct members.
+type groupRecord struct {
+	models.Group
+	contacts map[uint]bool
+	groups   map[uint]bool
+}
+
+func NewStore() *Store {
+	return &Store{
+		lastIDs:     map[string]uint{},
+		contacts:    map[uint]*models.Contact{},
+		contactTags: map[uint]map[uint]bool{},
+		tags:        map[uint]*models.Tag{},
+		groups:      map[uint]*groupRecord{},
+		resources:   map[string]models.CardResource{},
+		tombstones:  map[string]models.CardTombstone{},
+		requests:    map[string]models.IdempotentRequest{},
+	}
+}
+
+// nextID returns the next id of table, ids start at 1 and are never reused.
+func (store *Store) nextID(table string) uint {
+	store.lastIDs[table]++
+
+	return store.lastIDs[table]
+}
+
+// contact returns a copy of the stored contact, in the trash or not, with its
+// tags by name. found is false when there is no such contact.
+func (store *Store) contact(id uint) (models.Contact, bool) {
+	stored, found := store.contacts[id]
+	if !found {
+		return models.Contact{}, false
+	}
+
+	contact := copyContact(*stored)
+
+	for tagID := range store.contactTags[id] {
+		contact.Tags = append(contact.Tags, *store.tags[tagID])
+	}
+
+	sort.Slice(contact.Tags, func(i, j int) bool { return contact.Tags[i].Name < contact.Tags[j].Name })
+
+	return contact, true
+}
+
+// active returns the contact unless it does not exist or is in the trash.
+func (store *Store) active(id uint) (models.Contact, bool) {
+	contact, found := store.contact(id)
+	if !found || contact.DeletedAt.Valid {
+		return models.Contact{}, false
+	}
+
+	return contact, true
+}
+
+// save stores contact, with new ids for its phones, emails and addresses. Its
+// tags are kept apart and left as they are.
+func (store *Store) save(contact *models.Contact) {
+	for i := range contact.Phones {
+		contact.Phones[i].ID = store.nextID("phones")
+		contact.Phones[i].ContactID = contact.ID
+	}
+
+	for i := range contact.Emails {
+		contact.Emails[i].ID = store.nextID("emails")
+		contact.Emails[i].ContactID = contact.ID
+	}
+
+	for i := range contact.Addresses {
+		contact.Addresses[i].ID = store.nextID("addresses")
+		contact.Addresses[i].ContactID = contact.ID
+	}
+
+	stored := copyContact(*contact)
+	stored.Tags = nil
+	store.contacts[contact.ID] = &stored
+}
+
+// phoneOwner returns the id of the contact out of the trash with number as its
+// phone number.
+func (store *Store) phoneOwner(number string) (uint, bool) {
+	for id, contact := range store.contacts {
+		if !contact.DeletedAt.Valid && contact.PhoneNumber == number {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// trash moves the contact to the trash at now.
+func (store *Store) trash(id uint, now time.Time) {
+	store.contacts[id].DeletedAt = gorm.DeletedAt{Time: now, Valid: true}
+}
+
+// purge deletes the contact for good with its tags, memberships and resource.
+func (store *Store) purge(id uint) {
+	delete(store.contacts, id)
+	delete(store.contactTags, id)
+
+	for _, stored := range store.groups {
+		delete(stored.contacts, id)
+	}
+
+	for name, resource := range store.resources {
+		if resource.ContactID == id {
+			delete(store.resources, name)
+			store.tombstones[name] = models.CardTombstone{Name: name, ContactID: id}
+		}
+	}
+}
+
+// allContactsExist reports whether every id in ids, which must not have
+// repetitions, is a contact out of the trash.
+func (store *Store) allContactsExist(ids []uint) bool {
+	for _, id := range ids {
+		if _, found := store.active(id); !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// recordRevision keeps the state of contact right after a write.
+func (store *Store) recordRevision(contact models.Contact, now time.Time) {
+	store.revisions = append(store.revisions, models.Revision{
+		ID:        store.nextID("revisions"),
+		ContactID: contact.ID,
+		Version:   contact.Version,
+		Contact:   copyContact(contact),
+		CreatedAt: now,
+	})
+}
+
+// recordChange audits a change made to a contact, given its state before and
+// after the change. before is nil for a new contact and after for a purged one.
+func (store *Store) recordChange(origin models.Origin, action models.AuditAction, before, after *models.Contact,
+	now time.Time) error {
+	entry, err := auditEntry(origin, action, before, after)
+	if err != nil {
+		return err
+	}
+
+	store.recordEntries(now, entry)
+
+	return nil
+}
+
+func (store *Store) recordEntries(now time.Time, entries ...models.AuditEntry) {
+	for _, entry := range entries {
+		entry.ID = store.nextID("audit_entries")
+		entry.CreatedAt = now
+		store.entries = append(store.entries, entry)
+	}
+}
+
+// auditEntry builds the audit entry of a change, as recordChange records it.
+func auditEntry(origin models.Origin, action models.AuditAction, before, after *models.Contact) (models.AuditEntry,
+	error) {
+	changes, err := models.DiffContacts(before, after)
+	if err != nil {
+		return models.AuditEntry{}, apperrors.Internal(err)
+	}
+
+	entry := models.AuditEntry{
+		Action:    action,
+		Actor:     origin.Actor,
+		RequestID: origin.RequestID,
+		Changes:   changes,
+	}
+
+	if after != nil {
+		entry.ContactID = after.ID
+	} else {
+		entry.ContactID = before.ID
+	}
+
+	return entry, nil
+}
+
+// copyContact copies contact with lists of its own, empty lists are not nil as
+// they are not when read from a database.
+func copyContact(contact models.Contact) models.Contact {
+	contact.Phones = append([]models.Phone{}, contact.Phones...)
+	contact.Emails = append([]models.Email{}, contact.Emails...)
+	contact.Addresses = append([]models.Address{}, contact.Addresses...)
+	contact.Tags = append([]models.Tag{}, contact.Tags...)
+
+	return contact
+}
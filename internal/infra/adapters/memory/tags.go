@@ -0,0 +1,251 @@
+package memory
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+)
+
+type tags struct {
+	store *Store
+}
+
+func NewTags(store *Store) repository.Tags {
+	return &tags{
+		store,
+	}
+}
+
+func (repo *tags) Create(tag models.Tag) (models.Tag, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if _, taken := repo.store.tagNamed(tag.Name); taken {
+		return models.Tag{}, tagExists(tag.Name)
+	}
+
+	tag.ID = repo.store.nextID("tags")
+	repo.store.tags[tag.ID] = &tag
+
+	return tag, nil
+}
+
+func (repo *tags) GetByID(id uint) (models.Tag, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	tag, found := repo.store.tags[id]
+	if !found {
+		return models.Tag{}, tagNotFound(id)
+	}
+
+	return *tag, nil
+}
+
+// Update renames or recolors the tag, the contacts having it get a new version
+// as their representation changes. Changes made to contacts are audited on
+// behalf of origin.
+func (repo *tags) Update(origin models.Origin, id uint, tag models.Tag) (models.Tag, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if _, found := repo.store.tags[id]; !found {
+		return models.Tag{}, tagNotFound(id)
+	}
+
+	if owner, taken := repo.store.tagNamed(tag.Name); taken && owner != id {
+		return models.Tag{}, tagExists(tag.Name)
+	}
+
+	tag.ID = id
+
+	err := repo.store.retag(origin, repo.store.tagged(id), func() {
+		repo.store.tags[id] = &tag
+	})
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// Delete removes the tag from every contact and then the tag itself.
+func (repo *tags) Delete(origin models.Origin, id uint) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if _, found := repo.store.tags[id]; !found {
+		return tagNotFound(id)
+	}
+
+	tagged := repo.store.tagged(id)
+
+	return repo.store.retag(origin, tagged, func() {
+		for _, contactID := range tagged {
+			delete(repo.store.contactTags[contactID], id)
+		}
+
+		delete(repo.store.tags, id)
+	})
+}
+
+func (repo *tags) Get() ([]models.Tag, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	tags := make([]models.Tag, 0, len(repo.store.tags))
+	for _, tag := range repo.store.tags {
+		tags = append(tags, *tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	return tags, nil
+}
+
+// Assign adds every tag to every contact at once, pairs that already exist are
+// left as they are. Unknown tags or contacts fail the whole assignment.
+func (repo *tags) Assign(origin models.Origin, contactIDs []uint, tags []string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	tagIDs, err := repo.store.findAssignment(contactIDs, tags)
+	if err != nil {
+		return err
+	}
+
+	return repo.store.retag(origin, contactIDs, func() {
+		for _, contactID := range contactIDs {
+			for _, tagID := range tagIDs {
+				repo.store.link(contactID, tagID)
+			}
+		}
+	})
+}
+
+// Unassign removes every tag from every contact, pairs that do not exist are
+// ignored.
+func (repo *tags) Unassign(origin models.Origin, contactIDs []uint, tags []string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	tagIDs, err := repo.store.findAssignment(contactIDs, tags)
+	if err != nil {
+		return err
+	}
+
+	return repo.store.retag(origin, contactIDs, func() {
+		for _, contactID := range contactIDs {
+			for _, tagID := range tagIDs {
+				delete(repo.store.contactTags[contactID], tagID)
+			}
+		}
+	})
+}
+
+// findAssignment checks that every contact and tag of an assignment exists and
+// returns the ids of the tags.
+func (store *Store) findAssignment(contactIDs []uint, names []string) ([]uint, error) {
+	var tagIDs []uint
+	var missing []string
+
+	for _, name := range names {
+		if id, found := store.tagNamed(name); found {
+			tagIDs = append(tagIDs, id)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, apperrors.Validation(
+			fmt.Sprintf("the tags: %s do not exist", strings.Join(missing, ", ")), nil)
+	}
+
+	if !store.allContactsExist(contactIDs) {
+		return nil, apperrors.Validation("some of the contacts do not exist", nil)
+	}
+
+	return tagIDs, nil
+}
+
+// tagNamed returns the id of the tag with name.
+func (store *Store) tagNamed(name string) (uint, bool) {
+	for id, tag := range store.tags {
+		if tag.Name == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// tagged returns the ids of the contacts having the tag, in the trash or not.
+func (store *Store) tagged(tagID uint) []uint {
+	var ids []uint
+
+	for contactID, tagIDs := range store.contactTags {
+		if tagIDs[tagID] {
+			ids = append(ids, contactID)
+		}
+	}
+
+	return ids
+}
+
+// link gives the tag to the contact.
+func (store *Store) link(contactID, tagID uint) {
+	if store.contactTags[contactID] == nil {
+		store.contactTags[contactID] = map[uint]bool{}
+	}
+
+	store.contactTags[contactID][tagID] = true
+}
+
+// retag runs change, which changes the tags of the contacts of ids, and gives a
+// new version to the ones out of the trash whose tags did change. Each of them
+// keeps a revision and is audited on behalf of origin.
+func (store *Store) retag(origin models.Origin, ids []uint, change func()) error {
+	before := make(map[uint]models.Contact, len(ids))
+	for _, id := range ids {
+		if contact, found := store.active(id); found {
+			before[id] = contact
+		}
+	}
+
+	change()
+
+	retagged := make([]uint, 0, len(before))
+	for id := range before {
+		retagged = append(retagged, id)
+	}
+
+	sort.Slice(retagged, func(i, j int) bool { return retagged[i] < retagged[j] })
+
+	now := time.Now()
+
+	for _, id := range retagged {
+		previous := before[id]
+
+		after, _ := store.contact(id)
+		if reflect.DeepEqual(previous.Tags, after.Tags) {
+			continue
+		}
+
+		store.contacts[id].Version++
+		after.Version++
+		store.recordRevision(after, now)
+
+		if err := store.recordChange(origin, models.AuditUpdate, &previous, &after, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package pg
+
+import (
+	"os"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestContacts(t *testing.T) {
+	suite.Run(t, &repositorytest.ContactsSuite{New: newRepositories(t)})
+}
+
+func TestGroups(t *testing.T) {
+	suite.Run(t, &repositorytest.GroupsSuite{New: newRepositories(t)})
+}
+
+func TestTags(t *testing.T) {
+	suite.Run(t, &repositorytest.TagsSuite{New: newRepositories(t)})
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, &repositorytest.AuditSuite{New: newRepositories(t)})
+}
+
+func TestAddressBook(t *testing.T) {
+	suite.Run(t, &repositorytest.AddressBookSuite{New: newRepositories(t)})
+}
+
+func TestIdempotency(t *testing.T) {
+	suite.Run(t, &repositorytest.IdempotencySuite{New: newRepositories(t)})
+}
+
+// newRepositories runs the repositories on the Postgres database of
+// TEST_DATABASE_URL, which is migrated once and emptied before every test.
+func newRepositories(t *testing.T) func() repositorytest.Repositories {
+	db := openTestDatabase(t)
+
+	migrator, err := NewMigrator(db, newPhoneNormalizer(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() repositorytest.Repositories {
+		err := db.Exec(`TRUNCATE contacts, phones, emails, addresses, tags, contact_tags, groups, group_subgroups,
+			group_contacts, audit_entries, revisions, card_resources, idempotent_requests RESTART IDENTITY CASCADE`).Error
+		if err == nil {
+			err = db.Exec("UPDATE sync_counters SET token = 0").Error
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return repositorytest.Repositories{
+			Contacts:    repository.NewContacts(db),
+			Tags:        repository.NewTags(db),
+			Groups:      repository.NewGroups(db),
+			Audit:       repository.NewAudit(db),
+			AddressBook: repository.NewAddressBook(db),
+			Idempotency: repository.NewIdempotency(db),
+		}
+	}
+}
+
+// openTestDatabase connects to the Postgres database of TEST_DATABASE_URL. The
+// test is skipped when the variable is not set.
+func openTestDatabase(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
@@ -0,0 +1,368 @@
+package pg
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"gorm.io/gorm"
+)
+
+// migrationFiles are the SQL migrations, named <version>_<name>.up.sql and
+// <version>_<name>.down.sql. Versions are applied in ascending order. Each file
+// runs in a transaction, so it can only hold statements Postgres runs inside
+// one: no CREATE INDEX CONCURRENTLY, VACUUM or ALTER TYPE ... ADD VALUE.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// dataMigrations backfill data that needs the Go code of the models, right after
+// the SQL migration adding the columns they fill. Reverting them keeps the data,
+// it is valid at the previous version too. Phone numbers are read with phones.
+func dataMigrations(phones dto.PhoneNormalizer) []migration {
+	return []migration{
+		{Version: 5, Name: "split_names", up: splitNames, down: keepData},
+		{Version: 12, Name: "record_revisions", up: recordRevisions, down: keepData},
+		{Version: 19, Name: "normalize_phones", up: normalizePhones(phones), down: keepData},
+	}
+}
+
+// migrationsLockKey identifies the advisory lock held while migrating, so that
+// replicas starting together apply every migration once. It spells "contacts".
+const migrationsLockKey int64 = 0x636f6e7461637473
+
+// migration moves the schema from the previous version to Version with up, and
+// back with down.
+type migration struct {
+	Version uint
+	Name    string
+	up      func(tx *gorm.DB) error
+	down    func(tx *gorm.DB) error
+}
+
+// schemaMigration is a migration applied to the database.
+type schemaMigration struct {
+	Version   uint `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name text NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+// MigrationStatus tells whether a migration was applied, AppliedAt is nil when
+// it is pending. Unknown is set for applied migrations this build does not have.
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time
+	Unknown   bool
+}
+
+// Migrator applies and reverts the migrations of the schema, each one in its own
+// transaction along with its record in schema_migrations.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []migration
+}
+
+// NewMigrator creates the migrator of db, phones reads the stored phone numbers
+// written before they were normalized.
+func NewMigrator(db *gorm.DB, phones dto.PhoneNormalizer) (*Migrator, error) {
+	migrations, err := loadMigrations(phones)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db,
+		migrations,
+	}, nil
+}
+
+// Up applies the pending migrations and returns them.
+func (migrator *Migrator) Up() ([]MigrationStatus, error) {
+	var applied []MigrationStatus
+
+	err := migrator.locked(func(conn *gorm.DB) error {
+		done, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, next := range migrator.migrations {
+			if _, ok := done[next.Version]; ok {
+				continue
+			}
+
+			record := schemaMigration{Version: next.Version, Name: next.Name, AppliedAt: time.Now()}
+
+			err = conn.Transaction(func(tx *gorm.DB) error {
+				if err := next.up(tx); err != nil {
+					return err
+				}
+
+				return tx.Create(&record).Error
+			})
+			if err != nil {
+				return fmt.Errorf("applying migration %s: %w", next, err)
+			}
+
+			applied = append(applied, MigrationStatus{Version: next.Version, Name: next.Name,
+				AppliedAt: &record.AppliedAt})
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations and returns them.
+func (migrator *Migrator) Down(steps int) ([]MigrationStatus, error) {
+	var reverted []MigrationStatus
+
+	err := migrator.locked(func(conn *gorm.DB) error {
+		done, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]uint, 0, len(done))
+		for version := range done {
+			versions = append(versions, version)
+		}
+
+		sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+
+		for i := 0; i < steps && i < len(versions); i++ {
+			last, ok := migrator.find(versions[i])
+			if !ok {
+				return fmt.Errorf("the applied migration %06d is unknown to this build", versions[i])
+			}
+
+			if last.down == nil {
+				return fmt.Errorf("the migration %s has no down file", last)
+			}
+
+			err = conn.Transaction(func(tx *gorm.DB) error {
+				if err := last.down(tx); err != nil {
+					return err
+				}
+
+				return tx.Delete(&schemaMigration{Version: last.Version}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("reverting migration %s: %w", last, err)
+			}
+
+			reverted = append(reverted, MigrationStatus{Version: last.Version, Name: last.Name})
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists the migrations of this build and the applied ones it does not
+// know, by version.
+func (migrator *Migrator) Status() ([]MigrationStatus, error) {
+	done := map[uint]schemaMigration{}
+
+	if migrator.db.Migrator().HasTable(&schemaMigration{}) {
+		var err error
+		if done, err = appliedMigrations(migrator.db); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrator.migrations))
+	for _, known := range migrator.migrations {
+		status := MigrationStatus{Version: known.Version, Name: known.Name}
+
+		if record, ok := done[known.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(done, known.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for _, record := range done {
+		record := record
+		statuses = append(statuses, MigrationStatus{Version: record.Version, Name: record.Name,
+			AppliedAt: &record.AppliedAt, Unknown: true})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// locked runs migrate on a single connection holding the migrations lock, once
+// schema_migrations exists. Failing to release the lock fails the migration, the
+// connection going back to the pool would keep it.
+func (migrator *Migrator) locked(migrate func(conn *gorm.DB) error) error {
+	return migrator.db.Connection(func(conn *gorm.DB) (err error) {
+		if err = conn.Exec("SELECT pg_advisory_lock(?)", migrationsLockKey).Error; err != nil {
+			return err
+		}
+
+		defer func() {
+			if unlockErr := unlockMigrations(conn); unlockErr != nil && err == nil {
+				err = unlockErr
+			}
+		}()
+
+		if err = conn.Exec(createSchemaMigrations).Error; err != nil {
+			return err
+		}
+
+		return migrate(conn)
+	})
+}
+
+// unlockMigrations releases the migrations lock held by conn.
+func unlockMigrations(conn *gorm.DB) error {
+	var unlocked bool
+
+	if err := conn.Raw("SELECT pg_advisory_unlock(?)", migrationsLockKey).Scan(&unlocked).Error; err != nil {
+		return fmt.Errorf("releasing the migrations lock: %w", err)
+	}
+
+	if !unlocked {
+		return errors.New("releasing the migrations lock: it was not held")
+	}
+
+	return nil
+}
+
+func (migrator *Migrator) find(version uint) (migration, bool) {
+	for _, known := range migrator.migrations {
+		if known.Version == version {
+			return known, true
+		}
+	}
+
+	return migration{}, false
+}
+
+func (known migration) String() string {
+	return fmt.Sprintf("%06d_%s", known.Version, known.Name)
+}
+
+// appliedMigrations returns the applied migrations by version.
+func appliedMigrations(db *gorm.DB) (map[uint]schemaMigration, error) {
+	var records []schemaMigration
+
+	if err := db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	done := make(map[uint]schemaMigration, len(records))
+	for _, record := range records {
+		done[record.Version] = record
+	}
+
+	return done, nil
+}
+
+// loadMigrations joins the SQL migrations and the data migrations, sorted by
+// version. Every SQL migration needs an up file, and versions can not repeat.
+func loadMigrations(phones dto.PhoneNormalizer) ([]migration, error) {
+	byVersion := map[uint]*migration{}
+
+	err := fs.WalkDir(migrationFiles, "migrations", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		parts := migrationFileName.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			return fmt.Errorf("the migration file %s is not named <version>_<name>.<up|down>.sql", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil || version == 0 {
+			return fmt.Errorf("the migration file %s has an invalid version", entry.Name())
+		}
+
+		content, err := migrationFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		found, ok := byVersion[uint(version)]
+		if !ok {
+			found = &migration{Version: uint(version), Name: parts[2]}
+			byVersion[found.Version] = found
+		}
+
+		if found.Name != parts[2] {
+			return fmt.Errorf("the migrations %s and %s share their version", found, entry.Name())
+		}
+
+		if parts[3] == "up" {
+			found.up = execSQL(string(content))
+		} else {
+			found.down = execSQL(string(content))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range dataMigrations(phones) {
+		if found, ok := byVersion[data.Version]; ok {
+			return nil, fmt.Errorf("the migrations %s and %s share their version", found, data)
+		}
+
+		data := data
+		byVersion[data.Version] = &data
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, found := range byVersion {
+		if found.up == nil {
+			return nil, fmt.Errorf("the migration %s has no up file", found)
+		}
+
+		migrations = append(migrations, *found)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+func keepData(*gorm.DB) error {
+	return nil
+}
+
+// execSQL runs the statements of a migration file. They go straight to the
+// connection, so gorm does not take the ? and @ they may contain for parameters.
+func execSQL(statements string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		_, err := tx.Statement.ConnPool.ExecContext(tx.Statement.Context, statements)
+
+		return err
+	}
+}
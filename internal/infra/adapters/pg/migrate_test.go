@@ -0,0 +1,124 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// baselineContact is the contact AutoMigrate created tables for before
+// migrations existed.
+type baselineContact struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	Name        string `gorm:"not null"`
+	PhoneNumber string `gorm:"unique;not null"`
+}
+
+func (baselineContact) TableName() string {
+	return "contacts"
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations(newPhoneNormalizer(t))
+
+	assert.NoError(t, err)
+
+	names := make([]string, len(migrations))
+	for i, migration := range migrations {
+		names[i] = migration.String()
+
+		assert.NotNil(t, migration.up)
+		assert.NotNil(t, migration.down)
+	}
+
+	assert.Equal(t, []string{
+		"000001_create_contacts",
+		"000002_add_contact_versions",
+		"000003_create_contact_details",
+		"000004_add_name_parts",
+		"000005_split_names",
+		"000006_add_phone_formats",
+		"000007_create_tags",
+		"000008_create_groups",
+		"000009_add_trash",
+		"000010_create_audit_entries",
+		"000011_create_revisions",
+		"000012_record_revisions",
+		"000013_add_notes_and_photo",
+		"000014_create_card_resources",
+		"000015_create_idempotent_requests",
+		"000016_create_search",
+		"000017_add_company",
+		"000018_add_sync_counter",
+		"000019_normalize_phones",
+		"000020_add_idempotency_leases",
+		"000021_create_card_tombstones",
+	}, names)
+}
+
+// TestMigrator_WhenDatabaseIsBaseline migrates a database AutoMigrate created for
+// the baseline contacts up to the last version and back.
+func TestMigrator_WhenDatabaseIsBaseline(t *testing.T) {
+	db := openTestDatabase(t)
+
+	migrator, err := NewMigrator(db, newPhoneNormalizer(t))
+	require.NoError(t, err)
+
+	_, err = migrator.Down(len(migrator.migrations))
+	require.NoError(t, err)
+	require.NoError(t, db.Migrator().DropTable("schema_migrations"))
+	require.NoError(t, db.AutoMigrate(&baselineContact{}))
+	require.NoError(t, db.Create(&baselineContact{Name: "Ana Pérez", PhoneNumber: "+573001111111"}).Error)
+	require.NoError(t, db.Create(&baselineContact{Name: "Juan", PhoneNumber: "300 222 2222"}).Error)
+
+	applied, err := migrator.Up()
+
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.migrations))
+	assert.False(t, db.Migrator().HasConstraint("contacts", "uni_contacts_phone_number"))
+
+	var contact models.Contact
+	require.NoError(t, db.Unscoped().Order("id").First(&contact).Error)
+
+	assert.Equal(t, "Ana", contact.GivenName)
+	assert.Equal(t, "Pérez", contact.FamilyName)
+	assert.Equal(t, uint(2), contact.Version)
+
+	var revision models.Revision
+	require.NoError(t, db.Where("contact_id = ? AND version = ?", contact.ID, 2).Take(&revision).Error)
+
+	assert.Equal(t, "Pérez", revision.Contact.FamilyName)
+
+	var normalized models.Contact
+	require.NoError(t, db.Where("name = ?", "Juan").Take(&normalized).Error)
+
+	assert.Equal(t, "+573002222222", normalized.PhoneNumber)
+	assert.Equal(t, "300 222 2222", normalized.PhoneDisplay)
+	assert.Equal(t, "CO", normalized.PhoneRegion)
+
+	reverted, err := migrator.Down(len(migrator.migrations) - 1)
+
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(migrator.migrations)-1)
+	assert.True(t, db.Migrator().HasConstraint("contacts", "uni_contacts_phone_number"))
+
+	columns, err := db.Migrator().ColumnTypes("contacts")
+	require.NoError(t, err)
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name()
+	}
+
+	assert.ElementsMatch(t, []string{"id", "name", "phone_number"}, names)
+
+	var baseline []baselineContact
+	require.NoError(t, db.Order("id").Find(&baseline).Error)
+
+	assert.Equal(t, []baselineContact{
+		{ID: contact.ID, Name: "Ana Pérez", PhoneNumber: "+573001111111"},
+		{ID: normalized.ID, Name: "Juan", PhoneNumber: "+573002222222"},
+	}, baseline)
+}
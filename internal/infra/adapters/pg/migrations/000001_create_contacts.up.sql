@@ -0,0 +1,11 @@
+-- The contacts table as AutoMigrate created it on boot, before migrations. Later
+-- changes of the schema are migrations of their own, guarded so the databases
+-- AutoMigrate had already changed are adopted as they are.
+
+CREATE TABLE IF NOT EXISTS contacts (
+	id bigserial,
+	name text NOT NULL,
+	phone_number text NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT uni_contacts_phone_number UNIQUE (phone_number)
+);
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS phones (
+	id bigserial,
+	contact_id bigint NOT NULL,
+	label text NOT NULL,
+	number text NOT NULL,
+	"primary" boolean NOT NULL DEFAULT false,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_contacts_phones FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_phones_contact_id ON phones (contact_id);
+
+CREATE TABLE IF NOT EXISTS emails (
+	id bigserial,
+	contact_id bigint NOT NULL,
+	label text NOT NULL,
+	address text NOT NULL,
+	"primary" boolean NOT NULL DEFAULT false,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_contacts_emails FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_emails_contact_id ON emails (contact_id);
+
+CREATE TABLE IF NOT EXISTS addresses (
+	id bigserial,
+	contact_id bigint NOT NULL,
+	label text NOT NULL,
+	street text,
+	city text,
+	region text,
+	postal_code text,
+	country text,
+	"primary" boolean NOT NULL DEFAULT false,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_contacts_addresses FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_addresses_contact_id ON addresses (contact_id);
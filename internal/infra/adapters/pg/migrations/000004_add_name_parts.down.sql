@@ -0,0 +1,5 @@
+ALTER TABLE contacts DROP COLUMN IF EXISTS nickname;
+ALTER TABLE contacts DROP COLUMN IF EXISTS name_suffix;
+ALTER TABLE contacts DROP COLUMN IF EXISTS family_name;
+ALTER TABLE contacts DROP COLUMN IF EXISTS given_name;
+ALTER TABLE contacts DROP COLUMN IF EXISTS name_prefix;
@@ -0,0 +1,7 @@
+-- The parts start empty, the split_names migration fills them from the name.
+
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS name_prefix text NOT NULL DEFAULT '';
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS given_name text NOT NULL DEFAULT '';
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS family_name text NOT NULL DEFAULT '';
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS name_suffix text NOT NULL DEFAULT '';
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS nickname text NOT NULL DEFAULT '';
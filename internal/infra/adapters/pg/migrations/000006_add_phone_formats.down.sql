@@ -0,0 +1,7 @@
+ALTER TABLE phones DROP COLUMN IF EXISTS type;
+ALTER TABLE phones DROP COLUMN IF EXISTS region;
+ALTER TABLE phones DROP COLUMN IF EXISTS display;
+
+ALTER TABLE contacts DROP COLUMN IF EXISTS phone_type;
+ALTER TABLE contacts DROP COLUMN IF EXISTS phone_region;
+ALTER TABLE contacts DROP COLUMN IF EXISTS phone_display;
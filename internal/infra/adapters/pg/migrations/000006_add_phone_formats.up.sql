@@ -0,0 +1,7 @@
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS phone_display text NOT NULL DEFAULT '';
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS phone_region text NOT NULL DEFAULT '';
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS phone_type text NOT NULL DEFAULT '';
+
+ALTER TABLE phones ADD COLUMN IF NOT EXISTS display text NOT NULL DEFAULT '';
+ALTER TABLE phones ADD COLUMN IF NOT EXISTS region text NOT NULL DEFAULT '';
+ALTER TABLE phones ADD COLUMN IF NOT EXISTS type text NOT NULL DEFAULT '';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS tags (
+	id bigserial,
+	name text NOT NULL,
+	color text NOT NULL DEFAULT '',
+	PRIMARY KEY (id),
+	CONSTRAINT uni_tags_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS contact_tags (
+	contact_id bigint,
+	tag_id bigint,
+	PRIMARY KEY (contact_id, tag_id),
+	CONSTRAINT fk_contact_tags_contact FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE,
+	CONSTRAINT fk_contact_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS group_contacts;
+DROP TABLE IF EXISTS group_subgroups;
+DROP TABLE IF EXISTS groups;
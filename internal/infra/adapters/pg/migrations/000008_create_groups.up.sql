@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS groups (
+	id bigserial,
+	name text NOT NULL,
+	description text NOT NULL DEFAULT '',
+	PRIMARY KEY (id),
+	CONSTRAINT uni_groups_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS group_subgroups (
+	group_id bigint,
+	member_group_id bigint,
+	PRIMARY KEY (group_id, member_group_id),
+	CONSTRAINT fk_group_subgroups_group FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE,
+	CONSTRAINT fk_group_subgroups_groups FOREIGN KEY (member_group_id) REFERENCES groups (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS group_contacts (
+	group_id bigint,
+	contact_id bigint,
+	PRIMARY KEY (group_id, contact_id),
+	CONSTRAINT fk_group_contacts_group FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE,
+	CONSTRAINT fk_group_contacts_contact FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE
+);
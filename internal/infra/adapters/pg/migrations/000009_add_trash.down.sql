@@ -0,0 +1,13 @@
+-- The contacts in the trash are purged, without the trash they would come back
+-- and could take the phone number of another contact.
+
+DELETE FROM contacts WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_contacts_phone_number;
+
+ALTER TABLE contacts DROP CONSTRAINT IF EXISTS uni_contacts_phone_number;
+ALTER TABLE contacts ADD CONSTRAINT uni_contacts_phone_number UNIQUE (phone_number);
+
+DROP INDEX IF EXISTS idx_contacts_deleted_at;
+
+ALTER TABLE contacts DROP COLUMN IF EXISTS deleted_at;
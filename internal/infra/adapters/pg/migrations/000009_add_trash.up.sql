@@ -0,0 +1,10 @@
+-- A phone number only has to be unique among the contacts out of the trash, the
+-- unique constraint of the baseline gives way to a partial index.
+
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_contacts_deleted_at ON contacts (deleted_at);
+
+ALTER TABLE contacts DROP CONSTRAINT IF EXISTS uni_contacts_phone_number;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contacts_phone_number ON contacts (phone_number) WHERE deleted_at IS NULL;
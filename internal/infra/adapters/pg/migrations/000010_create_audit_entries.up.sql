@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS audit_entries (
+	id bigserial,
+	contact_id bigint NOT NULL,
+	action text NOT NULL,
+	actor text NOT NULL,
+	request_id text NOT NULL DEFAULT '',
+	changes jsonb NOT NULL,
+	created_at timestamptz NOT NULL,
+	PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_created_at ON audit_entries (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_actor ON audit_entries (actor);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_contact_id ON audit_entries (contact_id);
@@ -0,0 +1,14 @@
+-- The revisions start empty, the record_revisions migration keeps the current
+-- state of every contact.
+
+CREATE TABLE IF NOT EXISTS revisions (
+	id bigserial,
+	contact_id bigint NOT NULL,
+	version bigint NOT NULL,
+	contact jsonb NOT NULL,
+	created_at timestamptz NOT NULL,
+	PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_revisions_created_at ON revisions (created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_revisions_contact_version ON revisions (contact_id, version);
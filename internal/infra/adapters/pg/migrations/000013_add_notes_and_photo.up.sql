@@ -0,0 +1,2 @@
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS notes text NOT NULL DEFAULT '';
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS photo text NOT NULL DEFAULT '';
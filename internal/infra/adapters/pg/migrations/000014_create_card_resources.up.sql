@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS card_resources (
+	name text,
+	contact_id bigint NOT NULL,
+	uid text NOT NULL DEFAULT '',
+	PRIMARY KEY (name),
+	CONSTRAINT fk_card_resources_contact FOREIGN KEY (contact_id) REFERENCES contacts (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_card_resources_contact_id ON card_resources (contact_id);
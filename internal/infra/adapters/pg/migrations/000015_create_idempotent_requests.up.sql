@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS idempotent_requests (
+	key text,
+	fingerprint text NOT NULL,
+	status bigint NOT NULL DEFAULT 0,
+	header jsonb,
+	body bytea,
+	expires_at timestamptz NOT NULL,
+	PRIMARY KEY (key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotent_requests_expires_at ON idempotent_requests (expires_at);
@@ -0,0 +1,13 @@
+-- The extensions are left installed, other schemas of the database may use them.
+
+DROP INDEX IF EXISTS idx_emails_address_trgm;
+DROP INDEX IF EXISTS idx_emails_search_vector;
+DROP INDEX IF EXISTS idx_contacts_notes_trgm;
+DROP INDEX IF EXISTS idx_contacts_name_trgm;
+DROP INDEX IF EXISTS idx_contacts_search_vector;
+
+ALTER TABLE emails DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE contacts DROP COLUMN IF EXISTS search_vector;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS contacts_search;
+DROP FUNCTION IF EXISTS search_fold(text);
@@ -0,0 +1,36 @@
+-- Full-text and fuzzy search of contacts. search_fold lowercases and strips the
+-- accents of a text; unaccent is only stable, so it is wrapped in an immutable
+-- function that indexes can use. The contacts_search configuration matches
+-- words regardless of their accents. The tsvector columns are generated, so
+-- every write keeps them up to date; email addresses are indexed whole and
+-- split in parts.
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE OR REPLACE FUNCTION search_fold(text) RETURNS text
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, lower($1)) $$;
+
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'contacts_search') THEN
+		CREATE TEXT SEARCH CONFIGURATION contacts_search (COPY = simple);
+		ALTER TEXT SEARCH CONFIGURATION contacts_search
+			ALTER MAPPING FOR hword, hword_part, word WITH unaccent, simple;
+	END IF;
+END $$;
+
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('contacts_search', name || ' ' || nickname), 'A') ||
+	setweight(to_tsvector('contacts_search', notes), 'C')) STORED;
+
+ALTER TABLE emails ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+	to_tsvector('contacts_search', address || ' ' || translate(address, '@.-_+', '     '))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_contacts_search_vector ON contacts USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS idx_contacts_name_trgm ON contacts
+USING gin (search_fold(name || ' ' || nickname) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_contacts_notes_trgm ON contacts USING gin (search_fold(notes) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_emails_search_vector ON emails USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS idx_emails_address_trgm ON emails USING gin (search_fold(address) gin_trgm_ops);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_contacts_company_trgm;
+
+ALTER TABLE contacts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE contacts ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('contacts_search', name || ' ' || nickname), 'A') ||
+	setweight(to_tsvector('contacts_search', notes), 'C')) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_contacts_search_vector ON contacts USING gin (search_vector);
+
+ALTER TABLE contacts DROP COLUMN IF EXISTS company;
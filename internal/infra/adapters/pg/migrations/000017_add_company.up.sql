@@ -0,0 +1,14 @@
+-- The search covers the company too. Generated columns can not be altered, so
+-- search_vector is built again with the company weighing between the names and
+-- the notes.
+
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS company text NOT NULL DEFAULT '';
+
+ALTER TABLE contacts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE contacts ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+	setweight(to_tsvector('contacts_search', name || ' ' || nickname), 'A') ||
+	setweight(to_tsvector('contacts_search', company), 'B') ||
+	setweight(to_tsvector('contacts_search', notes), 'C')) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_contacts_search_vector ON contacts USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS idx_contacts_company_trgm ON contacts USING gin (search_fold(company) gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_audit_entries_sync_token;
+
+ALTER TABLE audit_entries DROP COLUMN IF EXISTS sync_token;
+
+DROP TABLE IF EXISTS sync_counters;
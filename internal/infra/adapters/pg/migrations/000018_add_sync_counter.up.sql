@@ -0,0 +1,20 @@
+-- The sync tokens of the address book were the ids of the audit entries, which
+-- are given when the entries are written and not when they are committed. The
+-- sync counter hands them out in commit order from now on, going on from the
+-- last id so the tokens clients hold stay valid.
+
+CREATE TABLE IF NOT EXISTS sync_counters (
+	id bigint,
+	token bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY (id)
+);
+
+ALTER TABLE audit_entries ADD COLUMN IF NOT EXISTS sync_token bigint NOT NULL DEFAULT 0;
+
+UPDATE audit_entries SET sync_token = id WHERE sync_token = 0;
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_sync_token ON audit_entries (sync_token);
+
+INSERT INTO sync_counters (id, token)
+SELECT 1, COALESCE(MAX(id), 0) FROM audit_entries
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1 @@
+ALTER TABLE idempotent_requests DROP COLUMN IF EXISTS locked_until;
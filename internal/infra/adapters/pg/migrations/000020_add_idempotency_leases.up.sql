@@ -0,0 +1,6 @@
+-- Requests being processed hold their key until locked_until. The ones stored
+-- before are leased until now, so those left behind can be taken over.
+
+ALTER TABLE idempotent_requests ADD COLUMN IF NOT EXISTS locked_until timestamptz NOT NULL DEFAULT now();
+
+ALTER TABLE idempotent_requests ALTER COLUMN locked_until DROP DEFAULT;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS card_tombstones (
+	name text,
+	contact_id bigint NOT NULL,
+	PRIMARY KEY (name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_tombstones_contact_id ON card_tombstones (contact_id);
@@ -0,0 +1,43 @@
+package pg
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+const splitNamesBatchSize = 500
+
+// splitNames fills the name components of the contacts saved before they
+// existed by splitting their name. The display name is kept as it was, and the
+// version is bumped because the representation of the contact changed. It runs
+// before the trash exists, so the queries are unscoped.
+func splitNames(db *gorm.DB) error {
+	var contacts []models.Contact
+
+	return db.
+		Unscoped().
+		Select("id", "name").
+		Where("given_name = '' AND family_name = '' AND nickname = '' AND name <> ''").
+		FindInBatches(&contacts, splitNamesBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, contact := range contacts {
+				name := models.SplitName(contact.Name)
+
+				err := tx.
+					Unscoped().
+					Model(&models.Contact{}).
+					Where("id = ?", contact.ID).
+					Updates(map[string]interface{}{
+						"name_prefix": name.Prefix,
+						"given_name":  name.GivenName,
+						"family_name": name.FamilyName,
+						"name_suffix": name.Suffix,
+						"version":     gorm.Expr("version + 1"),
+					}).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}).Error
+}
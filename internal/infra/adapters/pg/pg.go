@@ -5,7 +5,7 @@ import (
 	"sync"
 
 	"github.com/AjxGnx/contacts-go/config"
-	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
 	"github.com/labstack/gommon/log"
 	_ "github.com/lib/pq"
 	"gorm.io/driver/postgres"
@@ -25,7 +25,34 @@ func ConnInstance() *gorm.DB {
 	return instance
 }
 
+// getConnection opens the connection and, unless it is disabled, applies the
+// pending migrations.
 func getConnection() *gorm.DB {
+	db := Open()
+
+	if !config.Environments().MigrateOnBoot {
+		return db
+	}
+
+	phones, err := dto.NewPhoneNormalizer(config.Environments().PhoneDefaultRegion)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	migrator, err := NewMigrator(db, phones)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err = migrator.Up(); err != nil {
+		log.Fatal(err)
+	}
+
+	return db
+}
+
+// Open connects to the database without migrating it.
+func Open() *gorm.DB {
 	connString := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%v sslmode=disable",
 		config.Environments().DBHost,
 		config.Environments().DBUser,
@@ -33,14 +60,10 @@ func getConnection() *gorm.DB {
 		config.Environments().DBName,
 		config.Environments().DBPort)
 
-	db, err := gorm.Open(postgres.Open(connString), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(connString), &gorm.Config{TranslateError: true})
 	if err != nil {
 		panic("failed to connect database")
 	}
 
-	if err = db.AutoMigrate(models.Contact{}); err != nil {
-		log.Fatal(err)
-	}
-
 	return db
 }
@@ -0,0 +1,195 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+const normalizePhonesBatchSize = 500
+
+// maxPhoneProblems bounds the numbers named by the error of normalizePhones.
+const maxPhoneProblems = 20
+
+// normalizedPhone is a stored phone number along with its parsed form.
+type normalizedPhone struct {
+	ID      uint
+	Trashed bool
+	Number  dto.PhoneNumber
+}
+
+// normalizePhones returns the data migration that writes in E.164 the phone
+// numbers saved before they were normalized, those without a region, keeping
+// their text as display format. Contacts keep their version, they are still
+// shown with the number as it was written. Nothing is written when a number
+// does not parse or when two contacts out of the trash would share a number,
+// the error names them so they can be fixed before migrating again.
+func normalizePhones(normalizer dto.PhoneNormalizer) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		var problems []string
+
+		contacts, err := parseContactPhones(db, normalizer, &problems)
+		if err != nil {
+			return err
+		}
+
+		phones, err := parsePhones(db, normalizer, &problems)
+		if err != nil {
+			return err
+		}
+
+		if err := findSharedPhones(db, contacts, &problems); err != nil {
+			return err
+		}
+
+		if len(problems) > 0 {
+			return phoneProblemsError(problems)
+		}
+
+		for _, contact := range contacts {
+			err := db.
+				Unscoped().
+				Model(&models.Contact{}).
+				Where("id = ?", contact.ID).
+				Updates(map[string]interface{}{
+					"phone_number":  contact.Number.E164,
+					"phone_display": contact.Number.Display,
+					"phone_region":  contact.Number.Region,
+					"phone_type":    contact.Number.Type,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, phone := range phones {
+			err := db.
+				Model(&models.Phone{}).
+				Where("id = ?", phone.ID).
+				Updates(map[string]interface{}{
+					"number":  phone.Number.E164,
+					"display": phone.Number.Display,
+					"region":  phone.Number.Region,
+					"type":    phone.Number.Type,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// parseContactPhones parses the phone numbers of the contacts, trashed or not,
+// that were not normalized. The numbers that do not parse go to problems.
+func parseContactPhones(db *gorm.DB, normalizer dto.PhoneNormalizer, problems *[]string) ([]normalizedPhone, error) {
+	var (
+		contacts []models.Contact
+		parsed   []normalizedPhone
+	)
+
+	err := db.
+		Unscoped().
+		Select("id", "phone_number", "deleted_at").
+		Where("phone_region = ''").
+		FindInBatches(&contacts, normalizePhonesBatchSize, func(_ *gorm.DB, _ int) error {
+			for _, contact := range contacts {
+				number, err := normalizer.Parse(contact.PhoneNumber)
+				if err != nil {
+					*problems = append(*problems, fmt.Sprintf("contact %d: %s", contact.ID, err))
+					continue
+				}
+
+				parsed = append(parsed, normalizedPhone{ID: contact.ID, Trashed: contact.DeletedAt.Valid, Number: number})
+			}
+
+			return nil
+		}).Error
+
+	return parsed, err
+}
+
+// parsePhones parses the additional phone numbers that were not normalized. The
+// numbers that do not parse go to problems.
+func parsePhones(db *gorm.DB, normalizer dto.PhoneNormalizer, problems *[]string) ([]normalizedPhone, error) {
+	var (
+		phones []models.Phone
+		parsed []normalizedPhone
+	)
+
+	err := db.
+		Select("id", "contact_id", "number").
+		Where("region = ''").
+		FindInBatches(&phones, normalizePhonesBatchSize, func(_ *gorm.DB, _ int) error {
+			for _, phone := range phones {
+				number, err := normalizer.Parse(phone.Number)
+				if err != nil {
+					*problems = append(*problems, fmt.Sprintf("phone %d of contact %d: %s", phone.ID, phone.ContactID, err))
+					continue
+				}
+
+				parsed = append(parsed, normalizedPhone{ID: phone.ID, Number: number})
+			}
+
+			return nil
+		}).Error
+
+	return parsed, err
+}
+
+// findSharedPhones adds to problems the contacts out of the trash whose
+// normalized number would be the number of another contact out of the trash.
+func findSharedPhones(db *gorm.DB, contacts []normalizedPhone, problems *[]string) error {
+	owners := map[string]uint{}
+	numbers := make([]string, 0, len(contacts))
+
+	for _, contact := range contacts {
+		if contact.Trashed {
+			continue
+		}
+
+		if owner, ok := owners[contact.Number.E164]; ok {
+			*problems = append(*problems, fmt.Sprintf("contacts %d and %d share %s", owner, contact.ID, contact.Number.E164))
+			continue
+		}
+
+		owners[contact.Number.E164] = contact.ID
+		numbers = append(numbers, contact.Number.E164)
+	}
+
+	for start := 0; start < len(numbers); start += normalizePhonesBatchSize {
+		end := start + normalizePhonesBatchSize
+		if end > len(numbers) {
+			end = len(numbers)
+		}
+
+		var normalized []models.Contact
+
+		err := db.
+			Select("id", "phone_number").
+			Where("phone_region <> '' AND phone_number IN ?", numbers[start:end]).
+			Find(&normalized).Error
+		if err != nil {
+			return err
+		}
+
+		for _, contact := range normalized {
+			*problems = append(*problems, fmt.Sprintf("contacts %d and %d share %s", contact.ID, owners[contact.PhoneNumber], contact.PhoneNumber))
+		}
+	}
+
+	return nil
+}
+
+func phoneProblemsError(problems []string) error {
+	count := len(problems)
+	if len(problems) > maxPhoneProblems {
+		problems = append(problems[:maxPhoneProblems:maxPhoneProblems], fmt.Sprintf("and %d more", count-maxPhoneProblems))
+	}
+
+	return fmt.Errorf("%d stored phone numbers can not be normalized: %s", count, strings.Join(problems, "; "))
+}
@@ -0,0 +1,114 @@
+package pg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newPhoneNormalizer(t *testing.T) dto.PhoneNormalizer {
+	phones, err := dto.NewPhoneNormalizer("CO")
+	require.NoError(t, err)
+
+	return phones
+}
+
+// openPhonesDatabase opens a database in memory holding contacts, which the
+// data migration reads like the Postgres one.
+func openPhonesDatabase(t *testing.T, contacts ...models.Contact) *gorm.DB {
+	db, err := sqlite.Open(":memory:")
+	require.NoError(t, err)
+
+	for _, contact := range contacts {
+		require.NoError(t, db.Create(&contact).Error)
+	}
+
+	return db
+}
+
+func TestNormalizePhones(t *testing.T) {
+	db := openPhonesDatabase(t,
+		models.Contact{
+			ID:          1,
+			Name:        "Ana",
+			PhoneNumber: "300 111 1111",
+			Phones:      []models.Phone{{Label: "home", Number: "(601) 222 2222"}},
+		},
+		models.Contact{
+			ID:           2,
+			Name:         "Juan",
+			PhoneNumber:  "+573002222222",
+			PhoneDisplay: "300 222 2222",
+			PhoneRegion:  "CO",
+			PhoneType:    "mobile",
+		},
+		models.Contact{
+			ID:          3,
+			Name:        "Pedro",
+			PhoneNumber: "3002222222",
+			DeletedAt:   gorm.DeletedAt{Time: time.Now(), Valid: true},
+		},
+	)
+
+	require.NoError(t, normalizePhones(newPhoneNormalizer(t))(db))
+
+	var contacts []models.Contact
+	require.NoError(t, db.Unscoped().Preload("Phones").Order("id").Find(&contacts).Error)
+
+	assert.Equal(t, "+573001111111", contacts[0].PhoneNumber)
+	assert.Equal(t, "300 111 1111", contacts[0].PhoneDisplay)
+	assert.Equal(t, "CO", contacts[0].PhoneRegion)
+	assert.Equal(t, "mobile", contacts[0].PhoneType)
+	assert.Equal(t, uint(1), contacts[0].Version)
+	assert.Equal(t, "+576012222222", contacts[0].Phones[0].Number)
+	assert.Equal(t, "(601) 222 2222", contacts[0].Phones[0].Display)
+	assert.Equal(t, "CO", contacts[0].Phones[0].Region)
+	assert.Equal(t, "fixed_line", contacts[0].Phones[0].Type)
+	assert.Equal(t, "300 222 2222", contacts[1].PhoneDisplay)
+	assert.Equal(t, "+573002222222", contacts[2].PhoneNumber)
+}
+
+func TestNormalizePhones_WhenNumbersAreInvalid(t *testing.T) {
+	db := openPhonesDatabase(t,
+		models.Contact{ID: 1, Name: "Ana", PhoneNumber: "12"},
+		models.Contact{
+			ID:          2,
+			Name:        "Juan",
+			PhoneNumber: "3002222222",
+			Phones:      []models.Phone{{ID: 5, Label: "home", Number: "not a number"}},
+		},
+	)
+
+	err := normalizePhones(newPhoneNormalizer(t))(db)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 stored phone numbers can not be normalized")
+	assert.Contains(t, err.Error(), "contact 1: ")
+	assert.Contains(t, err.Error(), "phone 5 of contact 2: ")
+
+	var contact models.Contact
+	require.NoError(t, db.First(&contact, 2).Error)
+
+	assert.Equal(t, "3002222222", contact.PhoneNumber)
+}
+
+func TestNormalizePhones_WhenNumbersAreShared(t *testing.T) {
+	db := openPhonesDatabase(t,
+		models.Contact{ID: 1, Name: "Ana", PhoneNumber: "+573001111111", PhoneRegion: "CO"},
+		models.Contact{ID: 2, Name: "Juan", PhoneNumber: "300 111 1111"},
+		models.Contact{ID: 3, Name: "Pedro", PhoneNumber: "3002222222"},
+		models.Contact{ID: 4, Name: "Luis", PhoneNumber: "300 222 2222"},
+	)
+
+	err := normalizePhones(newPhoneNormalizer(t))(db)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "contacts 3 and 4 share +573002222222")
+	assert.Contains(t, err.Error(), "contacts 1 and 2 share +573001111111")
+}
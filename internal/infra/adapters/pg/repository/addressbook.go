@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AddressBook interface {
+	GetResource(name string) (models.CardResource, error)
+	GetResources(contactIDs []uint) ([]models.CardResource, error)
+	HasResource(contactID uint) (bool, error)
+	SaveResource(resource models.CardResource) error
+	SyncToken() (uint, error)
+	Changes(since uint) (models.AddressBookChanges, error)
+}
+
+type addressBook struct {
+	db *gorm.DB
+}
+
+func NewAddressBook(db *gorm.DB) AddressBook {
+	return &addressBook{
+		db,
+	}
+}
+
+func (repo *addressBook) GetResource(name string) (models.CardResource, error) {
+	var resource models.CardResource
+
+	if err := repo.db.Where("name = ?", name).Take(&resource).Error; err != nil {
+		return resource, translateError(err, fmt.Sprintf("the resource %s was not found", name), "")
+	}
+
+	return resource, nil
+}
+
+// GetResources returns the named resources of the contacts, contacts without a
+// name are left out. Purged contacts keep the name they had.
+func (repo *addressBook) GetResources(contactIDs []uint) ([]models.CardResource, error) {
+	var resources []models.CardResource
+	if len(contactIDs) == 0 {
+		return resources, nil
+	}
+
+	if err := repo.db.Where("contact_id IN ?", contactIDs).Find(&resources).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	var tombstones []models.CardTombstone
+
+	named := repo.db.Model(&models.CardResource{}).Select("1").Where("card_resources.contact_id = card_tombstones.contact_id")
+
+	err := repo.db.Where("contact_id IN ? AND NOT EXISTS (?)", contactIDs, named).Find(&tombstones).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	for _, tombstone := range tombstones {
+		resources = append(resources, models.CardResource{Name: tombstone.Name, ContactID: tombstone.ContactID})
+	}
+
+	return resources, nil
+}
+
+func (repo *addressBook) HasResource(contactID uint) (bool, error) {
+	var count int64
+
+	err := repo.db.Model(&models.CardResource{}).Where("contact_id = ?", contactID).Count(&count).Error
+	if err != nil {
+		return false, translateError(err, "", "")
+	}
+
+	return count > 0, nil
+}
+
+// SaveResource names a contact. A name given before to another contact, which
+// can only be in the trash or purged by then, moves to this one, and the contact
+// loses the name it had.
+func (repo *addressBook) SaveResource(resource models.CardResource) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("contact_id = ? AND name <> ?", resource.ContactID, resource.Name).
+			Delete(&models.CardResource{}).Error
+		if err != nil {
+			return translateError(err, "", "")
+		}
+
+		if err = tx.Where("name = ?", resource.Name).Delete(&models.CardTombstone{}).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		err = tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"contact_id", "uid"}),
+		}).Create(&resource).Error
+
+		return translateError(err, "", fmt.Sprintf("the contact %d already has a resource", resource.ContactID))
+	})
+}
+
+// SyncToken is the value of the sync counter: every change made to a contact is
+// audited, and its entries bump the counter as their transaction commits, so no
+// entry can show up later behind a token already handed out.
+func (repo *addressBook) SyncToken() (uint, error) {
+	var counter models.SyncCounter
+
+	if err := repo.db.Take(&counter, models.SyncCounterID).Error; err != nil {
+		return 0, translateError(err, "", "")
+	}
+
+	return counter.Token, nil
+}
+
+// Changes returns the contacts with audit entries after the since token, in the
+// order of their last change.
+func (repo *addressBook) Changes(since uint) (models.AddressBookChanges, error) {
+	var changed []struct {
+		ContactID uint
+		LastID    uint
+	}
+
+	err := repo.db.Model(&models.AuditEntry{}).
+		Select("contact_id, MAX(sync_token) AS last_id").
+		Where("sync_token > ?", since).
+		Group("contact_id").
+		Order("last_id").
+		Scan(&changed).Error
+	if err != nil {
+		return models.AddressBookChanges{}, translateError(err, "", "")
+	}
+
+	changes := models.AddressBookChanges{Token: since}
+	for _, change := range changed {
+		changes.ContactIDs = append(changes.ContactIDs, change.ContactID)
+		changes.Token = change.LastID
+	}
+
+	return changes, nil
+}
+
+// buryResources keeps in tx the names of the resources of the contacts with ids,
+// which are about to be purged.
+func buryResources(tx *gorm.DB, ids []uint) error {
+	err := tx.Exec(`INSERT INTO card_tombstones (name, contact_id)
+SELECT name, contact_id FROM card_resources WHERE contact_id IN ?
+ON CONFLICT (name) DO UPDATE SET contact_id = excluded.contact_id`, ids).Error
+
+	return translateError(err, "", "")
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"math"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+type Audit interface {
+	History(contactID uint, paginate models.Paginator) (*models.Paginator, error)
+	Get(paginate models.Paginator, filter models.AuditFilter) (*models.Paginator, error)
+}
+
+type audit struct {
+	db *gorm.DB
+}
+
+func NewAudit(db *gorm.DB) Audit {
+	return &audit{
+		db,
+	}
+}
+
+// History returns the audit entries of a contact, the newest first. Contacts
+// that were purged keep their history.
+func (repo *audit) History(contactID uint, paginate models.Paginator) (*models.Paginator, error) {
+	history, err := repo.Get(paginate, models.AuditFilter{ContactID: contactID})
+	if err != nil || history.TotalRecord > 0 {
+		return history, err
+	}
+
+	err = repo.db.Unscoped().Select("id").First(&models.Contact{}, contactID).Error
+	if err != nil {
+		return nil, translateError(err, contactNotFound(contactID), "")
+	}
+
+	return history, nil
+}
+
+// Get returns the audit entries matching filter, the newest first.
+func (repo *audit) Get(paginate models.Paginator, filter models.AuditFilter) (*models.Paginator, error) {
+	var entries []models.AuditEntry
+
+	offset := (paginate.Page - 1) * paginate.Limit
+
+	err := repo.db.
+		Scopes(filterAudit(filter)).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(paginate.Limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	var totalRecords int64
+
+	if err = repo.db.Model(&models.AuditEntry{}).Scopes(filterAudit(filter)).Count(&totalRecords).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return offsetPage(paginate, totalRecords, entries), nil
+}
+
+func filterAudit(filter models.AuditFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.ContactID != 0 {
+			db = db.Where("contact_id = ?", filter.ContactID)
+		}
+
+		if filter.Actor != "" {
+			db = db.Where("actor = ?", filter.Actor)
+		}
+
+		if filter.Action != "" {
+			db = db.Where("action = ?", filter.Action)
+		}
+
+		if filter.RequestID != "" {
+			db = db.Where("request_id = ?", filter.RequestID)
+		}
+
+		if !filter.From.IsZero() {
+			db = db.Where("created_at >= ?", filter.From)
+		}
+
+		if !filter.To.IsZero() {
+			db = db.Where("created_at <= ?", filter.To)
+		}
+
+		return db
+	}
+}
+
+// recordChange writes in tx the audit entry of a change made to a contact, given
+// its state before and after the change. before is nil for a new contact and
+// after for a purged one.
+func recordChange(tx *gorm.DB, origin models.Origin, action models.AuditAction, before, after *models.Contact) error {
+	entry, err := auditEntry(origin, action, before, after)
+	if err != nil {
+		return err
+	}
+
+	return writeEntries(tx, entry)
+}
+
+// writeEntries writes in tx the audit entries of the transaction, stamped with
+// the next sync token. It must be its last write: the sync counter stays locked
+// from then until the transaction ends, and other transactions writing entries
+// only wait for it at that point.
+func writeEntries(tx *gorm.DB, entries ...models.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	token, err := nextSyncToken(tx)
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		entries[i].SyncToken = token
+	}
+
+	if err := tx.Create(&entries).Error; err != nil {
+		return translateError(err, "", "")
+	}
+
+	return nil
+}
+
+// nextSyncToken bumps the sync counter in tx and returns its new value.
+func nextSyncToken(tx *gorm.DB) (uint, error) {
+	counter := models.SyncCounter{ID: models.SyncCounterID}
+
+	if err := tx.Model(&counter).UpdateColumn("token", gorm.Expr("token + 1")).Error; err != nil {
+		return 0, translateError(err, "", "")
+	}
+
+	if err := tx.Take(&counter, models.SyncCounterID).Error; err != nil {
+		return 0, translateError(err, "", "")
+	}
+
+	return counter.Token, nil
+}
+
+// auditEntry builds the audit entry of a change, as recordChange records it.
+func auditEntry(origin models.Origin, action models.AuditAction, before, after *models.Contact) (models.AuditEntry, error) {
+	changes, err := models.DiffContacts(before, after)
+	if err != nil {
+		return models.AuditEntry{}, apperrors.Internal(err)
+	}
+
+	entry := models.AuditEntry{
+		Action:    action,
+		Actor:     origin.Actor,
+		RequestID: origin.RequestID,
+		Changes:   changes,
+	}
+
+	if after != nil {
+		entry.ContactID = after.ID
+	} else {
+		entry.ContactID = before.ID
+	}
+
+	return entry, nil
+}
+
+// snapshot reads the contact as it is stored in tx, in the trash or not, with
+// its phones, emails, addresses and tags.
+func snapshot(tx *gorm.DB, id uint) (*models.Contact, error) {
+	var contact models.Contact
+
+	if err := tx.Unscoped().Scopes(preloadChildren).First(&contact, id).Error; err != nil {
+		return nil, translateError(err, contactNotFound(id), "")
+	}
+
+	return &contact, nil
+}
+
+// offsetPage builds the page of records found at the offset of paginate, out of
+// total records.
+func offsetPage(paginate models.Paginator, total int64, records interface{}) *models.Paginator {
+	paginator := &models.Paginator{
+		TotalRecord: total,
+		TotalPage:   int(math.Ceil(float64(total) / float64(paginate.Limit))),
+		Records:     records,
+		Offset:      (paginate.Page - 1) * paginate.Limit,
+		Limit:       paginate.Limit,
+		Page:        paginate.Page,
+		PrevPage:    paginate.Page,
+		NextPage:    paginate.Page,
+	}
+
+	if paginate.Page > 1 {
+		paginator.PrevPage = paginate.Page - 1
+	}
+
+	if paginate.Page < paginator.TotalPage {
+		paginator.NextPage = paginate.Page + 1
+	}
+
+	return paginator
+}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errBatchFailed rolls back an atomic batch with a failed operation, the failure
+// itself is reported in the results.
+var errBatchFailed = errors.New("an operation of the batch failed")
+
+// Batch applies operations in a single transaction and returns their results in
+// the same order. Every operation is checked before anything is written: updates
+// and deletes need a contact at the expected version, creates and updates a
+// phone number no other contact keeps. When atomic, one failed check leaves every
+// contact untouched; otherwise only the operations that passed are applied.
+// Deletes are applied first, so their numbers can be taken by the rest of the
+// batch, and each kind of operation is written with multi-row statements.
+func (repo *contacts) Batch(origin models.Origin, operations []models.BatchOperation,
+	atomic bool) ([]models.BatchResult, error) {
+	results := make([]models.BatchResult, len(operations))
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockContacts(tx, batchIDs(operations))
+		if err != nil {
+			return err
+		}
+
+		if err = checkBatch(tx, operations, current, results); err != nil {
+			return err
+		}
+
+		if atomic {
+			for _, result := range results {
+				if result.Err != nil {
+					return errBatchFailed
+				}
+			}
+		}
+
+		return applyBatch(tx, origin, operations, current, results)
+	})
+
+	if err != nil && !errors.Is(err, errBatchFailed) {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// batchIDs are the contacts updated or deleted by operations.
+func batchIDs(operations []models.BatchOperation) []uint {
+	var ids []uint
+
+	for _, operation := range operations {
+		if operation.Action != models.BatchCreate {
+			ids = append(ids, operation.ID)
+		}
+	}
+
+	return ids
+}
+
+// lockContacts reads for update the contacts out of the trash among ids, with
+// their phones, emails, addresses and tags.
+func lockContacts(tx *gorm.DB, ids []uint) (map[uint]*models.Contact, error) {
+	if len(ids) == 0 {
+		return map[uint]*models.Contact{}, nil
+	}
+
+	var locked []models.Contact
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id IN ?", ids).
+		Find(&locked).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	ids = make([]uint, len(locked))
+	for i, contact := range locked {
+		ids[i] = contact.ID
+	}
+
+	return snapshots(tx, ids)
+}
+
+// snapshots reads the contacts as they are stored in tx, in the trash or not,
+// with their phones, emails, addresses and tags.
+func snapshots(tx *gorm.DB, ids []uint) (map[uint]*models.Contact, error) {
+	contacts := make(map[uint]*models.Contact, len(ids))
+
+	if len(ids) == 0 {
+		return contacts, nil
+	}
+
+	var found []models.Contact
+
+	if err := tx.Unscoped().Scopes(preloadChildren).Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	for i := range found {
+		contacts[found[i].ID] = &found[i]
+	}
+
+	return contacts, nil
+}
+
+// checkBatch sets the error of the results whose operation can not be applied
+// to the current contacts.
+func checkBatch(tx *gorm.DB, operations []models.BatchOperation, current map[uint]*models.Contact,
+	results []models.BatchResult) error {
+	deleted := make(map[uint]bool)
+
+	for i, operation := range operations {
+		if operation.Action == models.BatchCreate {
+			continue
+		}
+
+		contact, found := current[operation.ID]
+
+		switch {
+		case !found:
+			results[i].Err = apperrors.NotFound(contactNotFound(operation.ID), nil)
+		case operation.Version != 0 && contact.Version != operation.Version:
+			results[i].Err = contactModified(operation.ID)
+		case operation.Action == models.BatchDelete:
+			deleted[operation.ID] = true
+		}
+	}
+
+	var numbers []string
+
+	for i, operation := range operations {
+		if operation.Action != models.BatchDelete && results[i].Err == nil {
+			numbers = append(numbers, operation.Contact.PhoneNumber)
+		}
+	}
+
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	var owners []models.Contact
+
+	err := tx.Select("id", "phone_number").Where("phone_number IN ?", numbers).Find(&owners).Error
+	if err != nil {
+		return translateError(err, "", "")
+	}
+
+	ownerOf := make(map[string]uint, len(owners))
+	for _, owner := range owners {
+		ownerOf[owner.PhoneNumber] = owner.ID
+	}
+
+	// a number is taken by the contact keeping it, unless it is deleted by the
+	// batch, and by the first operation of the batch claiming it
+	claimed := make(map[string]bool)
+
+	for i, operation := range operations {
+		if operation.Action == models.BatchDelete || results[i].Err != nil {
+			continue
+		}
+
+		number := operation.Contact.PhoneNumber
+		owner, owned := ownerOf[number]
+
+		if claimed[number] || (owned && owner != operation.ID && !deleted[owner]) {
+			results[i].Err = apperrors.Conflict(fmt.Sprintf("your contact number %s already exists", number), nil)
+			continue
+		}
+
+		claimed[number] = true
+	}
+
+	return nil
+}
+
+// applyBatch writes the operations whose results have no error, keeps a revision
+// of the contacts created and updated and audits every write on behalf of origin.
+func applyBatch(tx *gorm.DB, origin models.Origin, operations []models.BatchOperation,
+	current map[uint]*models.Contact, results []models.BatchResult) error {
+	var deletes []uint
+	var updates, creates []*models.Contact
+
+	written := make([]*models.Contact, len(operations))
+
+	for i, operation := range operations {
+		if results[i].Err != nil {
+			continue
+		}
+
+		contact := operation.Contact
+		written[i] = &contact
+
+		switch operation.Action {
+		case models.BatchCreate:
+			contact.ID = 0
+			contact.Version = 1
+			creates = append(creates, &contact)
+		case models.BatchUpdate:
+			contact.ID = operation.ID
+			contact.Version = current[operation.ID].Version + 1
+			updates = append(updates, &contact)
+		case models.BatchDelete:
+			contact.ID = operation.ID
+			deletes = append(deletes, operation.ID)
+		}
+	}
+
+	if len(deletes) > 0 {
+		if err := tx.Where("id IN ?", deletes).Delete(&models.Contact{}).Error; err != nil {
+			return translateError(err, "", "")
+		}
+	}
+
+	if len(updates) > 0 {
+		err := tx.
+			Select("*").
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, UpdateAll: true}).
+			Create(&updates).Error
+		if err != nil {
+			return translateError(err, "", "a phone number of the batch already exists")
+		}
+
+		if err = replaceChildren(tx, updates...); err != nil {
+			return err
+		}
+	}
+
+	if len(creates) > 0 {
+		if err := tx.Create(&creates).Error; err != nil {
+			return translateError(err, "", "a phone number of the batch already exists")
+		}
+	}
+
+	ids := make([]uint, 0, len(operations))
+	for _, contact := range written {
+		if contact != nil {
+			ids = append(ids, contact.ID)
+		}
+	}
+
+	after, err := snapshots(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	var revisions []models.Revision
+	var entries []models.AuditEntry
+
+	for i, operation := range operations {
+		if written[i] == nil {
+			continue
+		}
+
+		contact := after[written[i].ID]
+		results[i].Contact = *contact
+
+		var entry models.AuditEntry
+
+		switch operation.Action {
+		case models.BatchCreate:
+			entry, err = auditEntry(origin, models.AuditCreate, nil, contact)
+		case models.BatchUpdate:
+			entry, err = auditEntry(origin, models.AuditUpdate, current[contact.ID], contact)
+		case models.BatchDelete:
+			entry, err = auditEntry(origin, models.AuditDelete, current[contact.ID], contact)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if operation.Action != models.BatchDelete {
+			revisions = append(revisions, models.Revision{ContactID: contact.ID, Version: contact.Version, Contact: *contact})
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if len(revisions) > 0 {
+		if err = tx.Create(&revisions).Error; err != nil {
+			return translateError(err, "", "")
+		}
+	}
+
+	return writeEntries(tx, entries...)
+}
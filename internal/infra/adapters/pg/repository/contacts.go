@@ -1,18 +1,35 @@
 package repository
 
 import (
+	"fmt"
 	"math"
+	"time"
 
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
 	"github.com/AjxGnx/contacts-go/internal/domain/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Contacts interface {
-	Create(contact models.Contact) (models.Contact, error)
+	Create(origin models.Origin, contact models.Contact) (models.Contact, error)
 	GetByID(id uint) (models.Contact, error)
-	Update(id uint, account models.Contact) (models.Contact, error)
-	Delete(id uint) error
-	Get(paginate models.Paginator) (*models.Paginator, error)
+	GetByPhoneNumbers(numbers []string) ([]models.Contact, error)
+	Update(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error)
+	Delete(origin models.Origin, id uint, version uint) error
+	Get(paginate models.Paginator, filter models.ContactFilter) (*models.Paginator, error)
+	Export(filter models.ContactFilter, write func(contacts []models.Contact) error) error
+	GetTrash(paginate models.Paginator) (*models.Paginator, error)
+	Restore(origin models.Origin, id uint) (models.Contact, error)
+	Purge(origin models.Origin, id uint) error
+	PurgeDeletedBefore(origin models.Origin, before time.Time) (int64, error)
+	Revert(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error)
+	GetRevisions(id uint, paginate models.Paginator) (*models.Paginator, error)
+	GetRevision(id uint, version uint) (models.Revision, error)
+	GetRevisionAt(id uint, at time.Time) (models.Revision, error)
+	Batch(origin models.Origin, operations []models.BatchOperation, atomic bool) ([]models.BatchResult, error)
+	Merge(origin models.Origin, merge models.Merge) (models.Contact, error)
+	Search(paginate models.Paginator, query string) (*models.Paginator, error)
 }
 
 type contacts struct {
@@ -25,10 +42,29 @@ func NewContacts(db *gorm.DB) Contacts {
 	}
 }
 
-func (repo *contacts) Create(contact models.Contact) (models.Contact, error) {
-	result := repo.db.Create(&contact).Scan(&contact)
-	if result.Error != nil {
-		return models.Contact{}, result.Error
+// Create saves the contact and audits its creation on behalf of origin.
+func (repo *contacts) Create(origin models.Origin, contact models.Contact) (models.Contact, error) {
+	contact.Version = 1
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&contact).Error; err != nil {
+			return translateError(err, "", fmt.Sprintf("your contact number %s already exists", contact.PhoneNumber))
+		}
+
+		after, err := snapshot(tx, contact.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = recordRevision(tx, after); err != nil {
+			return err
+		}
+
+		return recordChange(tx, origin, models.AuditCreate, nil, after)
+	})
+
+	if err != nil {
+		return models.Contact{}, err
 	}
 
 	return contact, nil
@@ -37,53 +73,265 @@ func (repo *contacts) Create(contact models.Contact) (models.Contact, error) {
 func (repo *contacts) GetByID(id uint) (models.Contact, error) {
 	var contact models.Contact
 
-	result := repo.db.First(&contact, id)
+	result := repo.db.Scopes(preloadChildren).First(&contact, id)
 	if result.Error != nil {
-		return contact, result.Error
+		return contact, translateError(result.Error, contactNotFound(id), "")
 	}
 
 	return contact, nil
 }
 
-func (repo *contacts) Update(id uint, contact models.Contact) (models.Contact, error) {
-	result := repo.db.
-		Model(&contact).
-		Where("id = ?", id).
-		Updates(contact).
-		Scan(&contact)
+// GetByPhoneNumbers returns the id, phone number and version of the contacts,
+// out of the trash, that have one of numbers as their phone number.
+func (repo *contacts) GetByPhoneNumbers(numbers []string) ([]models.Contact, error) {
+	var contacts []models.Contact
 
-	if result.Error != nil {
-		return contact, result.Error
+	if len(numbers) == 0 {
+		return contacts, nil
 	}
 
-	return contact, nil
+	err := repo.db.Select("id", "phone_number", "version").Where("phone_number IN ?", numbers).Find(&contacts).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return contacts, nil
+}
+
+// Update replaces every column of the contact, fields left empty in contact are
+// cleared. When version is not 0 the contact is only written if it is still at
+// that version; every write bumps the version, keeps a revision of the contact
+// and is audited on behalf of origin.
+func (repo *contacts) Update(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error) {
+	return repo.update(origin, models.AuditUpdate, id, contact, version)
+}
+
+// Revert writes contact, the content of a previous revision, as Update does but
+// audits the write as a revert.
+func (repo *contacts) Revert(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error) {
+	return repo.update(origin, models.AuditRevert, id, contact, version)
+}
+
+func (repo *contacts) update(origin models.Origin, action models.AuditAction, id uint, contact models.Contact,
+	version uint) (models.Contact, error) {
+	var after *models.Contact
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockContact(tx, id, version)
+		if err != nil {
+			return err
+		}
+
+		before, err := snapshot(tx, id)
+		if err != nil {
+			return err
+		}
+
+		contact.ID = id
+
+		if err = writeContact(tx, &contact, current.Version); err != nil {
+			return err
+		}
+
+		if after, err = snapshot(tx, id); err != nil {
+			return err
+		}
+
+		if err = recordRevision(tx, after); err != nil {
+			return err
+		}
+
+		return recordChange(tx, origin, action, before, after)
+	})
+
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return *after, nil
 }
 
-func (repo *contacts) Delete(id uint) error {
-	result := repo.db.
-		Where("id = ?", id).
-		Delete(&models.Contact{})
+// writeContact replaces every column and child of the contact, which must still
+// be at version, and bumps its version.
+func writeContact(tx *gorm.DB, contact *models.Contact, version uint) error {
+	contact.Version = version + 1
+
+	result := tx.
+		Model(contact).
+		Where("version = ?", version).
+		Select("*").
+		Omit("id", clause.Associations).
+		Updates(contact)
 
 	if result.Error != nil {
-		return result.Error
+		return translateError(result.Error, contactNotFound(contact.ID),
+			fmt.Sprintf("your contact number %s already exists", contact.PhoneNumber))
+	}
+
+	if result.RowsAffected == 0 {
+		return contactModified(contact.ID)
+	}
+
+	return replaceChildren(tx, contact)
+}
+
+// Delete moves the contact to the trash, when version is not 0 only if it is
+// still at that version.
+func (repo *contacts) Delete(origin models.Origin, id uint, version uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockContact(tx, id, version)
+		if err != nil {
+			return err
+		}
+
+		before, err := snapshot(tx, id)
+		if err != nil {
+			return err
+		}
+
+		result := tx.
+			Where("id = ? AND version = ?", id, current.Version).
+			Delete(&models.Contact{})
+
+		if result.Error != nil {
+			return translateError(result.Error, contactNotFound(id), "")
+		}
+
+		if result.RowsAffected == 0 {
+			return contactModified(id)
+		}
+
+		after, err := snapshot(tx, id)
+		if err != nil {
+			return err
+		}
+
+		return recordChange(tx, origin, models.AuditDelete, before, after)
+	})
+}
+
+// replaceChildren swaps the phones, emails and addresses stored for contacts with
+// the ones they carry, so updates behave as a full replacement of the lists. Each
+// list is written with a single statement for all the contacts.
+func replaceChildren(tx *gorm.DB, contacts ...*models.Contact) error {
+	ids := make([]uint, 0, len(contacts))
+	for _, contact := range contacts {
+		ids = append(ids, contact.ID)
+	}
+
+	children := []interface{}{&models.Phone{}, &models.Email{}, &models.Address{}}
+	for _, child := range children {
+		if err := tx.Where("contact_id IN ?", ids).Delete(child).Error; err != nil {
+			return translateError(err, "", "")
+		}
+	}
+
+	var phones []*models.Phone
+	var emails []*models.Email
+	var addresses []*models.Address
+
+	for _, contact := range contacts {
+		for i := range contact.Phones {
+			contact.Phones[i].ID = 0
+			contact.Phones[i].ContactID = contact.ID
+			phones = append(phones, &contact.Phones[i])
+		}
+
+		for i := range contact.Emails {
+			contact.Emails[i].ID = 0
+			contact.Emails[i].ContactID = contact.ID
+			emails = append(emails, &contact.Emails[i])
+		}
+
+		for i := range contact.Addresses {
+			contact.Addresses[i].ID = 0
+			contact.Addresses[i].ContactID = contact.ID
+			addresses = append(addresses, &contact.Addresses[i])
+		}
+	}
+
+	if len(phones) > 0 {
+		if err := tx.Create(&phones).Error; err != nil {
+			return translateError(err, "", "")
+		}
+	}
+
+	if len(emails) > 0 {
+		if err := tx.Create(&emails).Error; err != nil {
+			return translateError(err, "", "")
+		}
+	}
+
+	if len(addresses) > 0 {
+		if err := tx.Create(&addresses).Error; err != nil {
+			return translateError(err, "", "")
+		}
 	}
 
 	return nil
 }
 
-func (repo *contacts) Get(paginate models.Paginator) (*models.Paginator, error) {
+// preloadChildren loads the phones, emails and addresses of the queried contacts
+// in the order they were saved, and their tags by name.
+func preloadChildren(db *gorm.DB) *gorm.DB {
+	byID := func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}
+
+	return db.
+		Preload("Phones", byID).
+		Preload("Emails", byID).
+		Preload("Addresses", byID).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		})
+}
+
+// lockContact reads the contact for update and checks that it is at version,
+// unless version is 0.
+func lockContact(tx *gorm.DB, id uint, version uint) (models.Contact, error) {
+	var current models.Contact
+
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&current, id).Error
+	if err != nil {
+		return current, translateError(err, contactNotFound(id), "")
+	}
+
+	if version != 0 && current.Version != version {
+		return current, contactModified(id)
+	}
+
+	return current, nil
+}
+
+func (repo *contacts) Get(paginate models.Paginator, filter models.ContactFilter) (*models.Paginator, error) {
+	order, err := contactsOrder(filter.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	if paginate.Cursor != nil {
+		return repo.getByCursor(paginate, filter, order)
+	}
+
 	var contacts []models.Contact
 
 	offset := (paginate.Page - 1) * paginate.Limit
 
-	err := repo.db.Offset(offset).Limit(paginate.Limit).Find(&contacts).Error
+	err = repo.db.
+		Scopes(filterContacts(filter), sortContacts(order, false), preloadChildren).
+		Offset(offset).
+		Limit(paginate.Limit).
+		Find(&contacts).Error
 	if err != nil {
-		return nil, err
+		return nil, translateError(err, "", "")
 	}
 
-	totalRecords, err := repo.countTotalRecords()
+	totalRecords, err := repo.countTotalRecords(filter)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err, "", "")
 	}
 
 	paginator := &models.Paginator{
@@ -101,18 +349,244 @@ func (repo *contacts) Get(paginate models.Paginator) (*models.Paginator, error)
 		paginator.PrevPage = paginate.Page
 	}
 
-	paginator.NextPage = paginate.Page + 1
+	if paginate.Page < paginator.TotalPage {
+		paginator.NextPage = paginate.Page + 1
+	} else {
+		paginator.NextPage = paginate.Page
+	}
 
 	return paginator, nil
 
 }
 
-func (repo *contacts) countTotalRecords() (int64, error) {
+// getByCursor seeks the page placed after (or before) paginate.Cursor instead of
+// using an offset, so deep pages are as cheap as the first one and rows inserted
+// while scanning are neither duplicated nor skipped. One extra row is read to know
+// if there is another page in the direction of the scan.
+func (repo *contacts) getByCursor(paginate models.Paginator, filter models.ContactFilter,
+	order []models.SortField) (*models.Paginator, error) {
+	var contacts []models.Contact
+
+	cursor := paginate.Cursor
+
+	err := repo.db.
+		Scopes(
+			filterContacts(filter),
+			seekContacts(order, cursor.Keys, cursor.Backward),
+			sortContacts(order, cursor.Backward),
+			preloadChildren,
+		).
+		Limit(paginate.Limit + 1).
+		Find(&contacts).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	hasMore := len(contacts) > paginate.Limit
+	if hasMore {
+		contacts = contacts[:paginate.Limit]
+	}
+
+	if cursor.Backward {
+		for i, j := 0, len(contacts)-1; i < j; i, j = i+1, j-1 {
+			contacts[i], contacts[j] = contacts[j], contacts[i]
+		}
+	}
+
+	totalRecords, err := repo.countTotalRecords(filter)
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	paginator := &models.Paginator{
+		TotalRecord: totalRecords,
+		TotalPage:   int(math.Ceil(float64(totalRecords) / float64(paginate.Limit))),
+		Records:     contacts,
+		Limit:       paginate.Limit,
+	}
+
+	if len(contacts) == 0 {
+		return paginator, nil
+	}
+
+	if hasMore || cursor.Backward {
+		paginator.Next = &models.Cursor{Keys: contactKeys(contacts[len(contacts)-1], order)}
+	}
+
+	if (hasMore && cursor.Backward) || (!cursor.Backward && len(cursor.Keys) > 0) {
+		paginator.Prev = &models.Cursor{Keys: contactKeys(contacts[0], order), Backward: true}
+	}
+
+	return paginator, nil
+}
+
+// GetTrash lists the contacts in the trash, the most recently deleted first.
+func (repo *contacts) GetTrash(paginate models.Paginator) (*models.Paginator, error) {
+	var contacts []models.Contact
+
+	offset := (paginate.Page - 1) * paginate.Limit
+
+	err := repo.db.
+		Unscoped().
+		Scopes(preloadChildren).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC, id DESC").
+		Offset(offset).
+		Limit(paginate.Limit).
+		Find(&contacts).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	var totalRecords int64
+
+	err = repo.db.Unscoped().Model(&models.Contact{}).Where("deleted_at IS NOT NULL").Count(&totalRecords).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return offsetPage(paginate, totalRecords, contacts), nil
+}
+
+// Restore takes the contact out of the trash with a new version. It fails with a
+// conflict when another contact took its phone number in the meantime.
+func (repo *contacts) Restore(origin models.Origin, id uint) (models.Contact, error) {
+	var contact models.Contact
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL").
+			First(&contact, id).Error
+		if err != nil {
+			return translateError(err, contactNotInTrash(id), "")
+		}
+
+		before, err := snapshot(tx, id)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().
+			Model(&contact).
+			Updates(map[string]interface{}{"deleted_at": nil, "version": contact.Version + 1}).Error
+		if err != nil {
+			return translateError(err, "", fmt.Sprintf(
+				"the contact: %v can not be restored, the number %s belongs to another contact",
+				id, contact.PhoneNumber))
+		}
+
+		after, err := snapshot(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = recordRevision(tx, after); err != nil {
+			return err
+		}
+
+		return recordChange(tx, origin, models.AuditRestore, before, after)
+	})
+
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return repo.GetByID(id)
+}
+
+// Purge deletes for good a contact that is in the trash, along with its phones,
+// emails, addresses and memberships. Its history and the name of its resource
+// are kept.
+func (repo *contacts) Purge(origin models.Origin, id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var contact models.Contact
+
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(preloadChildren).
+			Where("deleted_at IS NOT NULL").
+			First(&contact, id).Error
+		if err != nil {
+			return translateError(err, contactNotInTrash(id), "")
+		}
+
+		if err = buryResources(tx, []uint{id}); err != nil {
+			return err
+		}
+
+		if err = tx.Unscoped().Delete(&models.Contact{}, id).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		return recordChange(tx, origin, models.AuditPurge, &contact, nil)
+	})
+}
+
+// PurgeDeletedBefore deletes for good the contacts moved to the trash before
+// before and returns how many were deleted.
+func (repo *contacts) PurgeDeletedBefore(origin models.Origin, before time.Time) (int64, error) {
+	var purged int64
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var contacts []models.Contact
+
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(preloadChildren).
+			Where("deleted_at < ?", before).
+			Find(&contacts).Error
+		if err != nil || len(contacts) == 0 {
+			return translateError(err, "", "")
+		}
+
+		ids := make([]uint, len(contacts))
+		entries := make([]models.AuditEntry, len(contacts))
+
+		for i := range contacts {
+			ids[i] = contacts[i].ID
+
+			if entries[i], err = auditEntry(origin, models.AuditPurge, &contacts[i], nil); err != nil {
+				return err
+			}
+		}
+
+		if err = buryResources(tx, ids); err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&models.Contact{}, ids)
+		if result.Error != nil {
+			return translateError(result.Error, "", "")
+		}
+
+		purged = result.RowsAffected
+
+		return writeEntries(tx, entries...)
+	})
+
+	return purged, err
+}
+
+func (repo *contacts) countTotalRecords(filter models.ContactFilter) (int64, error) {
 	var total int64
 
-	if err := repo.db.Model(&models.Contact{}).Count(&total).Error; err != nil {
+	if err := repo.db.Model(&models.Contact{}).Scopes(filterContacts(filter)).Count(&total).Error; err != nil {
 		return 0, err
 	}
 
 	return total, nil
 }
+
+func contactNotFound(id uint) string {
+	return fmt.Sprintf("the contact: %v does not exist", id)
+}
+
+func contactNotInTrash(id uint) string {
+	return fmt.Sprintf("the contact: %v is not in the trash", id)
+}
+
+func contactModified(id uint) error {
+	return apperrors.PreconditionFailed(
+		fmt.Sprintf("the contact: %v was modified by someone else, reload it and try again", id), nil)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "gorm.io/gorm"
+
+// isPostgres reports whether db talks to Postgres. The repositories also run on
+// SQLite, which has no advisory locks, server side cursors nor full-text search:
+// its write transactions are serialized anyway, and the export and the search
+// fall back to pages and matching in Go.
+func isPostgres(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
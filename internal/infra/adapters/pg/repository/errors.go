@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const uniqueViolationCode = "23505"
+
+// translateError maps GORM and driver errors into apperrors so that the upper
+// layers never depend on database error messages. notFound and conflict are the
+// client facing messages used for the matching kinds.
+func translateError(err error, notFound, conflict string) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.NotFound(notFound, err)
+	}
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apperrors.Conflict(conflict, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return apperrors.Conflict(conflict, err)
+	}
+
+	return apperrors.Internal(err)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// exportBatchSize is how many contacts an export fetches from its cursor at once.
+const exportBatchSize = 500
+
+// Export walks the contacts matching filter, in its order, through a server side
+// cursor so they are never all held in memory. write is called with every batch
+// of contacts, with their children loaded; an error returned by it stops the
+// export. The contacts are read from a single snapshot of the database.
+func (repo *contacts) Export(filter models.ContactFilter, write func(contacts []models.Contact) error) error {
+	order, err := contactsOrder(filter.Sort)
+	if err != nil {
+		return err
+	}
+
+	if !isPostgres(repo.db) {
+		return repo.exportPages(filter, order, write)
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		ids := tx.Model(&models.Contact{}).Select("id").Scopes(filterContacts(filter), sortContacts(order, false))
+
+		if err := tx.Exec("DECLARE export_contacts NO SCROLL CURSOR FOR ?", ids).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		for {
+			var batch []uint
+
+			err := tx.Raw(fmt.Sprintf("FETCH FORWARD %d FROM export_contacts", exportBatchSize)).Scan(&batch).Error
+			if err != nil {
+				return translateError(err, "", "")
+			}
+
+			if len(batch) == 0 {
+				return nil
+			}
+
+			contacts, err := exportBatch(tx, batch)
+			if err != nil {
+				return err
+			}
+
+			if err := write(contacts); err != nil {
+				return err
+			}
+		}
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// exportPages walks the contacts as Export does, without a cursor: every batch is
+// a page seeked past the last contact of the previous one. Pages are read on
+// their own, so writes made during the export show in the pages still to come.
+func (repo *contacts) exportPages(filter models.ContactFilter, order []models.SortField,
+	write func(contacts []models.Contact) error) error {
+	var keys []string
+
+	for {
+		var batch []models.Contact
+
+		err := repo.db.
+			Scopes(filterContacts(filter), seekContacts(order, keys, false), sortContacts(order, false), preloadChildren).
+			Limit(exportBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			return translateError(err, "", "")
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err = write(batch); err != nil {
+			return err
+		}
+
+		if len(batch) < exportBatchSize {
+			return nil
+		}
+
+		keys = contactKeys(batch[len(batch)-1], order)
+	}
+}
+
+// exportBatch loads the contacts of ids, in the order of ids.
+func exportBatch(tx *gorm.DB, ids []uint) ([]models.Contact, error) {
+	var found []models.Contact
+
+	if err := tx.Scopes(preloadChildren).Find(&found, ids).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	byID := make(map[uint]models.Contact, len(found))
+	for _, contact := range found {
+		byID[contact.ID] = contact
+	}
+
+	contacts := make([]models.Contact, 0, len(ids))
+	for _, id := range ids {
+		if contact, ok := byID[id]; ok {
+			contacts = append(contacts, contact)
+		}
+	}
+
+	return contacts, nil
+}
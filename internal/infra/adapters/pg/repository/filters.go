@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// contactField is a column that listings can filter and sort by. key reads the
+// value of the column from a contact and parse reads it back from a cursor.
+type contactField struct {
+	column string
+	key    func(contact models.Contact) string
+	parse  func(key string) (interface{}, error)
+}
+
+// contactFields whitelists the columns that listings can filter and sort by,
+// keyed by the field name used by the api.
+var contactFields = map[string]contactField{
+	"id": {
+		column: "id",
+		key:    func(contact models.Contact) string { return strconv.FormatUint(uint64(contact.ID), 10) },
+		parse:  func(key string) (interface{}, error) { return strconv.ParseUint(key, 10, 64) },
+	},
+	"name": {
+		column: "name",
+		key:    func(contact models.Contact) string { return contact.Name },
+		parse:  parseString,
+	},
+	"name_prefix": {
+		column: "name_prefix",
+		key:    func(contact models.Contact) string { return contact.Prefix },
+		parse:  parseString,
+	},
+	"given_name": {
+		column: "given_name",
+		key:    func(contact models.Contact) string { return contact.GivenName },
+		parse:  parseString,
+	},
+	"family_name": {
+		column: "family_name",
+		key:    func(contact models.Contact) string { return contact.FamilyName },
+		parse:  parseString,
+	},
+	"name_suffix": {
+		column: "name_suffix",
+		key:    func(contact models.Contact) string { return contact.Suffix },
+		parse:  parseString,
+	},
+	"nickname": {
+		column: "nickname",
+		key:    func(contact models.Contact) string { return contact.Nickname },
+		parse:  parseString,
+	},
+	"phone_number": {
+		column: "phone_number",
+		key:    func(contact models.Contact) string { return contact.PhoneNumber },
+		parse:  parseString,
+	},
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// filterContacts restricts a query to the contacts matching filter.
+func filterContacts(filter models.ContactFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Search != "" {
+			pattern := "%" + likeEscaper.Replace(strings.ToLower(filter.Search)) + "%"
+			db = db.Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(nickname) LIKE ? ESCAPE '\' `+
+				`OR LOWER(phone_number) LIKE ? ESCAPE '\'`, pattern, pattern, pattern)
+		}
+
+		for _, fieldFilter := range filter.Filters {
+			field, ok := contactFields[fieldFilter.Field]
+			if !ok {
+				_ = db.AddError(unknownField(fieldFilter.Field))
+				continue
+			}
+
+			if fieldFilter.Prefix {
+				db = db.Where(fmt.Sprintf(`%s LIKE ? ESCAPE '\'`, field.column),
+					likeEscaper.Replace(fieldFilter.Value)+"%")
+			} else {
+				db = db.Where(clause.Eq{Column: clause.Column{Name: field.column}, Value: fieldFilter.Value})
+			}
+		}
+
+		if len(filter.Tags) > 0 {
+			db = db.Where("id IN (?)", taggedContacts(db, filter.Tags, filter.AnyTag))
+		}
+
+		return db
+	}
+}
+
+// taggedContacts selects the ids of the contacts having every tag in names, or
+// at least one of them when any is set.
+func taggedContacts(db *gorm.DB, names []string, any bool) *gorm.DB {
+	tagged := db.Session(&gorm.Session{NewDB: true}).
+		Model(&contactTag{}).
+		Select("contact_tags.contact_id").
+		Joins("JOIN tags ON tags.id = contact_tags.tag_id").
+		Where("tags.name IN ?", names)
+
+	if !any {
+		tagged = tagged.
+			Group("contact_tags.contact_id").
+			Having("COUNT(DISTINCT tags.id) = ?", len(names))
+	}
+
+	return tagged
+}
+
+// contactsOrder returns the fields of sort, the id is always appended as the last
+// criteria so the order is total and pages are stable.
+func contactsOrder(sort []models.SortField) ([]models.SortField, error) {
+	order := make([]models.SortField, 0, len(sort)+1)
+	sortedByID := false
+
+	for _, sortField := range sort {
+		if _, ok := contactFields[sortField.Field]; !ok {
+			return nil, unknownField(sortField.Field)
+		}
+
+		sortedByID = sortedByID || sortField.Field == "id"
+		order = append(order, sortField)
+	}
+
+	if !sortedByID {
+		order = append(order, models.SortField{Field: "id"})
+	}
+
+	return order, nil
+}
+
+// sortContacts orders a query by order, reversing every criteria when reverse is
+// set.
+func sortContacts(order []models.SortField, reverse bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, sortField := range order {
+			db = db.Order(clause.OrderByColumn{
+				Column: clause.Column{Name: contactFields[sortField.Field].column},
+				Desc:   sortField.Desc != reverse,
+			})
+		}
+
+		return db
+	}
+}
+
+// seekContacts keeps the contacts placed after keys in order, or before them when
+// backward is set. For order (a, -b) it builds: a > ? OR (a = ? AND b < ?).
+func seekContacts(order []models.SortField, keys []string, backward bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(keys) == 0 {
+			return db
+		}
+
+		if len(keys) != len(order) {
+			_ = db.AddError(apperrors.Validation("the cursor does not match the sort of the listing", nil))
+			return db
+		}
+
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			value, err := contactFields[order[i].Field].parse(key)
+			if err != nil {
+				_ = db.AddError(apperrors.Validation("the cursor is invalid", err))
+				return db
+			}
+
+			values[i] = value
+		}
+
+		conditions := make([]string, 0, len(order))
+		var args []interface{}
+
+		for i, sortField := range order {
+			parts := make([]string, 0, i+1)
+
+			for j := 0; j < i; j++ {
+				parts = append(parts, contactFields[order[j].Field].column+" = ?")
+				args = append(args, values[j])
+			}
+
+			operator := ">"
+			if sortField.Desc != backward {
+				operator = "<"
+			}
+
+			parts = append(parts, fmt.Sprintf("%s %s ?", contactFields[sortField.Field].column, operator))
+			args = append(args, values[i])
+			conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+		}
+
+		return db.Where(strings.Join(conditions, " OR "), args...)
+	}
+}
+
+// contactKeys returns the cursor keys of contact for order.
+func contactKeys(contact models.Contact, order []models.SortField) []string {
+	keys := make([]string, len(order))
+	for i, sortField := range order {
+		keys[i] = contactFields[sortField.Field].key(contact)
+	}
+
+	return keys
+}
+
+func parseString(key string) (interface{}, error) {
+	return key, nil
+}
+
+func unknownField(field string) error {
+	return apperrors.Validation(fmt.Sprintf("unknown contact field %q", field), nil)
+}
@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// groupNestingLock is the key of the advisory lock held while subgroups are
+// added, so two concurrent additions can not close a cycle between them.
+const groupNestingLock = 7_162_001
+
+// descendantGroups selects the ids of every group nested, at any depth, in the
+// group given as parameter. UNION stops the recursion on groups already seen.
+const descendantGroups = `WITH RECURSIVE descendants(id) AS (
+	SELECT member_group_id FROM group_subgroups WHERE group_id = ?
+	UNION
+	SELECT group_subgroups.member_group_id FROM group_subgroups
+	JOIN descendants ON group_subgroups.group_id = descendants.id
+) SELECT id FROM descendants`
+
+type Groups interface {
+	Create(group models.Group) (models.Group, error)
+	GetByID(id uint) (models.Group, error)
+	Update(id uint, group models.Group) (models.Group, error)
+	Delete(id uint) error
+	Get() ([]models.Group, error)
+	AddMembers(id uint, contactIDs []uint, groupIDs []uint) error
+	RemoveMembers(id uint, contactIDs []uint, groupIDs []uint) error
+	Members(id uint) ([]models.Contact, error)
+}
+
+// groupContact and groupSubgroup are rows of the join tables of the members of
+// a group.
+type groupContact struct {
+	GroupID   uint
+	ContactID uint
+}
+
+func (groupContact) TableName() string {
+	return "group_contacts"
+}
+
+type groupSubgroup struct {
+	GroupID       uint
+	MemberGroupID uint
+}
+
+func (groupSubgroup) TableName() string {
+	return "group_subgroups"
+}
+
+type groups struct {
+	db *gorm.DB
+}
+
+func NewGroups(db *gorm.DB) Groups {
+	return &groups{
+		db,
+	}
+}
+
+func (repo *groups) Create(group models.Group) (models.Group, error) {
+	if err := repo.db.Omit(clause.Associations).Create(&group).Error; err != nil {
+		return models.Group{}, translateError(err, "", groupExists(group.Name))
+	}
+
+	return group, nil
+}
+
+// GetByID returns the group with its direct members.
+func (repo *groups) GetByID(id uint) (models.Group, error) {
+	var group models.Group
+
+	err := repo.db.
+		Preload("Contacts", func(db *gorm.DB) *gorm.DB { return db.Order("name, id") }).
+		Preload("Groups", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		First(&group, id).Error
+	if err != nil {
+		return group, translateError(err, groupNotFound(id), "")
+	}
+
+	return group, nil
+}
+
+func (repo *groups) Update(id uint, group models.Group) (models.Group, error) {
+	group.ID = id
+
+	result := repo.db.Model(&group).Select("*").Omit("id", clause.Associations).Updates(&group)
+	if result.Error != nil {
+		return models.Group{}, translateError(result.Error, groupNotFound(id), groupExists(group.Name))
+	}
+
+	if result.RowsAffected == 0 {
+		return models.Group{}, apperrors.NotFound(groupNotFound(id), nil)
+	}
+
+	return group, nil
+}
+
+// Delete removes the group and its memberships, including the ones in the groups
+// it was nested in.
+func (repo *groups) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&groupContact{}).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		err := tx.Where("group_id = ? OR member_group_id = ?", id, id).Delete(&groupSubgroup{}).Error
+		if err != nil {
+			return translateError(err, "", "")
+		}
+
+		result := tx.Delete(&models.Group{}, id)
+		if result.Error != nil {
+			return translateError(result.Error, groupNotFound(id), "")
+		}
+
+		if result.RowsAffected == 0 {
+			return apperrors.NotFound(groupNotFound(id), nil)
+		}
+
+		return nil
+	})
+}
+
+func (repo *groups) Get() ([]models.Group, error) {
+	var groups []models.Group
+
+	if err := repo.db.Order("name").Find(&groups).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return groups, nil
+}
+
+// AddMembers adds the contacts and subgroups to the group, members already in it
+// are left as they are. A subgroup that contains the group, at any depth, is
+// rejected because it would create a cycle.
+func (repo *groups) AddMembers(id uint, contactIDs []uint, groupIDs []uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkMembers(tx, id, contactIDs, groupIDs); err != nil {
+			return err
+		}
+
+		if len(contactIDs) > 0 {
+			rows := make([]groupContact, len(contactIDs))
+			for i, contactID := range contactIDs {
+				rows[i] = groupContact{GroupID: id, ContactID: contactID}
+			}
+
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+				return translateError(err, "", "")
+			}
+		}
+
+		if len(groupIDs) == 0 {
+			return nil
+		}
+
+		if isPostgres(tx) {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", groupNestingLock).Error; err != nil {
+				return translateError(err, "", "")
+			}
+		}
+
+		rows := make([]groupSubgroup, len(groupIDs))
+		for i, groupID := range groupIDs {
+			if err := checkCycle(tx, id, groupID); err != nil {
+				return err
+			}
+
+			rows[i] = groupSubgroup{GroupID: id, MemberGroupID: groupID}
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		return nil
+	})
+}
+
+// RemoveMembers removes the contacts and subgroups from the group, the ones that
+// are not members are ignored.
+func (repo *groups) RemoveMembers(id uint, contactIDs []uint, groupIDs []uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkMembers(tx, id, contactIDs, groupIDs); err != nil {
+			return err
+		}
+
+		if len(contactIDs) > 0 {
+			err := tx.Where("group_id = ? AND contact_id IN ?", id, contactIDs).Delete(&groupContact{}).Error
+			if err != nil {
+				return translateError(err, "", "")
+			}
+		}
+
+		if len(groupIDs) > 0 {
+			err := tx.Where("group_id = ? AND member_group_id IN ?", id, groupIDs).Delete(&groupSubgroup{}).Error
+			if err != nil {
+				return translateError(err, "", "")
+			}
+		}
+
+		return nil
+	})
+}
+
+// Members returns every contact of the group and of the groups nested in it, at
+// any depth. A contact reached through several groups is returned once.
+func (repo *groups) Members(id uint) ([]models.Contact, error) {
+	if err := repo.db.Select("id").First(&models.Group{}, id).Error; err != nil {
+		return nil, translateError(err, groupNotFound(id), "")
+	}
+
+	var contacts []models.Contact
+
+	groupIDs := repo.db.Raw(descendantGroups+" UNION SELECT ?", id, id)
+	memberIDs := repo.db.Model(&groupContact{}).Select("contact_id").Where("group_id IN (?)", groupIDs)
+
+	err := repo.db.
+		Scopes(preloadChildren).
+		Where("id IN (?)", memberIDs).
+		Order("name, id").
+		Find(&contacts).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return contacts, nil
+}
+
+// checkMembers checks that the group and the members being added or removed
+// exist.
+func checkMembers(tx *gorm.DB, id uint, contactIDs []uint, groupIDs []uint) error {
+	if err := tx.Select("id").First(&models.Group{}, id).Error; err != nil {
+		return translateError(err, groupNotFound(id), "")
+	}
+
+	exist, err := allExist(tx, &models.Contact{}, contactIDs)
+	if err != nil {
+		return err
+	}
+
+	if !exist {
+		return apperrors.Validation("some of the contacts do not exist", nil)
+	}
+
+	if exist, err = allExist(tx, &models.Group{}, groupIDs); err != nil {
+		return err
+	}
+
+	if !exist {
+		return apperrors.Validation("some of the groups do not exist", nil)
+	}
+
+	return nil
+}
+
+// checkCycle fails when nesting member in the group id would make a group
+// contain itself.
+func checkCycle(tx *gorm.DB, id uint, member uint) error {
+	if id == member {
+		return apperrors.Conflict(fmt.Sprintf("the group: %v can not contain itself", id), nil)
+	}
+
+	var nested []uint
+	if err := tx.Raw(descendantGroups, member).Scan(&nested).Error; err != nil {
+		return translateError(err, "", "")
+	}
+
+	for _, nestedID := range nested {
+		if nestedID == id {
+			return apperrors.Conflict(fmt.Sprintf(
+				"adding the group: %v to the group: %v would create a cycle, it already contains it", member, id), nil)
+		}
+	}
+
+	return nil
+}
+
+// allExist reports whether there is a row of model for every id in ids, which
+// must not have repetitions.
+func allExist(tx *gorm.DB, model interface{}, ids []uint) (bool, error) {
+	if len(ids) == 0 {
+		return true, nil
+	}
+
+	var found int64
+	if err := tx.Model(model).Where("id IN ?", ids).Count(&found).Error; err != nil {
+		return false, translateError(err, "", "")
+	}
+
+	return int(found) == len(ids), nil
+}
+
+func groupNotFound(id uint) string {
+	return fmt.Sprintf("the group: %v does not exist", id)
+}
+
+func groupExists(name string) string {
+	return fmt.Sprintf("the group %s already exists", name)
+}
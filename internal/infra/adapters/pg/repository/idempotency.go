@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Idempotency interface {
+	Reserve(request models.IdempotentRequest, now time.Time) (models.IdempotentRequest, bool, error)
+	Complete(request models.IdempotentRequest) error
+	Release(key string) error
+	PurgeExpired(now time.Time) (int64, error)
+}
+
+type idempotency struct {
+	db *gorm.DB
+}
+
+func NewIdempotency(db *gorm.DB) Idempotency {
+	return &idempotency{
+		db,
+	}
+}
+
+// Reserve saves request unless its key is already taken by a request that did
+// not expire at now and is not still processed past its lease. It reports if
+// the key was reserved and, when it was not, returns the request holding it.
+func (repo *idempotency) Reserve(request models.IdempotentRequest, now time.Time) (models.IdempotentRequest,
+	bool, error) {
+	var stored models.IdempotentRequest
+	var reserved bool
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&request)
+		if result.Error != nil {
+			return translateError(result.Error, "", "")
+		}
+
+		if result.RowsAffected == 0 {
+			result = tx.
+				Model(&models.IdempotentRequest{Key: request.Key}).
+				Where("expires_at <= ? OR (status = 0 AND locked_until <= ?)", now, now).
+				Select("*").
+				Updates(&request)
+			if result.Error != nil {
+				return translateError(result.Error, "", "")
+			}
+		}
+
+		if result.RowsAffected > 0 {
+			reserved = true
+			stored = request
+
+			return nil
+		}
+
+		return translateError(tx.Where("key = ?", request.Key).Take(&stored).Error, "", "")
+	})
+
+	return stored, reserved, err
+}
+
+// Complete keeps the response of a reserved request.
+func (repo *idempotency) Complete(request models.IdempotentRequest) error {
+	err := repo.db.
+		Model(&models.IdempotentRequest{Key: request.Key}).
+		Select("status", "header", "body").
+		Updates(&request).Error
+
+	return translateError(err, "", "")
+}
+
+// Release frees the key of a request, so it can be sent again.
+func (repo *idempotency) Release(key string) error {
+	return translateError(repo.db.Where("key = ?", key).Delete(&models.IdempotentRequest{}).Error, "", "")
+}
+
+// PurgeExpired deletes the requests that expired at now and returns how many.
+func (repo *idempotency) PurgeExpired(now time.Time) (int64, error) {
+	result := repo.db.Where("expires_at <= ?", now).Delete(&models.IdempotentRequest{})
+
+	return result.RowsAffected, translateError(result.Error, "", "")
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// Audited fields that tell, in the merge entries, which contacts were merged.
+const (
+	mergedContactsField = "merged_contacts"
+	mergedIntoField     = "merged_into"
+)
+
+// repointTags and repointGroups move the tags and group memberships of the
+// contacts given as second parameter to the contact given as first one.
+const (
+	repointTags = `INSERT INTO contact_tags (contact_id, tag_id)
+	SELECT ?, tag_id FROM contact_tags WHERE contact_id IN ? ON CONFLICT DO NOTHING`
+	repointGroups = `INSERT INTO group_contacts (group_id, contact_id)
+	SELECT group_id, ? FROM group_contacts WHERE contact_id IN ? ON CONFLICT DO NOTHING`
+)
+
+// Merge writes the merged content of the survivor, moves the merged contacts to
+// the trash and gives their tags and groups to the survivor. Every contact must
+// still be at the version it was read at. The survivor gets a new version and
+// revision, and the merge is audited on behalf of origin for every contact.
+func (repo *contacts) Merge(origin models.Origin, merge models.Merge) (models.Contact, error) {
+	survivor := merge.Survivor
+
+	ids := make([]uint, len(merge.Merged))
+	for i, contact := range merge.Merged {
+		ids[i] = contact.ID
+	}
+
+	var merged *models.Contact
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		current, err := lockContact(tx, survivor.ID, survivor.Version)
+		if err != nil {
+			return err
+		}
+
+		others, err := lockContacts(tx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, contact := range merge.Merged {
+			other, found := others[contact.ID]
+			if !found {
+				return apperrors.NotFound(contactNotFound(contact.ID), nil)
+			}
+
+			if other.Version != contact.Version {
+				return contactModified(contact.ID)
+			}
+		}
+
+		before, err := snapshot(tx, survivor.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Where("id IN ?", ids).Delete(&models.Contact{}).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		if err = writeContact(tx, &survivor, current.Version); err != nil {
+			return err
+		}
+
+		for _, statement := range []string{repointTags, repointGroups} {
+			if err = tx.Exec(statement, survivor.ID, ids).Error; err != nil {
+				return translateError(err, "", "")
+			}
+		}
+
+		if err = tx.Where("contact_id IN ?", ids).Delete(&contactTag{}).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		if err = tx.Where("contact_id IN ?", ids).Delete(&groupContact{}).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		if merged, err = snapshot(tx, survivor.ID); err != nil {
+			return err
+		}
+
+		if err = recordRevision(tx, merged); err != nil {
+			return err
+		}
+
+		return recordMerge(tx, origin, before, merged, ids, others)
+	})
+
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return *merged, nil
+}
+
+// recordMerge audits the merge of the contacts of ids, which were others, into
+// the survivor, which went from before to after. The entry of the survivor names
+// the merged contacts and the entries of the merged contacts name the survivor.
+func recordMerge(tx *gorm.DB, origin models.Origin, before, after *models.Contact, ids []uint,
+	others map[uint]*models.Contact) error {
+	trashed, err := snapshots(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	entry, err := auditEntry(origin, models.AuditMerge, before, after)
+	if err != nil {
+		return err
+	}
+
+	entry.Changes[mergedContactsField] = models.Change{After: ids}
+	entries := []models.AuditEntry{entry}
+
+	for _, id := range ids {
+		entry, err = auditEntry(origin, models.AuditMerge, others[id], trashed[id])
+		if err != nil {
+			return err
+		}
+
+		entry.Changes[mergedIntoField] = models.Change{After: after.ID}
+		entries = append(entries, entry)
+	}
+
+	return writeEntries(tx, entries...)
+}
@@ -0,0 +1,194 @@
+package repositorytest
+
+import (
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// AddressBookSuite is the contract of repository.AddressBook, New is called as
+// the one of ContactsSuite.
+type AddressBookSuite struct {
+	suite.Suite
+	New func() Repositories
+
+	repo Repositories
+}
+
+func (suite *AddressBookSuite) SetupTest() {
+	suite.repo = suite.New()
+}
+
+func (suite *AddressBookSuite) TestSaveResource_WhenSuccess() {
+	ana := suite.createContact("Ana", "+573001111111")
+	bob := suite.createContact("Bob", "+573002222222")
+
+	suite.NoError(suite.repo.AddressBook.SaveResource(models.CardResource{Name: "ana.vcf", ContactID: ana.ID,
+		UID: "ana"}))
+	suite.NoError(suite.repo.AddressBook.SaveResource(models.CardResource{Name: "ana-perez.vcf",
+		ContactID: ana.ID, UID: "ana"}))
+
+	_, err := suite.repo.AddressBook.GetResource("ana.vcf")
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+
+	resource, err := suite.repo.AddressBook.GetResource("ana-perez.vcf")
+
+	suite.NoError(err)
+	suite.Equal(ana.ID, resource.ContactID)
+	suite.Equal("ana", resource.UID)
+
+	found, err := suite.repo.AddressBook.HasResource(bob.ID)
+
+	suite.NoError(err)
+	suite.False(found)
+
+	resources, err := suite.repo.AddressBook.GetResources([]uint{ana.ID, bob.ID})
+
+	suite.NoError(err)
+	suite.Len(resources, 1)
+	suite.Equal("ana-perez.vcf", resources[0].Name)
+}
+
+func (suite *AddressBookSuite) TestSaveResource_WhenNameMovesToAnotherContact() {
+	ana := suite.createContact("Ana", "+573001111111")
+
+	suite.NoError(suite.repo.AddressBook.SaveResource(models.CardResource{Name: "ana.vcf", ContactID: ana.ID}))
+	suite.NoError(suite.repo.Contacts.Delete(origin, ana.ID, 0))
+
+	bob := suite.createContact("Bob", "+573002222222")
+
+	suite.NoError(suite.repo.AddressBook.SaveResource(models.CardResource{Name: "ana.vcf", ContactID: bob.ID}))
+
+	resource, err := suite.repo.AddressBook.GetResource("ana.vcf")
+
+	suite.NoError(err)
+	suite.Equal(bob.ID, resource.ContactID)
+
+	found, err := suite.repo.AddressBook.HasResource(ana.ID)
+
+	suite.NoError(err)
+	suite.False(found)
+}
+
+func (suite *AddressBookSuite) TestGetResources_WhenContactIsPurged() {
+	ana := suite.createContact("Ana", "+573001111111")
+	bob := suite.createContact("Bob", "+573002222222")
+
+	suite.NoError(suite.repo.AddressBook.SaveResource(models.CardResource{Name: "ana.vcf", ContactID: ana.ID}))
+	suite.NoError(suite.repo.Contacts.Delete(origin, ana.ID, 0))
+	suite.NoError(suite.repo.Contacts.Delete(origin, bob.ID, 0))
+	suite.NoError(suite.repo.Contacts.Purge(origin, ana.ID))
+	suite.NoError(suite.repo.Contacts.Purge(origin, bob.ID))
+
+	resources, err := suite.repo.AddressBook.GetResources([]uint{ana.ID, bob.ID})
+
+	suite.NoError(err)
+	suite.Len(resources, 1)
+	suite.Equal("ana.vcf", resources[0].Name)
+	suite.Equal(ana.ID, resources[0].ContactID)
+
+	_, err = suite.repo.AddressBook.GetResource("ana.vcf")
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+
+	found, err := suite.repo.AddressBook.HasResource(ana.ID)
+
+	suite.NoError(err)
+	suite.False(found)
+}
+
+func (suite *AddressBookSuite) TestGetResources_WhenNameOfPurgedContactIsReused() {
+	ana := suite.createContact("Ana", "+573001111111")
+
+	suite.NoError(suite.repo.AddressBook.SaveResource(models.CardResource{Name: "ana.vcf", ContactID: ana.ID}))
+	suite.NoError(suite.repo.Contacts.Delete(origin, ana.ID, 0))
+
+	_, err := suite.repo.Contacts.PurgeDeletedBefore(origin, time.Now().Add(time.Hour))
+	suite.NoError(err)
+
+	bob := suite.createContact("Bob", "+573002222222")
+
+	suite.NoError(suite.repo.AddressBook.SaveResource(models.CardResource{Name: "ana.vcf", ContactID: bob.ID}))
+
+	resources, err := suite.repo.AddressBook.GetResources([]uint{ana.ID, bob.ID})
+
+	suite.NoError(err)
+	suite.Len(resources, 1)
+	suite.Equal(bob.ID, resources[0].ContactID)
+}
+
+func (suite *AddressBookSuite) TestChanges_WhenSuccess() {
+	token, err := suite.repo.AddressBook.SyncToken()
+
+	suite.NoError(err)
+	suite.Zero(token)
+
+	ana := suite.createContact("Ana", "+573001111111")
+	bob := suite.createContact("Bob", "+573002222222")
+
+	token, err = suite.repo.AddressBook.SyncToken()
+	suite.NoError(err)
+
+	carla := suite.createContact("Carla", "+573003333333")
+	_, err = suite.repo.Contacts.Update(origin, ana.ID, models.Contact{Name: "Ana María",
+		PhoneNumber: "+573001111111"}, 1)
+	suite.Require().NoError(err)
+
+	changes, err := suite.repo.AddressBook.Changes(token)
+
+	suite.NoError(err)
+	suite.Equal([]uint{carla.ID, ana.ID}, changes.ContactIDs)
+
+	last, err := suite.repo.AddressBook.SyncToken()
+
+	suite.NoError(err)
+	suite.Equal(last, changes.Token)
+
+	changes, err = suite.repo.AddressBook.Changes(last)
+
+	suite.NoError(err)
+	suite.Empty(changes.ContactIDs)
+	suite.Equal(last, changes.Token)
+
+	changes, err = suite.repo.AddressBook.Changes(0)
+
+	suite.NoError(err)
+	suite.Equal([]uint{bob.ID, carla.ID, ana.ID}, changes.ContactIDs)
+}
+
+// TestChanges_WhenTagsChange checks that the token moves with every new version
+// of a contact, its ETag.
+func (suite *AddressBookSuite) TestChanges_WhenTagsChange() {
+	ana := suite.createContact("Ana", "+573001111111")
+
+	tag, err := suite.repo.Tags.Create(models.Tag{Name: "family"})
+	suite.Require().NoError(err)
+
+	for _, change := range []func() error{
+		func() error { return suite.repo.Tags.Assign(origin, []uint{ana.ID}, []string{"family"}) },
+		func() error {
+			_, err := suite.repo.Tags.Update(origin, tag.ID, models.Tag{Name: "relatives"})
+			return err
+		},
+		func() error { return suite.repo.Tags.Delete(origin, tag.ID) },
+	} {
+		token, err := suite.repo.AddressBook.SyncToken()
+		suite.NoError(err)
+
+		suite.NoError(change())
+
+		changes, err := suite.repo.AddressBook.Changes(token)
+
+		suite.NoError(err)
+		suite.Equal([]uint{ana.ID}, changes.ContactIDs)
+		suite.Greater(changes.Token, token)
+	}
+}
+
+func (suite *AddressBookSuite) createContact(name, phoneNumber string) models.Contact {
+	contact, err := suite.repo.Contacts.Create(origin, models.Contact{Name: name, PhoneNumber: phoneNumber})
+	suite.Require().NoError(err)
+
+	return contact
+}
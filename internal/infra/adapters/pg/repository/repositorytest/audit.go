@@ -0,0 +1,89 @@
+package repositorytest
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// AuditSuite is the contract of repository.Audit, New is called as the one of
+// ContactsSuite.
+type AuditSuite struct {
+	suite.Suite
+	New func() Repositories
+
+	repo Repositories
+}
+
+func (suite *AuditSuite) SetupTest() {
+	suite.repo = suite.New()
+}
+
+func (suite *AuditSuite) TestGet_WhenFiltered() {
+	editor := models.Origin{Actor: "editor", RequestID: "editing"}
+
+	ana, err := suite.repo.Contacts.Create(origin, models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	suite.Require().NoError(err)
+	_, err = suite.repo.Contacts.Create(origin, models.Contact{Name: "Bob", PhoneNumber: "+573002222222"})
+	suite.Require().NoError(err)
+	_, err = suite.repo.Contacts.Update(editor, ana.ID, models.Contact{Name: "Ana María",
+		PhoneNumber: "+573001111111"}, 1)
+	suite.Require().NoError(err)
+
+	entries, err := suite.repo.Audit.Get(models.Paginator{Page: 1, Limit: 10}, models.AuditFilter{})
+
+	suite.NoError(err)
+	suite.Equal(int64(3), entries.TotalRecord)
+	suite.Equal(models.AuditUpdate, entries.Records.([]models.AuditEntry)[0].Action)
+
+	entries, err = suite.repo.Audit.Get(models.Paginator{Page: 1, Limit: 10}, models.AuditFilter{Actor: "editor"})
+
+	suite.NoError(err)
+	suite.Equal(int64(1), entries.TotalRecord)
+
+	entry := entries.Records.([]models.AuditEntry)[0]
+	suite.Equal(ana.ID, entry.ContactID)
+	suite.Equal("editing", entry.RequestID)
+	suite.Equal(models.Change{Before: "Ana", After: "Ana María"}, entry.Changes["name"])
+
+	entries, err = suite.repo.Audit.Get(models.Paginator{Page: 1, Limit: 10},
+		models.AuditFilter{Action: models.AuditCreate, ContactID: ana.ID})
+
+	suite.NoError(err)
+	suite.Equal(int64(1), entries.TotalRecord)
+	suite.Equal("contract", entries.Records.([]models.AuditEntry)[0].Actor)
+}
+
+func (suite *AuditSuite) TestGet_WhenPaginated() {
+	for _, phoneNumber := range []string{"+573001111111", "+573002222222", "+573003333333"} {
+		_, err := suite.repo.Contacts.Create(origin, models.Contact{Name: "Ana", PhoneNumber: phoneNumber})
+		suite.Require().NoError(err)
+	}
+
+	entries, err := suite.repo.Audit.Get(models.Paginator{Page: 2, Limit: 2}, models.AuditFilter{})
+
+	suite.NoError(err)
+	suite.Equal(int64(3), entries.TotalRecord)
+	suite.Equal(2, entries.TotalPage)
+	suite.Len(entries.Records, 1)
+}
+
+func (suite *AuditSuite) TestHistory_WhenPurged() {
+	ana, err := suite.repo.Contacts.Create(origin, models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	suite.Require().NoError(err)
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, ana.ID, 0))
+	suite.NoError(suite.repo.Contacts.Purge(origin, ana.ID))
+
+	history, err := suite.repo.Audit.History(ana.ID, models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal(int64(3), history.TotalRecord)
+	suite.Equal(models.AuditPurge, history.Records.([]models.AuditEntry)[0].Action)
+}
+
+func (suite *AuditSuite) TestHistory_WhenNotFound() {
+	_, err := suite.repo.Audit.History(99, models.Paginator{Page: 1, Limit: 10})
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
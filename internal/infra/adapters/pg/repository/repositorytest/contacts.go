@@ -0,0 +1,672 @@
+// Package repositorytest holds the contract every storage backend of the
+// repositories must honor. Each backend runs the suites over its own store, so
+// the service behaves the same whichever of them it is started with.
+package repositorytest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+// Repositories are the repositories of a backend sharing the same store.
+type Repositories struct {
+	Contacts    repository.Contacts
+	Tags        repository.Tags
+	Groups      repository.Groups
+	Audit       repository.Audit
+	AddressBook repository.AddressBook
+	Idempotency repository.Idempotency
+}
+
+// ContactsSuite is the contract of repository.Contacts. New returns the
+// repositories of the backend over an empty store, it is called before every
+// test.
+type ContactsSuite struct {
+	suite.Suite
+	New func() Repositories
+
+	repo Repositories
+}
+
+var origin = models.Origin{Actor: "contract", RequestID: "request"}
+
+func (suite *ContactsSuite) SetupTest() {
+	suite.repo = suite.New()
+}
+
+func (suite *ContactsSuite) TestCreate_WhenSuccess() {
+	created := suite.create(models.Contact{
+		Name:        "Ana Pérez",
+		PhoneNumber: "+573001111111",
+		Phones:      []models.Phone{{Label: "work", Number: "+573002222222"}},
+		Emails:      []models.Email{{Label: "home", Address: "ana@example.com", Primary: true}},
+		Addresses:   []models.Address{{Label: "home", City: "Bogotá"}},
+	})
+
+	suite.NotZero(created.ID)
+	suite.Equal(uint(1), created.Version)
+
+	contact, err := suite.repo.Contacts.GetByID(created.ID)
+
+	suite.NoError(err)
+	suite.Equal("Ana Pérez", contact.Name)
+	suite.Equal("+573001111111", contact.PhoneNumber)
+	suite.Equal(uint(1), contact.Version)
+	suite.Len(contact.Phones, 1)
+	suite.Equal("+573002222222", contact.Phones[0].Number)
+	suite.Len(contact.Emails, 1)
+	suite.NotZero(contact.Emails[0].ID)
+	suite.Equal("ana@example.com", contact.Emails[0].Address)
+	suite.Len(contact.Addresses, 1)
+	suite.Equal("Bogotá", contact.Addresses[0].City)
+	suite.Empty(contact.Tags)
+}
+
+func (suite *ContactsSuite) TestCreate_WhenPhoneNumberIsTaken() {
+	suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	_, err := suite.repo.Contacts.Create(origin, models.Contact{Name: "Bob", PhoneNumber: "+573001111111"})
+
+	assertKind(&suite.Suite, apperrors.KindConflict, err)
+}
+
+func (suite *ContactsSuite) TestGetByID_WhenNotFound() {
+	_, err := suite.repo.Contacts.GetByID(99)
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
+
+func (suite *ContactsSuite) TestGetByPhoneNumbers_WhenSuccess() {
+	ana := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	bob := suite.create(models.Contact{Name: "Bob", PhoneNumber: "+573002222222"})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, bob.ID, 0))
+
+	contacts, err := suite.repo.Contacts.GetByPhoneNumbers([]string{"+573001111111", "+573002222222", "+1"})
+
+	suite.NoError(err)
+	suite.Len(contacts, 1)
+	suite.Equal(ana.ID, contacts[0].ID)
+	suite.Equal(uint(1), contacts[0].Version)
+}
+
+func (suite *ContactsSuite) TestUpdate_WhenSuccess() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111",
+		Emails: []models.Email{{Label: "home", Address: "ana@example.com"}}})
+
+	_, err := suite.repo.Contacts.Update(origin, created.ID, models.Contact{Name: "Ana María",
+		PhoneNumber: "+573001111111", Emails: []models.Email{{Label: "work", Address: "ana@work.com"}}}, 1)
+	suite.NoError(err)
+
+	contact, err := suite.repo.Contacts.GetByID(created.ID)
+
+	suite.NoError(err)
+	suite.Equal("Ana María", contact.Name)
+	suite.Equal(uint(2), contact.Version)
+	suite.Len(contact.Emails, 1)
+	suite.Equal("ana@work.com", contact.Emails[0].Address)
+
+	revision, err := suite.repo.Contacts.GetRevision(created.ID, 1)
+
+	suite.NoError(err)
+	suite.Equal("Ana", revision.Contact.Name)
+}
+
+func (suite *ContactsSuite) TestUpdate_WhenTagged() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	_, err := suite.repo.Tags.Create(models.Tag{Name: "family"})
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.repo.Tags.Assign(origin, []uint{created.ID}, []string{"family"}))
+
+	updated, err := suite.repo.Contacts.Update(origin, created.ID, models.Contact{Name: "Ana María",
+		PhoneNumber: "+573001111111"}, 2)
+
+	suite.NoError(err)
+	suite.Equal("Ana María", updated.Name)
+	suite.Equal(uint(3), updated.Version)
+	suite.Equal([]string{"family"}, tagNames(updated.Tags))
+
+	contact, err := suite.repo.Contacts.GetByID(created.ID)
+
+	suite.NoError(err)
+	suite.Equal([]string{"family"}, tagNames(contact.Tags))
+}
+
+func (suite *ContactsSuite) TestUpdate_WhenVersionIsStale() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	_, err := suite.repo.Contacts.Update(origin, created.ID, models.Contact{Name: "Ana", PhoneNumber: "+5730011"}, 2)
+
+	assertKind(&suite.Suite, apperrors.KindPreconditionFailed, err)
+}
+
+func (suite *ContactsSuite) TestUpdate_WhenPhoneNumberIsTaken() {
+	suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	bob := suite.create(models.Contact{Name: "Bob", PhoneNumber: "+573002222222"})
+
+	_, err := suite.repo.Contacts.Update(origin, bob.ID, models.Contact{Name: "Bob", PhoneNumber: "+573001111111"}, 0)
+
+	assertKind(&suite.Suite, apperrors.KindConflict, err)
+}
+
+func (suite *ContactsSuite) TestUpdate_WhenNotFound() {
+	_, err := suite.repo.Contacts.Update(origin, 99, models.Contact{Name: "Ana", PhoneNumber: "+573001111111"}, 0)
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
+
+func (suite *ContactsSuite) TestDelete_WhenSuccess() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, created.ID, 1))
+
+	_, err := suite.repo.Contacts.GetByID(created.ID)
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, suite.repo.Contacts.Delete(origin, created.ID, 0))
+
+	trash, err := suite.repo.Contacts.GetTrash(models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal(int64(1), trash.TotalRecord)
+
+	contacts := trash.Records.([]models.Contact)
+	suite.Equal(created.ID, contacts[0].ID)
+	suite.True(contacts[0].DeletedAt.Valid)
+}
+
+func (suite *ContactsSuite) TestDelete_WhenVersionIsStale() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	assertKind(&suite.Suite, apperrors.KindPreconditionFailed, suite.repo.Contacts.Delete(origin, created.ID, 2))
+}
+
+func (suite *ContactsSuite) TestGetTrash_WhenSuccess() {
+	ana := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	bob := suite.create(models.Contact{Name: "Bob", PhoneNumber: "+573002222222"})
+	suite.create(models.Contact{Name: "Carla", PhoneNumber: "+573003333333"})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, ana.ID, 0))
+	suite.NoError(suite.repo.Contacts.Delete(origin, bob.ID, 0))
+
+	trash, err := suite.repo.Contacts.GetTrash(models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal([]uint{bob.ID, ana.ID}, contactIDs(trash.Records))
+}
+
+func (suite *ContactsSuite) TestRestore_WhenSuccess() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, created.ID, 0))
+
+	restored, err := suite.repo.Contacts.Restore(origin, created.ID)
+
+	suite.NoError(err)
+	suite.Equal(uint(2), restored.Version)
+	suite.False(restored.DeletedAt.Valid)
+
+	_, err = suite.repo.Contacts.GetByID(created.ID)
+	suite.NoError(err)
+}
+
+func (suite *ContactsSuite) TestRestore_WhenPhoneNumberIsTaken() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, created.ID, 0))
+	suite.create(models.Contact{Name: "Bob", PhoneNumber: "+573001111111"})
+
+	_, err := suite.repo.Contacts.Restore(origin, created.ID)
+
+	assertKind(&suite.Suite, apperrors.KindConflict, err)
+}
+
+func (suite *ContactsSuite) TestRestore_WhenNotInTrash() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	_, err := suite.repo.Contacts.Restore(origin, created.ID)
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
+
+func (suite *ContactsSuite) TestPurge_WhenSuccess() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111",
+		Phones: []models.Phone{{Label: "work", Number: "+573002222222"}}})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, created.ID, 0))
+	suite.NoError(suite.repo.Contacts.Purge(origin, created.ID))
+
+	_, err := suite.repo.Contacts.Restore(origin, created.ID)
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+
+	_, err = suite.repo.Contacts.GetRevisions(created.ID, models.Paginator{Page: 1, Limit: 10})
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
+
+func (suite *ContactsSuite) TestPurge_WhenNotInTrash() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, suite.repo.Contacts.Purge(origin, created.ID))
+}
+
+func (suite *ContactsSuite) TestPurgeDeletedBefore_WhenSuccess() {
+	ana := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	bob := suite.create(models.Contact{Name: "Bob", PhoneNumber: "+573002222222"})
+	suite.create(models.Contact{Name: "Carla", PhoneNumber: "+573003333333"})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, ana.ID, 0))
+	suite.NoError(suite.repo.Contacts.Delete(origin, bob.ID, 0))
+
+	purged, err := suite.repo.Contacts.PurgeDeletedBefore(origin, time.Now().Add(-time.Hour))
+
+	suite.NoError(err)
+	suite.Zero(purged)
+
+	purged, err = suite.repo.Contacts.PurgeDeletedBefore(origin, time.Now().Add(time.Minute))
+
+	suite.NoError(err)
+	suite.Equal(int64(2), purged)
+
+	trash, err := suite.repo.Contacts.GetTrash(models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Zero(trash.TotalRecord)
+}
+
+func (suite *ContactsSuite) TestGet_WhenPaginatedByOffset() {
+	for _, name := range []string{"Ana", "Bob", "Carla", "Dario", "Elena"} {
+		suite.create(models.Contact{Name: name, PhoneNumber: "+57300" + name})
+	}
+
+	page, err := suite.repo.Contacts.Get(models.Paginator{Page: 2, Limit: 2},
+		models.ContactFilter{Sort: []models.SortField{{Field: "name"}}})
+
+	suite.NoError(err)
+	suite.Equal(int64(5), page.TotalRecord)
+	suite.Equal(3, page.TotalPage)
+	suite.Equal(1, page.PrevPage)
+	suite.Equal(3, page.NextPage)
+	suite.Equal([]string{"Carla", "Dario"}, contactNames(page.Records))
+}
+
+func (suite *ContactsSuite) TestGet_WhenFiltered() {
+	ana := suite.create(models.Contact{Name: "Ana", StructuredName: models.StructuredName{Nickname: "Anita"},
+		PhoneNumber: "+573001111111"})
+	bob := suite.create(models.Contact{Name: "Bob", PhoneNumber: "+573002222222"})
+	suite.create(models.Contact{Name: "Joana", PhoneNumber: "+13001111111"})
+
+	_, err := suite.repo.Tags.Create(models.Tag{Name: "family"})
+	suite.NoError(err)
+
+	_, err = suite.repo.Tags.Create(models.Tag{Name: "work"})
+	suite.NoError(err)
+
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{ana.ID, bob.ID}, []string{"family"}))
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{bob.ID}, []string{"work"}))
+
+	byName := []models.SortField{{Field: "name"}}
+
+	cases := []struct {
+		filter   models.ContactFilter
+		expected []string
+	}{
+		{models.ContactFilter{Sort: byName}, []string{"Ana", "Bob", "Joana"}},
+		{models.ContactFilter{Search: "ANA", Sort: byName}, []string{"Ana", "Joana"}},
+		{models.ContactFilter{Search: "anita", Sort: byName}, []string{"Ana"}},
+		{models.ContactFilter{Filters: []models.FieldFilter{{Field: "phone_number", Value: "+57", Prefix: true}},
+			Sort: byName}, []string{"Ana", "Bob"}},
+		{models.ContactFilter{Filters: []models.FieldFilter{{Field: "name", Value: "Bob"}}}, []string{"Bob"}},
+		{models.ContactFilter{Tags: []string{"family"}, Sort: byName}, []string{"Ana", "Bob"}},
+		{models.ContactFilter{Tags: []string{"family", "work"}, Sort: byName}, []string{"Bob"}},
+		{models.ContactFilter{Tags: []string{"work", "friends"}, AnyTag: true, Sort: byName}, []string{"Bob"}},
+	}
+
+	for _, tc := range cases {
+		page, err := suite.repo.Contacts.Get(models.Paginator{Page: 1, Limit: 10}, tc.filter)
+
+		suite.NoError(err)
+		suite.Equal(tc.expected, contactNames(page.Records), "filter %+v", tc.filter)
+	}
+}
+
+func (suite *ContactsSuite) TestGet_WhenPaginatedByCursor() {
+	for _, name := range []string{"Ana", "Bob", "Carla", "Dario", "Elena"} {
+		suite.create(models.Contact{Name: name, PhoneNumber: "+57300" + name})
+	}
+
+	filter := models.ContactFilter{Sort: []models.SortField{{Field: "name", Desc: true}}}
+
+	first, err := suite.repo.Contacts.Get(models.Paginator{Limit: 2, Cursor: &models.Cursor{}}, filter)
+
+	suite.NoError(err)
+	suite.Equal([]string{"Elena", "Dario"}, contactNames(first.Records))
+	suite.Equal(int64(5), first.TotalRecord)
+	suite.Nil(first.Prev)
+	suite.NotNil(first.Next)
+
+	second, err := suite.repo.Contacts.Get(models.Paginator{Limit: 2, Cursor: first.Next}, filter)
+
+	suite.NoError(err)
+	suite.Equal([]string{"Carla", "Bob"}, contactNames(second.Records))
+	suite.NotNil(second.Prev)
+	suite.NotNil(second.Next)
+
+	last, err := suite.repo.Contacts.Get(models.Paginator{Limit: 2, Cursor: second.Next}, filter)
+
+	suite.NoError(err)
+	suite.Equal([]string{"Ana"}, contactNames(last.Records))
+	suite.Nil(last.Next)
+
+	back, err := suite.repo.Contacts.Get(models.Paginator{Limit: 2, Cursor: second.Prev}, filter)
+
+	suite.NoError(err)
+	suite.Equal([]string{"Elena", "Dario"}, contactNames(back.Records))
+	suite.Nil(back.Prev)
+}
+
+func (suite *ContactsSuite) TestGet_WhenFieldIsUnknown() {
+	_, err := suite.repo.Contacts.Get(models.Paginator{Page: 1, Limit: 10},
+		models.ContactFilter{Sort: []models.SortField{{Field: "password"}}})
+
+	assertKind(&suite.Suite, apperrors.KindValidation, err)
+}
+
+func (suite *ContactsSuite) TestExport_WhenSuccess() {
+	for _, name := range []string{"Carla", "Ana", "Bob"} {
+		suite.create(models.Contact{Name: name, PhoneNumber: "+57300" + name,
+			Emails: []models.Email{{Label: "home", Address: name + "@example.com"}}})
+	}
+
+	var exported []models.Contact
+
+	err := suite.repo.Contacts.Export(models.ContactFilter{Sort: []models.SortField{{Field: "name"}}},
+		func(contacts []models.Contact) error {
+			exported = append(exported, contacts...)
+			return nil
+		})
+
+	suite.NoError(err)
+	suite.Equal([]string{"Ana", "Bob", "Carla"}, contactNames(exported))
+	suite.Equal("Ana@example.com", exported[0].Emails[0].Address)
+}
+
+func (suite *ContactsSuite) TestExport_WhenWriteFails() {
+	suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	failure := errors.New("some error")
+
+	err := suite.repo.Contacts.Export(models.ContactFilter{}, func([]models.Contact) error {
+		return failure
+	})
+
+	suite.ErrorIs(err, failure)
+}
+
+func (suite *ContactsSuite) TestGetRevisions_WhenSuccess() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	_, err := suite.repo.Contacts.Update(origin, created.ID, models.Contact{Name: "Ana María",
+		PhoneNumber: "+573001111111"}, 0)
+	suite.NoError(err)
+
+	revisions, err := suite.repo.Contacts.GetRevisions(created.ID, models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal(int64(2), revisions.TotalRecord)
+
+	records := revisions.Records.([]models.Revision)
+	suite.Equal(uint(2), records[0].Version)
+	suite.Equal("Ana María", records[0].Contact.Name)
+	suite.Equal(uint(1), records[1].Version)
+
+	revision, err := suite.repo.Contacts.GetRevisionAt(created.ID, time.Now().Add(time.Minute))
+
+	suite.NoError(err)
+	suite.Equal(uint(2), revision.Version)
+
+	_, err = suite.repo.Contacts.GetRevisionAt(created.ID, time.Now().Add(-time.Hour))
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+
+	_, err = suite.repo.Contacts.GetRevision(created.ID, 3)
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
+
+func (suite *ContactsSuite) TestGetRevisions_WhenTagsChange() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	tag, err := suite.repo.Tags.Create(models.Tag{Name: "family"})
+	suite.NoError(err)
+
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{created.ID}, []string{"family"}))
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{created.ID}, []string{"family"}))
+
+	_, err = suite.repo.Tags.Update(origin, tag.ID, models.Tag{Name: "relatives"})
+	suite.NoError(err)
+
+	suite.NoError(suite.repo.Tags.Delete(origin, tag.ID))
+
+	contact, err := suite.repo.Contacts.GetByID(created.ID)
+
+	suite.NoError(err)
+	suite.Equal(uint(4), contact.Version)
+
+	revisions, err := suite.repo.Contacts.GetRevisions(created.ID, models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal(int64(4), revisions.TotalRecord)
+
+	tags := map[uint][]string{}
+	for _, revision := range revisions.Records.([]models.Revision) {
+		tags[revision.Version] = tagNames(revision.Contact.Tags)
+	}
+
+	suite.Equal(map[uint][]string{1: nil, 2: {"family"}, 3: {"relatives"}, 4: nil}, tags)
+}
+
+func (suite *ContactsSuite) TestHistory_WhenTagsChange() {
+	created := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	_, err := suite.repo.Tags.Create(models.Tag{Name: "family"})
+	suite.NoError(err)
+
+	tagger := models.Origin{Actor: "tagger", RequestID: "tagging"}
+	suite.NoError(suite.repo.Tags.Assign(tagger, []uint{created.ID}, []string{"family"}))
+
+	history, err := suite.repo.Audit.History(created.ID, models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal(int64(2), history.TotalRecord)
+
+	entry := history.Records.([]models.AuditEntry)[0]
+	suite.Equal(models.AuditUpdate, entry.Action)
+	suite.Equal("tagger", entry.Actor)
+	suite.Equal("tagging", entry.RequestID)
+	suite.Contains(entry.Changes, "tags")
+	suite.Contains(entry.Changes, "version")
+}
+
+func (suite *ContactsSuite) TestBatch_WhenAtomicAndAnOperationFails() {
+	ana := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+
+	results, err := suite.repo.Contacts.Batch(origin, []models.BatchOperation{
+		{Action: models.BatchCreate, Contact: models.Contact{Name: "Bob", PhoneNumber: "+573002222222"}},
+		{Action: models.BatchUpdate, ID: ana.ID, Version: 2,
+			Contact: models.Contact{Name: "Ana María", PhoneNumber: "+573001111111"}},
+	}, true)
+
+	suite.NoError(err)
+	suite.NoError(results[0].Err)
+	assertKind(&suite.Suite, apperrors.KindPreconditionFailed, results[1].Err)
+
+	page, err := suite.repo.Contacts.Get(models.Paginator{Page: 1, Limit: 10}, models.ContactFilter{})
+
+	suite.NoError(err)
+	suite.Equal([]string{"Ana"}, contactNames(page.Records))
+}
+
+func (suite *ContactsSuite) TestBatch_WhenBestEffort() {
+	ana := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	bob := suite.create(models.Contact{Name: "Bob", PhoneNumber: "+573002222222"})
+
+	results, err := suite.repo.Contacts.Batch(origin, []models.BatchOperation{
+		{Action: models.BatchDelete, ID: ana.ID},
+		{Action: models.BatchCreate, Contact: models.Contact{Name: "Carla", PhoneNumber: "+573001111111"}},
+		{Action: models.BatchUpdate, ID: bob.ID, Contact: models.Contact{Name: "Bob Díaz", PhoneNumber: "+573002222222"}},
+		{Action: models.BatchCreate, Contact: models.Contact{Name: "Dario", PhoneNumber: "+573002222222"}},
+		{Action: models.BatchDelete, ID: 99},
+	}, false)
+
+	suite.NoError(err)
+	suite.Len(results, 5)
+	suite.NoError(results[0].Err)
+	suite.NoError(results[1].Err)
+	suite.Equal("Carla", results[1].Contact.Name)
+	suite.Equal(uint(1), results[1].Contact.Version)
+	suite.NoError(results[2].Err)
+	suite.Equal(uint(2), results[2].Contact.Version)
+	assertKind(&suite.Suite, apperrors.KindConflict, results[3].Err)
+	assertKind(&suite.Suite, apperrors.KindNotFound, results[4].Err)
+
+	page, err := suite.repo.Contacts.Get(models.Paginator{Page: 1, Limit: 10},
+		models.ContactFilter{Sort: []models.SortField{{Field: "name"}}})
+
+	suite.NoError(err)
+	suite.Equal([]string{"Bob Díaz", "Carla"}, contactNames(page.Records))
+}
+
+func (suite *ContactsSuite) TestMerge_WhenSuccess() {
+	ana := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	other := suite.create(models.Contact{Name: "Ana P", PhoneNumber: "+573002222222"})
+
+	_, err := suite.repo.Tags.Create(models.Tag{Name: "family"})
+	suite.NoError(err)
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{other.ID}, []string{"family"}))
+
+	other, err = suite.repo.Contacts.GetByID(other.ID)
+	suite.NoError(err)
+
+	survivor := ana
+	survivor.Name = "Ana Pérez"
+	survivor.Phones = []models.Phone{{Label: "other", Number: "+573002222222"}}
+
+	merged, err := suite.repo.Contacts.Merge(origin, models.Merge{Survivor: survivor, Merged: []models.Contact{other}})
+
+	suite.NoError(err)
+	suite.Equal("Ana Pérez", merged.Name)
+	suite.Equal(uint(2), merged.Version)
+	suite.Len(merged.Phones, 1)
+	suite.Len(merged.Tags, 1)
+	suite.Equal("family", merged.Tags[0].Name)
+
+	_, err = suite.repo.Contacts.GetByID(other.ID)
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+
+	trash, err := suite.repo.Contacts.GetTrash(models.Paginator{Page: 1, Limit: 10})
+
+	suite.NoError(err)
+	suite.Equal([]uint{other.ID}, contactIDs(trash.Records))
+}
+
+func (suite *ContactsSuite) TestMerge_WhenAContactChanged() {
+	ana := suite.create(models.Contact{Name: "Ana", PhoneNumber: "+573001111111"})
+	other := suite.create(models.Contact{Name: "Ana P", PhoneNumber: "+573002222222"})
+	other.Version = 2
+
+	_, err := suite.repo.Contacts.Merge(origin, models.Merge{Survivor: ana, Merged: []models.Contact{other}})
+
+	assertKind(&suite.Suite, apperrors.KindPreconditionFailed, err)
+
+	_, err = suite.repo.Contacts.GetByID(other.ID)
+	suite.NoError(err)
+}
+
+func (suite *ContactsSuite) TestSearch_WhenSuccess() {
+	ana := suite.create(models.Contact{Name: "Ana Pérez", PhoneNumber: "+573001111111"})
+	bob := suite.create(models.Contact{Name: "Bob", Notes: "friend of ana", PhoneNumber: "+573002222222"})
+	gone := suite.create(models.Contact{Name: "Ana Gómez", PhoneNumber: "+573003333333"})
+	suite.create(models.Contact{Name: "Carla", PhoneNumber: "+573004444444"})
+
+	suite.NoError(suite.repo.Contacts.Delete(origin, gone.ID, 0))
+
+	page, err := suite.repo.Contacts.Search(models.Paginator{Page: 1, Limit: 10}, "ana")
+
+	suite.NoError(err)
+	suite.Equal(int64(2), page.TotalRecord)
+
+	results := page.Records.([]models.SearchResult)
+	suite.Equal(ana.ID, results[0].Contact.ID)
+	suite.Equal(bob.ID, results[1].Contact.ID)
+	suite.Greater(results[0].Rank, results[1].Rank)
+	suite.Equal("<mark>Ana</mark> Pérez", results[0].Highlights["name"])
+	suite.Equal("friend of <mark>ana</mark>", results[1].Highlights["notes"])
+
+	page, err = suite.repo.Contacts.Search(models.Paginator{Page: 1, Limit: 10}, "PEREZ")
+
+	suite.NoError(err)
+	suite.Equal(int64(1), page.TotalRecord)
+	suite.Equal(ana.ID, page.Records.([]models.SearchResult)[0].Contact.ID)
+
+	carla := suite.create(models.Contact{Name: "Carla Ruiz", Company: "Acme Corp", PhoneNumber: "+573005555555"})
+
+	page, err = suite.repo.Contacts.Search(models.Paginator{Page: 1, Limit: 10}, "acme")
+
+	suite.NoError(err)
+	suite.Equal(int64(1), page.TotalRecord)
+	suite.Equal(carla.ID, page.Records.([]models.SearchResult)[0].Contact.ID)
+	suite.Equal("<mark>Acme</mark> Corp", page.Records.([]models.SearchResult)[0].Highlights["company"])
+
+	page, err = suite.repo.Contacts.Search(models.Paginator{Page: 2, Limit: 10}, "ana")
+
+	suite.NoError(err)
+	suite.Equal(int64(2), page.TotalRecord)
+	suite.Empty(page.Records)
+}
+
+// create saves contact, it must succeed.
+func (suite *ContactsSuite) create(contact models.Contact) models.Contact {
+	created, err := suite.repo.Contacts.Create(origin, contact)
+	suite.Require().NoError(err)
+
+	return created
+}
+
+// assertKind asserts that err is an apperrors.Error of kind.
+func assertKind(suite *suite.Suite, kind apperrors.Kind, err error) {
+	var appErr *apperrors.Error
+	if suite.ErrorAs(err, &appErr) {
+		suite.Equal(kind, appErr.Kind, appErr.Error())
+	}
+}
+
+func contactIDs(records interface{}) []uint {
+	var ids []uint
+	for _, contact := range records.([]models.Contact) {
+		ids = append(ids, contact.ID)
+	}
+
+	return ids
+}
+
+func contactNames(records interface{}) []string {
+	var names []string
+	for _, contact := range records.([]models.Contact) {
+		names = append(names, contact.Name)
+	}
+
+	return names
+}
+
+func tagNames(tags []models.Tag) []string {
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,129 @@
+package repositorytest
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// GroupsSuite is the contract of repository.Groups, New is called as the one of
+// ContactsSuite.
+type GroupsSuite struct {
+	suite.Suite
+	New func() Repositories
+
+	repo Repositories
+}
+
+func (suite *GroupsSuite) SetupTest() {
+	suite.repo = suite.New()
+}
+
+func (suite *GroupsSuite) TestCreate_WhenNameIsTaken() {
+	suite.createGroup("family")
+
+	_, err := suite.repo.Groups.Create(models.Group{Name: "family"})
+
+	assertKind(&suite.Suite, apperrors.KindConflict, err)
+}
+
+func (suite *GroupsSuite) TestGetByID_WhenSuccess() {
+	family := suite.createGroup("family")
+	cousins := suite.createGroup("cousins")
+	ana := suite.createContact("Ana", "+573001111111")
+
+	suite.NoError(suite.repo.Groups.AddMembers(family.ID, []uint{ana.ID}, []uint{cousins.ID}))
+
+	group, err := suite.repo.Groups.GetByID(family.ID)
+
+	suite.NoError(err)
+	suite.Equal("family", group.Name)
+	suite.Len(group.Contacts, 1)
+	suite.Equal(ana.ID, group.Contacts[0].ID)
+	suite.Len(group.Groups, 1)
+	suite.Equal(cousins.ID, group.Groups[0].ID)
+
+	_, err = suite.repo.Groups.GetByID(99)
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
+
+func (suite *GroupsSuite) TestMembers_WhenGroupsAreNested() {
+	family := suite.createGroup("family")
+	cousins := suite.createGroup("cousins")
+	ana := suite.createContact("Ana", "+573001111111")
+	bob := suite.createContact("Bob", "+573002222222")
+	gone := suite.createContact("Carla", "+573003333333")
+
+	suite.NoError(suite.repo.Groups.AddMembers(family.ID, []uint{bob.ID}, []uint{cousins.ID}))
+	suite.NoError(suite.repo.Groups.AddMembers(cousins.ID, []uint{ana.ID, bob.ID, gone.ID}, nil))
+	suite.NoError(suite.repo.Contacts.Delete(origin, gone.ID, 0))
+
+	members, err := suite.repo.Groups.Members(family.ID)
+
+	suite.NoError(err)
+	suite.Equal([]string{"Ana", "Bob"}, contactNames(members))
+
+	suite.NoError(suite.repo.Groups.RemoveMembers(family.ID, nil, []uint{cousins.ID}))
+
+	members, err = suite.repo.Groups.Members(family.ID)
+
+	suite.NoError(err)
+	suite.Equal([]string{"Bob"}, contactNames(members))
+}
+
+func (suite *GroupsSuite) TestAddMembers_WhenItCreatesACycle() {
+	family := suite.createGroup("family")
+	cousins := suite.createGroup("cousins")
+	kids := suite.createGroup("kids")
+
+	suite.NoError(suite.repo.Groups.AddMembers(family.ID, nil, []uint{cousins.ID}))
+	suite.NoError(suite.repo.Groups.AddMembers(cousins.ID, nil, []uint{kids.ID}))
+
+	assertKind(&suite.Suite, apperrors.KindConflict, suite.repo.Groups.AddMembers(kids.ID, nil, []uint{family.ID}))
+	assertKind(&suite.Suite, apperrors.KindConflict, suite.repo.Groups.AddMembers(kids.ID, nil, []uint{kids.ID}))
+}
+
+func (suite *GroupsSuite) TestAddMembers_WhenAMemberDoesNotExist() {
+	family := suite.createGroup("family")
+
+	assertKind(&suite.Suite, apperrors.KindValidation, suite.repo.Groups.AddMembers(family.ID, []uint{99}, nil))
+	assertKind(&suite.Suite, apperrors.KindValidation, suite.repo.Groups.AddMembers(family.ID, nil, []uint{99}))
+	assertKind(&suite.Suite, apperrors.KindNotFound, suite.repo.Groups.AddMembers(99, nil, nil))
+}
+
+func (suite *GroupsSuite) TestDelete_WhenNested() {
+	family := suite.createGroup("family")
+	cousins := suite.createGroup("cousins")
+	ana := suite.createContact("Ana", "+573001111111")
+
+	suite.NoError(suite.repo.Groups.AddMembers(family.ID, nil, []uint{cousins.ID}))
+	suite.NoError(suite.repo.Groups.AddMembers(cousins.ID, []uint{ana.ID}, nil))
+	suite.NoError(suite.repo.Groups.Delete(cousins.ID))
+
+	members, err := suite.repo.Groups.Members(family.ID)
+
+	suite.NoError(err)
+	suite.Empty(members)
+
+	groups, err := suite.repo.Groups.Get()
+
+	suite.NoError(err)
+	suite.Len(groups, 1)
+	suite.Equal("family", groups[0].Name)
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, suite.repo.Groups.Delete(cousins.ID))
+}
+
+func (suite *GroupsSuite) createGroup(name string) models.Group {
+	group, err := suite.repo.Groups.Create(models.Group{Name: name})
+	suite.Require().NoError(err)
+
+	return group
+}
+
+func (suite *GroupsSuite) createContact(name, phoneNumber string) models.Contact {
+	contact, err := suite.repo.Contacts.Create(origin, models.Contact{Name: name, PhoneNumber: phoneNumber})
+	suite.Require().NoError(err)
+
+	return contact
+}
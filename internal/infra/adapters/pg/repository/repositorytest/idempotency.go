@@ -0,0 +1,157 @@
+package repositorytest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// IdempotencySuite is the contract of repository.Idempotency, New is called as
+// the one of ContactsSuite.
+type IdempotencySuite struct {
+	suite.Suite
+	New func() Repositories
+
+	repo Repositories
+}
+
+var now = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+func (suite *IdempotencySuite) SetupTest() {
+	suite.repo = suite.New()
+}
+
+func (suite *IdempotencySuite) TestReserve_WhenKeyIsFree() {
+	request := suite.request("key", "first")
+
+	stored, reserved, err := suite.repo.Idempotency.Reserve(request, now)
+
+	suite.NoError(err)
+	suite.True(reserved)
+	suite.Equal("first", stored.Fingerprint)
+	suite.Zero(stored.Status)
+}
+
+func (suite *IdempotencySuite) TestReserve_WhenKeyIsTaken() {
+	request := suite.reserve("key", "first")
+
+	request.Status = http.StatusCreated
+	request.Header = map[string][]string{"Location": {"/api/contacts/1"}}
+	request.Body = []byte(`{"id":1}`)
+	suite.NoError(suite.repo.Idempotency.Complete(request))
+
+	stored, reserved, err := suite.repo.Idempotency.Reserve(suite.request("key", "second"), now.Add(time.Minute))
+
+	suite.NoError(err)
+	suite.False(reserved)
+	suite.Equal("first", stored.Fingerprint)
+	suite.Equal(http.StatusCreated, stored.Status)
+	suite.Equal([]string{"/api/contacts/1"}, stored.Header["Location"])
+	suite.Equal(`{"id":1}`, string(stored.Body))
+}
+
+func (suite *IdempotencySuite) TestReserve_WhenKeyExpired() {
+	suite.reserve("key", "first")
+
+	stored, reserved, err := suite.repo.Idempotency.Reserve(suite.request("key", "second"), now.Add(time.Hour))
+
+	suite.NoError(err)
+	suite.True(reserved)
+	suite.Equal("second", stored.Fingerprint)
+}
+
+func (suite *IdempotencySuite) TestReserve_WhenInProgress() {
+	suite.reserve("key", "first")
+
+	stored, reserved, err := suite.repo.Idempotency.Reserve(suite.request("key", "first"), now.Add(time.Minute))
+
+	suite.NoError(err)
+	suite.False(reserved)
+	suite.Zero(stored.Status)
+}
+
+func (suite *IdempotencySuite) TestReserve_WhenLeaseExpired() {
+	suite.reserve("key", "first")
+
+	retry := suite.request("key", "first")
+	retry.LockedUntil = now.Add(20 * time.Minute)
+
+	stored, reserved, err := suite.repo.Idempotency.Reserve(retry, now.Add(10*time.Minute))
+
+	suite.NoError(err)
+	suite.True(reserved)
+	suite.True(retry.LockedUntil.Equal(stored.LockedUntil))
+
+	_, reserved, err = suite.repo.Idempotency.Reserve(suite.request("key", "first"), now.Add(15*time.Minute))
+
+	suite.NoError(err)
+	suite.False(reserved)
+}
+
+func (suite *IdempotencySuite) TestReserve_WhenCompletedPastLease() {
+	request := suite.reserve("key", "first")
+
+	request.Status = http.StatusOK
+	suite.NoError(suite.repo.Idempotency.Complete(request))
+
+	stored, reserved, err := suite.repo.Idempotency.Reserve(suite.request("key", "first"), now.Add(10*time.Minute))
+
+	suite.NoError(err)
+	suite.False(reserved)
+	suite.Equal(http.StatusOK, stored.Status)
+}
+
+func (suite *IdempotencySuite) TestRelease_WhenSuccess() {
+	suite.reserve("key", "first")
+
+	suite.NoError(suite.repo.Idempotency.Release("key"))
+
+	_, reserved, err := suite.repo.Idempotency.Reserve(suite.request("key", "second"), now)
+
+	suite.NoError(err)
+	suite.True(reserved)
+}
+
+func (suite *IdempotencySuite) TestPurgeExpired_WhenSuccess() {
+	suite.reserve("old", "first")
+
+	request := suite.request("new", "second")
+	request.ExpiresAt = now.Add(2 * time.Hour)
+
+	_, reserved, err := suite.repo.Idempotency.Reserve(request, now)
+	suite.Require().NoError(err)
+	suite.Require().True(reserved)
+
+	request.Status = http.StatusCreated
+	suite.Require().NoError(suite.repo.Idempotency.Complete(request))
+
+	purged, err := suite.repo.Idempotency.PurgeExpired(now.Add(time.Hour))
+
+	suite.NoError(err)
+	suite.Equal(int64(1), purged)
+
+	stored, reserved, err := suite.repo.Idempotency.Reserve(suite.request("new", "third"), now.Add(time.Hour))
+
+	suite.NoError(err)
+	suite.False(reserved)
+	suite.Equal("second", stored.Fingerprint)
+}
+
+// request is a request with key expiring an hour after now, leased for five
+// minutes.
+func (suite *IdempotencySuite) request(key, fingerprint string) models.IdempotentRequest {
+	return models.IdempotentRequest{Key: key, Fingerprint: fingerprint, ExpiresAt: now.Add(time.Hour),
+		LockedUntil: now.Add(5 * time.Minute)}
+}
+
+func (suite *IdempotencySuite) reserve(key, fingerprint string) models.IdempotentRequest {
+	request := suite.request(key, fingerprint)
+
+	_, reserved, err := suite.repo.Idempotency.Reserve(request, now)
+	suite.Require().NoError(err)
+	suite.Require().True(reserved)
+
+	return request
+}
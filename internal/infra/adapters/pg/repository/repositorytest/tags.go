@@ -0,0 +1,172 @@
+package repositorytest
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/stretchr/testify/suite"
+)
+
+// TagsSuite is the contract of repository.Tags, New is called as the one of
+// ContactsSuite.
+type TagsSuite struct {
+	suite.Suite
+	New func() Repositories
+
+	repo Repositories
+}
+
+func (suite *TagsSuite) SetupTest() {
+	suite.repo = suite.New()
+}
+
+func (suite *TagsSuite) TestCreate_WhenNameIsTaken() {
+	suite.createTag("family")
+
+	_, err := suite.repo.Tags.Create(models.Tag{Name: "family"})
+
+	assertKind(&suite.Suite, apperrors.KindConflict, err)
+}
+
+func (suite *TagsSuite) TestGet_WhenSuccess() {
+	suite.createTag("work")
+	suite.createTag("family")
+
+	tags, err := suite.repo.Tags.Get()
+
+	suite.NoError(err)
+	suite.Equal([]string{"family", "work"}, tagNames(tags))
+}
+
+func (suite *TagsSuite) TestUpdate_WhenSuccess() {
+	tag := suite.createTag("family")
+	ana := suite.createContact("Ana", "+573001111111")
+
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{ana.ID}, []string{"family"}))
+
+	updated, err := suite.repo.Tags.Update(origin, tag.ID, models.Tag{Name: "relatives", Color: "#00ff00"})
+
+	suite.NoError(err)
+	suite.Equal(tag.ID, updated.ID)
+
+	stored, err := suite.repo.Tags.GetByID(tag.ID)
+
+	suite.NoError(err)
+	suite.Equal("relatives", stored.Name)
+	suite.Equal("#00ff00", stored.Color)
+
+	contact, err := suite.repo.Contacts.GetByID(ana.ID)
+
+	suite.NoError(err)
+	suite.Equal([]string{"relatives"}, tagNames(contact.Tags))
+	suite.Equal(uint(3), contact.Version)
+}
+
+func (suite *TagsSuite) TestUpdate_WhenNameIsTaken() {
+	suite.createTag("family")
+	tag := suite.createTag("work")
+
+	_, err := suite.repo.Tags.Update(origin, tag.ID, models.Tag{Name: "family"})
+
+	assertKind(&suite.Suite, apperrors.KindConflict, err)
+}
+
+func (suite *TagsSuite) TestUpdate_WhenNotFound() {
+	_, err := suite.repo.Tags.Update(origin, 99, models.Tag{Name: "family"})
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+}
+
+func (suite *TagsSuite) TestDelete_WhenAssigned() {
+	tag := suite.createTag("family")
+	ana := suite.createContact("Ana", "+573001111111")
+
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{ana.ID}, []string{"family"}))
+	suite.NoError(suite.repo.Tags.Delete(origin, tag.ID))
+
+	_, err := suite.repo.Tags.GetByID(tag.ID)
+	assertKind(&suite.Suite, apperrors.KindNotFound, err)
+
+	contact, err := suite.repo.Contacts.GetByID(ana.ID)
+
+	suite.NoError(err)
+	suite.Empty(contact.Tags)
+	suite.Equal(uint(3), contact.Version)
+
+	assertKind(&suite.Suite, apperrors.KindNotFound, suite.repo.Tags.Delete(origin, tag.ID))
+}
+
+func (suite *TagsSuite) TestAssign_WhenSuccess() {
+	suite.createTag("family")
+	suite.createTag("work")
+	ana := suite.createContact("Ana", "+573001111111")
+	bob := suite.createContact("Bob", "+573002222222")
+
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{ana.ID, bob.ID}, []string{"family", "work"}))
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{ana.ID}, []string{"family"}))
+
+	for _, id := range []uint{ana.ID, bob.ID} {
+		contact, err := suite.repo.Contacts.GetByID(id)
+
+		suite.NoError(err)
+		suite.Equal([]string{"family", "work"}, tagNames(contact.Tags))
+		suite.Equal(uint(2), contact.Version)
+	}
+}
+
+func (suite *TagsSuite) TestAssign_WhenATagDoesNotExist() {
+	suite.createTag("family")
+	ana := suite.createContact("Ana", "+573001111111")
+
+	err := suite.repo.Tags.Assign(origin, []uint{ana.ID}, []string{"family", "work"})
+
+	assertKind(&suite.Suite, apperrors.KindValidation, err)
+
+	contact, err := suite.repo.Contacts.GetByID(ana.ID)
+
+	suite.NoError(err)
+	suite.Empty(contact.Tags)
+	suite.Equal(uint(1), contact.Version)
+}
+
+func (suite *TagsSuite) TestAssign_WhenAContactDoesNotExist() {
+	suite.createTag("family")
+
+	err := suite.repo.Tags.Assign(origin, []uint{99}, []string{"family"})
+
+	assertKind(&suite.Suite, apperrors.KindValidation, err)
+}
+
+func (suite *TagsSuite) TestUnassign_WhenSuccess() {
+	suite.createTag("family")
+	suite.createTag("work")
+	ana := suite.createContact("Ana", "+573001111111")
+	bob := suite.createContact("Bob", "+573002222222")
+
+	suite.NoError(suite.repo.Tags.Assign(origin, []uint{ana.ID}, []string{"family", "work"}))
+	suite.NoError(suite.repo.Tags.Unassign(origin, []uint{ana.ID, bob.ID}, []string{"work"}))
+
+	contact, err := suite.repo.Contacts.GetByID(ana.ID)
+
+	suite.NoError(err)
+	suite.Equal([]string{"family"}, tagNames(contact.Tags))
+	suite.Equal(uint(3), contact.Version)
+
+	contact, err = suite.repo.Contacts.GetByID(bob.ID)
+
+	suite.NoError(err)
+	suite.Equal(uint(1), contact.Version)
+}
+
+func (suite *TagsSuite) createTag(name string) models.Tag {
+	tag, err := suite.repo.Tags.Create(models.Tag{Name: name})
+	suite.Require().NoError(err)
+
+	return tag
+}
+
+func (suite *TagsSuite) createContact(name, phoneNumber string) models.Contact {
+	contact, err := suite.repo.Contacts.Create(origin, models.Contact{Name: name, PhoneNumber: phoneNumber})
+	suite.Require().NoError(err)
+
+	return contact
+}
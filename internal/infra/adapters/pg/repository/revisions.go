@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+// GetRevisions lists the revisions of a contact, the newest first. Contacts in
+// the trash keep theirs until they are purged.
+func (repo *contacts) GetRevisions(id uint, paginate models.Paginator) (*models.Paginator, error) {
+	if err := repo.db.Unscoped().Select("id").First(&models.Contact{}, id).Error; err != nil {
+		return nil, translateError(err, contactNotFound(id), "")
+	}
+
+	var revisions []models.Revision
+
+	offset := (paginate.Page - 1) * paginate.Limit
+
+	err := repo.db.
+		Where("contact_id = ?", id).
+		Order("version DESC").
+		Offset(offset).
+		Limit(paginate.Limit).
+		Find(&revisions).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	var totalRecords int64
+
+	err = repo.db.Model(&models.Revision{}).Where("contact_id = ?", id).Count(&totalRecords).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return offsetPage(paginate, totalRecords, revisions), nil
+}
+
+// GetRevision returns the revision of the contact at version.
+func (repo *contacts) GetRevision(id uint, version uint) (models.Revision, error) {
+	var revision models.Revision
+
+	err := repo.db.Where("contact_id = ? AND version = ?", id, version).First(&revision).Error
+	if err != nil {
+		return revision, translateError(err, fmt.Sprintf("the contact: %v has no revision %v", id, version), "")
+	}
+
+	return revision, nil
+}
+
+// GetRevisionAt returns the last revision of the contact written at or before at.
+func (repo *contacts) GetRevisionAt(id uint, at time.Time) (models.Revision, error) {
+	var revision models.Revision
+
+	err := repo.db.
+		Where("contact_id = ? AND created_at <= ?", id, at).
+		Order("created_at DESC, version DESC").
+		First(&revision).Error
+	if err != nil {
+		return revision, translateError(err,
+			fmt.Sprintf("the contact: %v did not exist at %s", id, at.Format(time.RFC3339)), "")
+	}
+
+	return revision, nil
+}
+
+// recordRevision keeps in tx the state of contact right after a write.
+func recordRevision(tx *gorm.DB, contact *models.Contact) error {
+	revision := models.Revision{
+		ContactID: contact.ID,
+		Version:   contact.Version,
+		Contact:   *contact,
+	}
+
+	if err := tx.Create(&revision).Error; err != nil {
+		return translateError(err, "", "")
+	}
+
+	return nil
+}
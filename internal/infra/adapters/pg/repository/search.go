@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"database/sql"
+	"html"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/search"
+	"gorm.io/gorm"
+)
+
+// searchQuery and searchTerm are the search sent by the client, as a full-text
+// query and as a text compared by trigram similarity with the fields.
+const (
+	searchQuery = `websearch_to_tsquery('contacts_search', @query)`
+	searchTerm  = `search_fold(@query)`
+)
+
+// searchMatches keeps the contacts, out of the trash, whose names, company,
+// notes or email addresses contain the words of the search or are similar enough
+// to it to tolerate typos, as told by the word similarity threshold of pg_trgm.
+const searchMatches = `contacts.deleted_at IS NULL AND (contacts.search_vector @@ ` + searchQuery + `
+	OR ` + searchTerm + ` <% search_fold(contacts.name || ' ' || contacts.nickname)
+	OR ` + searchTerm + ` <% search_fold(contacts.company)
+	OR ` + searchTerm + ` <% search_fold(contacts.notes)
+	OR contacts.id IN (SELECT emails.contact_id FROM emails WHERE emails.search_vector @@ ` + searchQuery + `
+		OR ` + searchTerm + ` <% search_fold(emails.address)))`
+
+// searchRank adds the full-text rank of the names, company and notes, where
+// names weigh the most and notes the least, to the best similarity of the search
+// with the names, the company, the notes or an email address.
+const searchRank = `ts_rank_cd(contacts.search_vector, ` + searchQuery + `) + GREATEST(
+	word_similarity(` + searchTerm + `, search_fold(contacts.name || ' ' || contacts.nickname)),
+	word_similarity(` + searchTerm + `, search_fold(contacts.company)),
+	word_similarity(` + searchTerm + `, search_fold(contacts.notes)) / 2,
+	COALESCE((SELECT MAX(GREATEST(ts_rank_cd(emails.search_vector, ` + searchQuery + `),
+		word_similarity(` + searchTerm + `, search_fold(emails.address))))
+		FROM emails WHERE emails.contact_id = contacts.id), 0))`
+
+// searchHeadlines are the excerpts of the fields of the contacts of ids with the
+// words of the search between markers, the email is the first one matching.
+const searchHeadlines = `SELECT contacts.id,
+	ts_headline('contacts_search', contacts.name, ` + searchQuery + `, @options) AS name,
+	ts_headline('contacts_search', contacts.nickname, ` + searchQuery + `, @options) AS nickname,
+	ts_headline('contacts_search', contacts.company, ` + searchQuery + `, @options) AS company,
+	ts_headline('contacts_search', contacts.notes, ` + searchQuery + `, @options || ', MaxFragments=2') AS notes,
+	(SELECT ts_headline('contacts_search', emails.address, ` + searchQuery + `, @options) FROM emails
+		WHERE emails.contact_id = contacts.id AND emails.search_vector @@ ` + searchQuery + `
+		ORDER BY emails.id LIMIT 1) AS email
+	FROM contacts WHERE contacts.id IN @ids`
+
+// The markers ts_headline puts around the words found, they are private use
+// characters so the excerpts can be escaped before the markers become tags.
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+var (
+	highlightOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop
+	highlightTags    = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+)
+
+type searchHit struct {
+	ID   uint
+	Rank float64
+}
+
+type searchHeadline struct {
+	ID       uint
+	Name     string
+	Nickname string
+	Company  string
+	Notes    string
+	Email    *string
+}
+
+// Search finds the contacts matching query, the best ranked first. The page is
+// read from a single snapshot of the database.
+func (repo *contacts) Search(paginate models.Paginator, query string) (*models.Paginator, error) {
+	if !isPostgres(repo.db) {
+		return repo.searchPages(paginate, query)
+	}
+
+	var page *models.Paginator
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		args := map[string]interface{}{
+			"query":  query,
+			"limit":  paginate.Limit,
+			"offset": (paginate.Page - 1) * paginate.Limit,
+		}
+
+		var hits []searchHit
+
+		err := tx.Raw(`SELECT contacts.id, `+searchRank+` AS rank FROM contacts WHERE `+searchMatches+`
+			ORDER BY rank DESC, contacts.id LIMIT @limit OFFSET @offset`, args).Scan(&hits).Error
+		if err != nil {
+			return translateError(err, "", "")
+		}
+
+		var total int64
+
+		if err = tx.Raw(`SELECT COUNT(*) FROM contacts WHERE `+searchMatches, args).Scan(&total).Error; err != nil {
+			return translateError(err, "", "")
+		}
+
+		results, err := searchResults(tx, query, hits)
+		if err != nil {
+			return err
+		}
+
+		page = offsetPage(paginate, total, results)
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// searchResults loads the contacts of hits, in their order, with the excerpts of
+// their fields where the words of query were found.
+func searchResults(tx *gorm.DB, query string, hits []searchHit) ([]models.SearchResult, error) {
+	results := make([]models.SearchResult, 0, len(hits))
+	if len(hits) == 0 {
+		return results, nil
+	}
+
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+
+	contacts, err := exportBatch(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var headlines []searchHeadline
+
+	err = tx.Raw(searchHeadlines, map[string]interface{}{
+		"query":   query,
+		"options": highlightOptions,
+		"ids":     ids,
+	}).Scan(&headlines).Error
+	if err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	highlights := make(map[uint]map[string]string, len(headlines))
+	for _, headline := range headlines {
+		highlights[headline.ID] = headline.highlights()
+	}
+
+	ranks := make(map[uint]float64, len(hits))
+	for _, hit := range hits {
+		ranks[hit.ID] = hit.Rank
+	}
+
+	for _, contact := range contacts {
+		results = append(results, models.SearchResult{
+			Contact:    contact,
+			Rank:       ranks[contact.ID],
+			Highlights: highlights[contact.ID],
+		})
+	}
+
+	return results, nil
+}
+
+// searchPages matches query in Go against every contact out of the trash, read
+// by pages, and returns the page of the results asked for.
+func (repo *contacts) searchPages(paginate models.Paginator, query string) (*models.Paginator, error) {
+	matcher := search.NewQuery(query)
+	results := []models.SearchResult{}
+
+	err := repo.exportPages(models.ContactFilter{}, []models.SortField{{Field: "id"}},
+		func(contacts []models.Contact) error {
+			for _, contact := range contacts {
+				if result, found := matcher.Match(contact); found {
+					results = append(results, result)
+				}
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	search.Sort(results)
+
+	total := len(results)
+	from := (paginate.Page - 1) * paginate.Limit
+
+	if from > total {
+		from = total
+	}
+
+	to := from + paginate.Limit
+	if to > total {
+		to = total
+	}
+
+	return offsetPage(paginate, int64(total), results[from:to]), nil
+}
+
+// highlights returns the excerpts where a word was found, keyed by field.
+func (headline searchHeadline) highlights() map[string]string {
+	fields := map[string]string{
+		"name":     headline.Name,
+		"nickname": headline.Nickname,
+		"company":  headline.Company,
+		"notes":    headline.Notes,
+	}
+
+	if headline.Email != nil {
+		fields["email"] = *headline.Email
+	}
+
+	highlights := make(map[string]string, len(fields))
+	for field, excerpt := range fields {
+		if strings.Contains(excerpt, highlightStart) {
+			highlights[field] = highlightTags.Replace(html.EscapeString(excerpt))
+		}
+	}
+
+	if len(highlights) == 0 {
+		return nil
+	}
+
+	return highlights
+}
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Tags interface {
+	Create(tag models.Tag) (models.Tag, error)
+	GetByID(id uint) (models.Tag, error)
+	Update(origin models.Origin, id uint, tag models.Tag) (models.Tag, error)
+	Delete(origin models.Origin, id uint) error
+	Get() ([]models.Tag, error)
+	Assign(origin models.Origin, contactIDs []uint, tags []string) error
+	Unassign(origin models.Origin, contactIDs []uint, tags []string) error
+}
+
+// contactTag is a row of the join table between contacts and tags.
+type contactTag struct {
+	ContactID uint
+	TagID     uint
+}
+
+func (contactTag) TableName() string {
+	return "contact_tags"
+}
+
+type tags struct {
+	db *gorm.DB
+}
+
+func NewTags(db *gorm.DB) Tags {
+	return &tags{
+		db,
+	}
+}
+
+func (repo *tags) Create(tag models.Tag) (models.Tag, error) {
+	if err := repo.db.Create(&tag).Error; err != nil {
+		return models.Tag{}, translateError(err, "", tagExists(tag.Name))
+	}
+
+	return tag, nil
+}
+
+func (repo *tags) GetByID(id uint) (models.Tag, error) {
+	var tag models.Tag
+
+	if err := repo.db.First(&tag, id).Error; err != nil {
+		return tag, translateError(err, tagNotFound(id), "")
+	}
+
+	return tag, nil
+}
+
+// Update renames or recolors the tag, the contacts having it get a new version
+// as their representation changes. Changes made to contacts are audited on
+// behalf of origin.
+func (repo *tags) Update(origin models.Origin, id uint, tag models.Tag) (models.Tag, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		tagged, err := contactsTagged(tx, id)
+		if err != nil {
+			return err
+		}
+
+		return retagContacts(tx, origin, tagged, func() error {
+			tag.ID = id
+
+			result := tx.Model(&tag).Select("*").Omit("id").Updates(&tag)
+			if result.Error != nil {
+				return translateError(result.Error, tagNotFound(id), tagExists(tag.Name))
+			}
+
+			if result.RowsAffected == 0 {
+				return apperrors.NotFound(tagNotFound(id), nil)
+			}
+
+			return nil
+		})
+	})
+
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// Delete removes the tag from every contact and then the tag itself.
+func (repo *tags) Delete(origin models.Origin, id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		tagged, err := contactsTagged(tx, id)
+		if err != nil {
+			return err
+		}
+
+		return retagContacts(tx, origin, tagged, func() error {
+			if err := tx.Where("tag_id = ?", id).Delete(&contactTag{}).Error; err != nil {
+				return translateError(err, "", "")
+			}
+
+			result := tx.Delete(&models.Tag{}, id)
+			if result.Error != nil {
+				return translateError(result.Error, tagNotFound(id), "")
+			}
+
+			if result.RowsAffected == 0 {
+				return apperrors.NotFound(tagNotFound(id), nil)
+			}
+
+			return nil
+		})
+	})
+}
+
+func (repo *tags) Get() ([]models.Tag, error) {
+	var tags []models.Tag
+
+	if err := repo.db.Order("name").Find(&tags).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return tags, nil
+}
+
+// Assign adds every tag to every contact in a single transaction, pairs that
+// already exist are left as they are. Unknown tags or contacts fail the whole
+// assignment.
+func (repo *tags) Assign(origin models.Origin, contactIDs []uint, tags []string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		tagIDs, err := findAssignment(tx, contactIDs, tags)
+		if err != nil {
+			return err
+		}
+
+		rows := make([]contactTag, 0, len(contactIDs)*len(tagIDs))
+		for _, contactID := range contactIDs {
+			for _, tagID := range tagIDs {
+				rows = append(rows, contactTag{ContactID: contactID, TagID: tagID})
+			}
+		}
+
+		return retagContacts(tx, origin, contactIDs, func() error {
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+
+			return translateError(err, "", "")
+		})
+	})
+}
+
+// Unassign removes every tag from every contact, pairs that do not exist are
+// ignored.
+func (repo *tags) Unassign(origin models.Origin, contactIDs []uint, tags []string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		tagIDs, err := findAssignment(tx, contactIDs, tags)
+		if err != nil {
+			return err
+		}
+
+		return retagContacts(tx, origin, contactIDs, func() error {
+			err := tx.Where("contact_id IN ? AND tag_id IN ?", contactIDs, tagIDs).Delete(&contactTag{}).Error
+
+			return translateError(err, "", "")
+		})
+	})
+}
+
+// findAssignment checks that every contact and tag of an assignment exists and
+// returns the ids of the tags.
+func findAssignment(tx *gorm.DB, contactIDs []uint, names []string) ([]uint, error) {
+	var found []models.Tag
+	if err := tx.Where("name IN ?", names).Find(&found).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	if len(found) != len(names) {
+		known := make(map[string]bool, len(found))
+		for _, tag := range found {
+			known[tag.Name] = true
+		}
+
+		var missing []string
+		for _, name := range names {
+			if !known[name] {
+				missing = append(missing, name)
+			}
+		}
+
+		return nil, apperrors.Validation(
+			fmt.Sprintf("the tags: %s do not exist", strings.Join(missing, ", ")), nil)
+	}
+
+	exist, err := allExist(tx, &models.Contact{}, contactIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exist {
+		return nil, apperrors.Validation("some of the contacts do not exist", nil)
+	}
+
+	tagIDs := make([]uint, len(found))
+	for i, tag := range found {
+		tagIDs[i] = tag.ID
+	}
+
+	return tagIDs, nil
+}
+
+// contactsTagged returns the ids of the contacts having the tag, in the trash or
+// not.
+func contactsTagged(tx *gorm.DB, tagID uint) ([]uint, error) {
+	var ids []uint
+
+	if err := tx.Model(&contactTag{}).Where("tag_id = ?", tagID).Pluck("contact_id", &ids).Error; err != nil {
+		return nil, translateError(err, "", "")
+	}
+
+	return ids, nil
+}
+
+// retagContacts runs change, which changes the tags of the contacts of ids, and
+// gives a new version to the ones out of the trash whose tags did change. As any
+// other write of a contact, each of them keeps a revision and is audited on
+// behalf of origin.
+func retagContacts(tx *gorm.DB, origin models.Origin, ids []uint, change func() error) error {
+	before, err := lockContacts(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	if err = change(); err != nil {
+		return err
+	}
+
+	locked := make([]uint, 0, len(before))
+	for id := range before {
+		locked = append(locked, id)
+	}
+
+	after, err := snapshots(tx, locked)
+	if err != nil {
+		return err
+	}
+
+	var changed []uint
+	for _, id := range locked {
+		if !reflect.DeepEqual(before[id].Tags, after[id].Tags) {
+			changed = append(changed, id)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	sort.Slice(changed, func(i, j int) bool { return changed[i] < changed[j] })
+
+	err = tx.Model(&models.Contact{}).
+		Where("id IN ?", changed).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error
+	if err != nil {
+		return translateError(err, "", "")
+	}
+
+	revisions := make([]models.Revision, len(changed))
+	entries := make([]models.AuditEntry, len(changed))
+
+	for i, id := range changed {
+		contact := after[id]
+		contact.Version++
+		revisions[i] = models.Revision{ContactID: id, Version: contact.Version, Contact: *contact}
+
+		if entries[i], err = auditEntry(origin, models.AuditUpdate, before[id], contact); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Create(&revisions).Error; err != nil {
+		return translateError(err, "", "")
+	}
+
+	return writeEntries(tx, entries...)
+}
+
+func tagNotFound(id uint) string {
+	return fmt.Sprintf("the tag: %v does not exist", id)
+}
+
+func tagExists(name string) string {
+	return fmt.Sprintf("the tag %s already exists", name)
+}
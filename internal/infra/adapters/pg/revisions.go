@@ -0,0 +1,40 @@
+package pg
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"gorm.io/gorm"
+)
+
+const recordRevisionsBatchSize = 500
+
+// recordRevisions keeps a revision of the current state of the contacts written
+// before revisions existed, so there is a state to go back to from their next
+// update.
+func recordRevisions(db *gorm.DB) error {
+	var contacts []models.Contact
+
+	byID := func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}
+
+	recorded := db.
+		Model(&models.Revision{}).
+		Select("1").
+		Where("revisions.contact_id = contacts.id AND revisions.version = contacts.version")
+
+	return db.
+		Unscoped().
+		Preload("Phones", byID).
+		Preload("Emails", byID).
+		Preload("Addresses", byID).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		Where("NOT EXISTS (?)", recorded).
+		FindInBatches(&contacts, recordRevisionsBatchSize, func(tx *gorm.DB, _ int) error {
+			revisions := make([]models.Revision, len(contacts))
+			for i, contact := range contacts {
+				revisions[i] = models.Revision{ContactID: contact.ID, Version: contact.Version, Contact: contact}
+			}
+
+			return tx.Create(&revisions).Error
+		}).Error
+}
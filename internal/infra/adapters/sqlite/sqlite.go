@@ -0,0 +1,79 @@
+// Package sqlite opens the SQLite database the gorm repositories run on when
+// the service is started without Postgres.
+package sqlite
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/AjxGnx/contacts-go/config"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/glebarez/sqlite"
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+var (
+	instance *gorm.DB
+	once     sync.Once
+)
+
+// tables are the models the schema is created from. SQLite databases are built
+// by gorm from the models, the SQL migrations are written for Postgres.
+var tables = []interface{}{
+	&models.Contact{},
+	&models.Phone{},
+	&models.Email{},
+	&models.Address{},
+	&models.Tag{},
+	&models.Group{},
+	&models.AuditEntry{},
+	&models.Revision{},
+	&models.CardResource{},
+	&models.CardTombstone{},
+	&models.IdempotentRequest{},
+	&models.SyncCounter{},
+}
+
+func ConnInstance() *gorm.DB {
+	once.Do(func() {
+		db, err := Open(config.Environments().SqlitePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		instance = db
+	})
+
+	return instance
+}
+
+// Open opens the database in the file at path, or in memory for ":memory:", and
+// creates or updates its tables and the row of the sync counter. Foreign keys
+// are enforced, and a single connection is kept so writes never wait on each
+// other and a database in memory is the same for every query.
+func Open(path string) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path)
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetMaxOpenConns(1)
+
+	if err = db.AutoMigrate(tables...); err != nil {
+		return nil, fmt.Errorf("creating the tables of %s: %w", path, err)
+	}
+
+	if err = db.FirstOrCreate(&models.SyncCounter{ID: models.SyncCounterID}).Error; err != nil {
+		return nil, fmt.Errorf("creating the sync counter of %s: %w", path, err)
+	}
+
+	return db, nil
+}
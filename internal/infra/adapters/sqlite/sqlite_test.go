@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository"
+	"github.com/AjxGnx/contacts-go/internal/infra/adapters/pg/repository/repositorytest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestContacts(t *testing.T) {
+	suite.Run(t, &repositorytest.ContactsSuite{New: newRepositories(t)})
+}
+
+func TestGroups(t *testing.T) {
+	suite.Run(t, &repositorytest.GroupsSuite{New: newRepositories(t)})
+}
+
+func TestTags(t *testing.T) {
+	suite.Run(t, &repositorytest.TagsSuite{New: newRepositories(t)})
+}
+
+func TestAudit(t *testing.T) {
+	suite.Run(t, &repositorytest.AuditSuite{New: newRepositories(t)})
+}
+
+func TestAddressBook(t *testing.T) {
+	suite.Run(t, &repositorytest.AddressBookSuite{New: newRepositories(t)})
+}
+
+func TestIdempotency(t *testing.T) {
+	suite.Run(t, &repositorytest.IdempotencySuite{New: newRepositories(t)})
+}
+
+// newRepositories opens a new database in memory for every test.
+func newRepositories(t *testing.T) func() repositorytest.Repositories {
+	return func() repositorytest.Repositories {
+		db, err := Open(":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return repositorytest.Repositories{
+			Contacts:    repository.NewContacts(db),
+			Tags:        repository.NewTags(db),
+			Groups:      repository.NewGroups(db),
+			Audit:       repository.NewAudit(db),
+			AddressBook: repository.NewAddressBook(db),
+			Idempotency: repository.NewIdempotency(db),
+		}
+	}
+}
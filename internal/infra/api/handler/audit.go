@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AjxGnx/contacts-go/internal/app"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/labstack/echo/v4"
+)
+
+type Audit interface {
+	History(ctx echo.Context) error
+	Get(ctx echo.Context) error
+}
+
+type audit struct {
+	app      app.Audit
+	paginate dto.PaginateConfig
+}
+
+func NewAudit(app app.Audit, paginate dto.PaginateConfig) Audit {
+	return &audit{
+		app,
+		paginate,
+	}
+}
+
+// @Tags         Audit
+// @Summary      Get the history of a Contact
+// @Description  Get the changes made to a Contact, the newest first. Purged contacts keep their history
+// @Produce      json
+// @Param        id     path      int  true   "value of the contact"
+// @Param        limit  query     int  false  "limit to find records, 10 by default"
+// @Param        page   query     int  false  "page to find records, 1 by default"
+// @Success      200    {object}  dto.Message{data=models.Paginator{records=[]models.AuditEntry}}
+// @Failure      400    {object}  dto.Problem
+// @Failure      404    {object}  dto.Problem
+// @Failure      500    {object}  dto.Problem
+// @Router       /contacts/{id}/history [get]
+func (handler *audit) History(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	paginate, err := dto.ParsePaginate(ctx.QueryParam("page"), ctx.QueryParam("limit"), handler.paginate)
+	if err != nil {
+		return err
+	}
+
+	history, err := handler.app.History(id, paginate)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "history successfully loaded",
+		Data:    history,
+	})
+}
+
+// @Tags         Audit
+// @Summary      Search the audit log
+// @Description  Get the changes made to contacts, the newest first
+// @Produce      json
+// @Param        contact_id  query     int     false  "changes of the contact"
+// @Param        actor       query     string  false  "changes made by the actor"
+// @Param        action      query     string  false  "kind of change"  Enums(create, update, delete, restore, purge, revert, merge)
+// @Param        request_id  query     string  false  "changes made by the request"
+// @Param        from        query     string  false  "changes made at or after the RFC 3339 timestamp"
+// @Param        to          query     string  false  "changes made at or before the RFC 3339 timestamp"
+// @Param        limit       query     int     false  "limit to find records, 10 by default"
+// @Param        page        query     int     false  "page to find records, 1 by default"
+// @Success      200         {object}  dto.Message{data=models.Paginator{records=[]models.AuditEntry}}
+// @Failure      400         {object}  dto.Problem
+// @Failure      500         {object}  dto.Problem
+// @Router       /audit [get]
+func (handler *audit) Get(ctx echo.Context) error {
+	paginate, err := dto.ParsePaginate(ctx.QueryParam("page"), ctx.QueryParam("limit"), handler.paginate)
+	if err != nil {
+		return err
+	}
+
+	filter, err := dto.AuditQuery{
+		ContactID: ctx.QueryParam("contact_id"),
+		Actor:     ctx.QueryParam("actor"),
+		Action:    ctx.QueryParam("action"),
+		RequestID: ctx.QueryParam("request_id"),
+		From:      ctx.QueryParam("from"),
+		To:        ctx.QueryParam("to"),
+	}.ToFilter()
+	if err != nil {
+		return err
+	}
+
+	entries, err := handler.app.Get(paginate, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "audit log successfully loaded",
+		Data:    entries,
+	})
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/app"
+	"github.com/stretchr/testify/suite"
+)
+
+type auditTestSuite struct {
+	suite.Suite
+	app       *mocks.Audit
+	underTest Audit
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, new(auditTestSuite))
+}
+
+func (suite *auditTestSuite) SetupTest() {
+	suite.app = &mocks.Audit{}
+	suite.underTest = NewAudit(suite.app, dto.PaginateConfig{MaxLimit: 100})
+}
+
+func (suite *auditTestSuite) TestHistory_WhenSuccess() {
+	suite.app.Mock.On("History", uint(10), dto.Paginate{Page: 1, Limit: 10}).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10/history", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.History(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *auditTestSuite) TestHistory_WhenContactNotFound() {
+	suite.app.Mock.On("History", uint(10), dto.Paginate{Page: 1, Limit: 10}).
+		Return(nil, apperrors.NotFound("the contact: 10 does not exist", nil))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10/history", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.History(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, StatusCode(err))
+}
+
+func (suite *auditTestSuite) TestHistory_WhenIDIsMalformed() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/abc/history", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("abc")
+
+	err := suite.underTest.History(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *auditTestSuite) TestGet_WhenFiltered() {
+	suite.app.Mock.On("Get", dto.Paginate{Page: 1, Limit: 20},
+		models.AuditFilter{ContactID: 10, Actor: "ana", Action: models.AuditUpdate}).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/audit?contact_id=10&actor=ana&action=update&limit=20", nil)
+
+	suite.NoError(suite.underTest.Get(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *auditTestSuite) TestGet_WhenActionIsInvalid() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/audit?action=rename", nil)
+
+	err := suite.underTest.Get(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/labstack/echo/v4"
+)
+
+// @Tags         Contacts
+// @Summary      Create, update and delete contacts in bulk
+// @Description  Apply a list of operations in a single transaction. Atomic batches apply every operation or none of them,
+// @Description  best effort batches apply the operations that succeed. Each result carries the status the operation
+// @Description  would have got on its own; operations of a failed atomic batch that were not applied get a 424
+// @Accept       json
+// @Produce      json
+// @Param        request          body      dto.Batch  true   "Request Body"
+// @Param        X-Actor          header    string     false  "author of the changes, anonymous by default"
+// @Param        Idempotency-Key  header    string     false  "key making retries of the request replay its first response"
+// @Success      200              {object}  dto.Message{data=dto.BatchReport}  "every operation succeeded"
+// @Success      207              {object}  dto.Message{data=dto.BatchReport}  "some operation failed"
+// @Failure      400              {object}  dto.Problem
+// @Failure      422              {object}  dto.Problem
+// @Failure      500              {object}  dto.Problem
+// @Router       /contacts/batch [post]
+func (handler *contacts) Batch(ctx echo.Context) error {
+	var batch dto.Batch
+
+	if err := ctx.Bind(&batch); err != nil {
+		return bindError(err)
+	}
+
+	if err := batch.Validate(handler.batches); err != nil {
+		return err
+	}
+
+	results, err := handler.app.Batch(origin(ctx), batch.Operations, batch.Atomic())
+	if err != nil {
+		return err
+	}
+
+	report := dto.BatchReport{Mode: dto.BatchAtomic, Results: make([]dto.BatchResult, len(results))}
+	if !batch.Atomic() {
+		report.Mode = dto.BatchBestEffort
+	}
+
+	for i, result := range results {
+		report.Results[i] = batchResult(i, batch.Operations[i], result, ctx.Request().URL.Path)
+
+		if result.Err != nil {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+	}
+
+	code, message := http.StatusOK, "batch successfully applied"
+	if report.Failed > 0 {
+		code, message = http.StatusMultiStatus, "batch applied with failures"
+	}
+
+	if report.Failed > 0 && batch.Atomic() {
+		message = "batch not applied, an operation failed"
+	}
+
+	return ctx.JSON(code, dto.Message{
+		Message: message,
+		Data:    report,
+	})
+}
+
+// batchResult reports the result of the operation at index, instance is the
+// path of the batch.
+func batchResult(index int, operation dto.BatchOperation, result models.BatchResult,
+	instance string) dto.BatchResult {
+	report := dto.BatchResult{Index: index, Op: operation.Op, ID: operation.ID}
+
+	if result.Err != nil {
+		problem := NewProblem(result.Err, instance)
+		report.Status = problem.Status
+		report.Error = &problem
+
+		return report
+	}
+
+	contact := result.Contact
+	report.ID = contact.ID
+
+	switch operation.Op {
+	case string(models.BatchCreate):
+		report.Status = http.StatusCreated
+		report.Data = &contact
+	case string(models.BatchUpdate):
+		report.Status = http.StatusOK
+		report.Data = &contact
+	default:
+		report.Status = http.StatusNoContent
+	}
+
+	return report
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/labstack/echo/v4"
+)
+
+const batchBody = `{
+	"mode": "best_effort",
+	"operations": [
+		{"op": "create", "contact": {"name": "Juan", "phone_number": "3000000000"}},
+		{"op": "update", "id": 7, "version": 2, "contact": {"name": "Ana", "phone_number": "3100000000"}},
+		{"op": "delete", "id": 8}
+	]
+}`
+
+func (suite *contactsTestSuite) batchOperations() []dto.BatchOperation {
+	return []dto.BatchOperation{
+		{Op: "create", Contact: &dto.Contact{Name: "Juan", PhoneNumber: "3000000000"}},
+		{Op: "update", ID: 7, Version: 2, Contact: &dto.Contact{Name: "Ana", PhoneNumber: "3100000000"}},
+		{Op: "delete", ID: 8},
+	}
+}
+
+func (suite *contactsTestSuite) TestBatch_WhenSuccess() {
+	suite.app.Mock.On("Batch", anonymous, suite.batchOperations(), false).Return([]models.BatchResult{
+		{Contact: models.Contact{ID: 9, Version: 1}},
+		{Contact: models.Contact{ID: 7, Version: 3}},
+		{Contact: models.Contact{ID: 8, Version: 4}},
+	}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/batch", strings.NewReader(batchBody))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Batch(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+
+	report := suite.batchReport(setupCase.Res.Body.Bytes())
+
+	suite.Equal(dto.BatchBestEffort, report.Mode)
+	suite.Equal(3, report.Succeeded)
+	suite.Equal([]int{http.StatusCreated, http.StatusOK, http.StatusNoContent}, batchStatuses(report))
+	suite.Equal(uint(9), report.Results[0].ID)
+	suite.Equal(uint(3), report.Results[1].Data.Version)
+	suite.Nil(report.Results[2].Data)
+}
+
+func (suite *contactsTestSuite) TestBatch_WhenSomeFail() {
+	suite.app.Mock.On("Batch", anonymous, suite.batchOperations(), false).Return([]models.BatchResult{
+		{Contact: models.Contact{ID: 9, Version: 1}},
+		{Err: apperrors.PreconditionFailed("the contact: 7 was modified by someone else", nil)},
+		{Err: apperrors.NotFound("the contact: 8 does not exist", nil)},
+	}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/batch", strings.NewReader(batchBody))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Batch(setupCase.context))
+	suite.Equal(http.StatusMultiStatus, setupCase.Res.Code)
+
+	report := suite.batchReport(setupCase.Res.Body.Bytes())
+
+	suite.Equal(1, report.Succeeded)
+	suite.Equal(2, report.Failed)
+	suite.Equal([]int{http.StatusCreated, http.StatusPreconditionFailed, http.StatusNotFound}, batchStatuses(report))
+	suite.Equal("the contact: 8 does not exist", report.Results[2].Error.Detail)
+	suite.Equal(uint(8), report.Results[2].ID)
+}
+
+func (suite *contactsTestSuite) TestBatch_WhenTooManyOperations() {
+	body := `{"operations": [{"op": "delete", "id": 1}, {"op": "delete", "id": 2}, {"op": "delete", "id": 3},
+		{"op": "delete", "id": 4}]}`
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/batch", strings.NewReader(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Batch(setupCase.context)
+
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.Equal("operations", apperrors.FieldsOf(err)[0].Field)
+}
+
+func (suite *contactsTestSuite) batchReport(body []byte) dto.BatchReport {
+	var message struct {
+		Data dto.BatchReport `json:"data"`
+	}
+
+	suite.NoError(json.Unmarshal(body, &message))
+
+	return message.Data
+}
+
+func batchStatuses(report dto.BatchReport) []int {
+	statuses := make([]int, len(report.Results))
+	for i, result := range report.Results {
+		statuses[i] = result.Status
+	}
+
+	return statuses
+}
@@ -0,0 +1,743 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/app"
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// CardDAVPath is the principal of the CardDAV server, which is also the home
+	// of its only address book.
+	CardDAVPath = "/carddav/"
+	// CardDAVWellKnownPath redirects clients to the server (RFC 6764).
+	CardDAVWellKnownPath = "/.well-known/carddav"
+
+	HeaderDAV   = "DAV"
+	HeaderDepth = "Depth"
+
+	addressBookName = "contacts"
+	addressBookPath = CardDAVPath + addressBookName + "/"
+	syncTokenPrefix = "urn:contacts-go:sync:"
+
+	davCompliance = "1, 3, addressbook"
+	davMethods    = "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT"
+
+	// maxCardSize bounds the vCards clients can store, in bytes. Photos inlined
+	// as data URIs take up to 1 MB.
+	maxCardSize = 2 << 20
+)
+
+// errEnoughCards stops listing the address book once a query has all the cards
+// it wants.
+var errEnoughCards = errors.New("enough cards")
+
+// CardDAV serves the contacts as a CardDAV address book (RFC 6352), with a
+// .vcf resource for each contact. Resources are named by the clients that
+// create them, contacts created elsewhere are named after their id.
+type CardDAV interface {
+	WellKnown(ctx echo.Context) error
+	Options(ctx echo.Context) error
+	PropFind(ctx echo.Context) error
+	Report(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Put(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+}
+
+type cardDAV struct {
+	contacts app.Contacts
+	book     app.AddressBook
+}
+
+func NewCardDAV(contacts app.Contacts, book app.AddressBook) CardDAV {
+	return &cardDAV{
+		contacts,
+		book,
+	}
+}
+
+// davKind is the kind of a resource of the server.
+type davKind int
+
+const (
+	davPrincipal davKind = iota
+	davAddressBook
+	davCard
+)
+
+// davResource is a resource being described: the principal, the address book,
+// with its sync token, or one of its cards.
+type davResource struct {
+	kind  davKind
+	href  string
+	token uint
+	card  vcard.Card
+}
+
+// davTarget is the resource a request is sent to, name is the name of a card.
+type davTarget struct {
+	kind davKind
+	name string
+}
+
+// WellKnown sends clients looking for the server to its principal.
+func (handler *cardDAV) WellKnown(ctx echo.Context) error {
+	return ctx.Redirect(http.StatusMovedPermanently, CardDAVPath)
+}
+
+func (handler *cardDAV) Options(ctx echo.Context) error {
+	ctx.Response().Header().Set(HeaderDAV, davCompliance)
+	ctx.Response().Header().Set(echo.HeaderAllow, davMethods)
+
+	return ctx.NoContent(http.StatusOK)
+}
+
+// PropFind describes the resource, and its members unless the Depth header is 0.
+// Infinite depth is served as depth 1, which reaches every resource anyway.
+func (handler *cardDAV) PropFind(ctx echo.Context) error {
+	target, err := cardDAVTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	props, err := readPropFind(ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+
+	members := ctx.Request().Header.Get(HeaderDepth) != "0"
+	status := newMultiStatus(ctx)
+
+	switch target.kind {
+	case davPrincipal:
+		if err := handler.add(status, davResource{kind: davPrincipal, href: CardDAVPath}, props); err != nil {
+			return err
+		}
+
+		if members {
+			book, err := handler.addressBook()
+			if err != nil {
+				return err
+			}
+
+			if err := handler.add(status, book, props); err != nil {
+				return err
+			}
+		}
+	case davAddressBook:
+		book, err := handler.addressBook()
+		if err != nil {
+			return err
+		}
+
+		if err := handler.add(status, book, props); err != nil {
+			return err
+		}
+
+		if members {
+			err = handler.eachCard(func(card davResource) error {
+				return handler.add(status, card, props)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	case davCard:
+		card, err := handler.card(target.name)
+		if err != nil {
+			return err
+		}
+
+		if err := handler.add(status, card, props); err != nil {
+			return err
+		}
+	}
+
+	return status.close("")
+}
+
+// Report serves the addressbook-query, addressbook-multiget and sync-collection
+// reports of the address book.
+func (handler *cardDAV) Report(ctx echo.Context) error {
+	target, err := cardDAVTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	var request report
+	if err := xml.NewDecoder(ctx.Request().Body).Decode(&request); err != nil {
+		return apperrors.Validation(invalidRequestMessage, err)
+	}
+
+	if target.kind != davAddressBook {
+		return davError(ctx, http.StatusForbidden, nameSupportedReport)
+	}
+
+	switch request.XMLName {
+	case nameQuery:
+		return handler.query(ctx, request)
+	case nameMultiGet:
+		return handler.multiGet(ctx, request)
+	case nameSyncCollection:
+		return handler.syncCollection(ctx, request)
+	default:
+		return davError(ctx, http.StatusForbidden, nameSupportedReport)
+	}
+}
+
+// query returns the cards matching the filter of the report, up to its limit.
+func (handler *cardDAV) query(ctx echo.Context, request report) error {
+	status := newMultiStatus(ctx)
+	limit := request.limit()
+	found := 0
+
+	err := handler.eachCard(func(card davResource) error {
+		if !request.Filter.matches(card.card) {
+			return nil
+		}
+
+		if limit > 0 && found == limit {
+			return errEnoughCards
+		}
+
+		found++
+
+		return handler.add(status, card, request.props())
+	})
+	if err != nil && !errors.Is(err, errEnoughCards) {
+		return err
+	}
+
+	return status.close("")
+}
+
+// multiGet returns the cards at the hrefs of the report, the ones not found get
+// a 404 status.
+func (handler *cardDAV) multiGet(ctx echo.Context, request report) error {
+	status := newMultiStatus(ctx)
+
+	for _, href := range request.Hrefs {
+		card, err := handler.cardAt(href)
+
+		switch {
+		case apperrors.Is(err, apperrors.KindNotFound):
+			err = status.add(davResponse{Href: href, Status: statusLine(http.StatusNotFound)})
+		case err == nil:
+			err = handler.add(status, card, request.props())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return status.close("")
+}
+
+// syncCollection returns the cards changed after the sync token of the report,
+// or every card without one, and the deleted ones with a 404 status.
+func (handler *cardDAV) syncCollection(ctx echo.Context, request report) error {
+	status := newMultiStatus(ctx)
+
+	if request.SyncToken == "" {
+		token, err := handler.book.SyncToken()
+		if err != nil {
+			return err
+		}
+
+		err = handler.eachCard(func(card davResource) error {
+			return handler.add(status, card, request.props())
+		})
+		if err != nil {
+			return err
+		}
+
+		return status.close(syncToken(token))
+	}
+
+	since, ok := parseSyncToken(request.SyncToken)
+	if !ok {
+		return davError(ctx, http.StatusForbidden, nameValidSyncToken)
+	}
+
+	changes, err := handler.book.Changes(since)
+	if apperrors.Is(err, apperrors.KindValidation) {
+		return davError(ctx, http.StatusForbidden, nameValidSyncToken)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	resources, err := handler.book.Resources(changes.ContactIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		contact, err := handler.contacts.GetByID(resource.ContactID)
+
+		switch {
+		case apperrors.Is(err, apperrors.KindNotFound):
+			err = status.add(davResponse{Href: cardHref(resource.Name), Status: statusLine(http.StatusNotFound)})
+		case err == nil:
+			err = handler.add(status, cardResource(resource, contact), request.props())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return status.close(syncToken(changes.Token))
+}
+
+// Get returns a card as a vCard, version 3.0 unless the Accept header asks for
+// another one.
+func (handler *cardDAV) Get(ctx echo.Context) error {
+	target, err := cardDAVTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	if target.kind != davCard {
+		return echo.ErrMethodNotAllowed
+	}
+
+	card, err := handler.card(target.name)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(card.card.Contact.Version))
+
+	if ifNoneMatch(ctx, card.card.Contact.Version) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	data, err := encodeCard(card.card, acceptedVersion(ctx.Request().Header.Get(echo.HeaderAccept)))
+	if err != nil {
+		return err
+	}
+
+	return ctx.Blob(http.StatusOK, MIMETextVCard, data)
+}
+
+// Put stores a card, creating its contact or replacing it. If-Match guards the
+// replacement of a contact and If-None-Match: * keeps an existing one. Cards are
+// normalized when they are stored, so no ETag is returned: clients get the card
+// again to learn it.
+func (handler *cardDAV) Put(ctx echo.Context) error {
+	target, err := cardDAVTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	if target.kind != davCard {
+		return echo.ErrMethodNotAllowed
+	}
+
+	card, err := readCard(ctx)
+	if err != nil {
+		return err
+	}
+
+	contact := dto.NewContact(card.Contact)
+	if err := contact.Validate(); err != nil {
+		return err
+	}
+
+	current, err := handler.card(target.name)
+	if apperrors.Is(err, apperrors.KindNotFound) {
+		return handler.create(ctx, target.name, card.UID, contact)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(ctx.Request().Header.Get(HeaderIfNoneMatch)) == "*" {
+		return apperrors.PreconditionFailed(fmt.Sprintf("the resource %s already exists", target.name), nil)
+	}
+
+	version, err := ifMatch(ctx, func() (uint, error) { return current.card.Contact.Version, nil })
+	if err != nil {
+		return err
+	}
+
+	updated, err := handler.contacts.Update(origin(ctx), current.card.Contact.ID, contact, version)
+	if err != nil {
+		return err
+	}
+
+	if card.UID != "" && card.UID != current.card.UID {
+		err = handler.book.Bind(models.CardResource{Name: target.name, ContactID: updated.ID, UID: card.UID})
+		if err != nil {
+			return err
+		}
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (handler *cardDAV) create(ctx echo.Context, name, uid string, contact dto.Contact) error {
+	if ctx.Request().Header.Get(HeaderIfMatch) != "" {
+		return apperrors.PreconditionFailed(fmt.Sprintf("the resource %s does not exist", name), nil)
+	}
+
+	created, err := handler.contacts.Create(origin(ctx), contact)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.book.Bind(models.CardResource{Name: name, ContactID: created.ID, UID: uid}); err != nil {
+		return err
+	}
+
+	return ctx.NoContent(http.StatusCreated)
+}
+
+// Delete moves the contact of a card to the trash, guarded by If-Match.
+func (handler *cardDAV) Delete(ctx echo.Context) error {
+	target, err := cardDAVTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	if target.kind != davCard {
+		return echo.ErrMethodNotAllowed
+	}
+
+	resource, err := handler.book.Resolve(target.name)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatch(ctx, versionOf(handler.contacts.GetByID, resource.ContactID))
+	if err != nil {
+		return err
+	}
+
+	if err := handler.contacts.Delete(origin(ctx), resource.ContactID, version); err != nil {
+		return err
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (handler *cardDAV) addressBook() (davResource, error) {
+	token, err := handler.book.SyncToken()
+	if err != nil {
+		return davResource{}, err
+	}
+
+	return davResource{kind: davAddressBook, href: addressBookPath, token: token}, nil
+}
+
+// card finds the card named name, along with its contact.
+func (handler *cardDAV) card(name string) (davResource, error) {
+	resource, err := handler.book.Resolve(name)
+	if err != nil {
+		return davResource{}, err
+	}
+
+	contact, err := handler.contacts.GetByID(resource.ContactID)
+	if err != nil {
+		return davResource{}, err
+	}
+
+	return cardResource(resource, contact), nil
+}
+
+// cardAt finds the card at href, which is either a path or a full URL.
+func (handler *cardDAV) cardAt(href string) (davResource, error) {
+	notFound := apperrors.NotFound(fmt.Sprintf("the resource %s was not found", href), nil)
+
+	location, err := url.Parse(href)
+	if err != nil || !strings.HasPrefix(location.Path, addressBookPath) {
+		return davResource{}, notFound
+	}
+
+	name := strings.TrimPrefix(location.Path, addressBookPath)
+	if name == "" || strings.Contains(name, "/") {
+		return davResource{}, notFound
+	}
+
+	return handler.card(name)
+}
+
+// eachCard calls visit with every card of the address book, reading the contacts
+// a batch at a time.
+func (handler *cardDAV) eachCard(visit func(card davResource) error) error {
+	return handler.contacts.Export(dto.Paginate{}, func(contacts []models.Contact) error {
+		ids := make([]uint, 0, len(contacts))
+		for _, contact := range contacts {
+			ids = append(ids, contact.ID)
+		}
+
+		resources, err := handler.book.Resources(ids)
+		if err != nil {
+			return err
+		}
+
+		for i, contact := range contacts {
+			if err := visit(cardResource(resources[i], contact)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// add writes the response describing resource with the properties asked for.
+// The ones the resource does not have are listed with a 404 status.
+func (handler *cardDAV) add(status *multiStatus, resource davResource, props propRequest) error {
+	names := props.names
+	if len(names) == 0 {
+		names = allProps[resource.kind]
+	}
+
+	var found, missing []element
+
+	for _, name := range names {
+		value, ok, err := property(resource, name, props.version)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case !ok:
+			missing = append(missing, element{XMLName: name})
+		case props.namesOnly:
+			found = append(found, element{XMLName: name})
+		default:
+			found = append(found, value)
+		}
+	}
+
+	response := davResponse{Href: resource.href}
+	if len(found) > 0 || len(missing) == 0 {
+		response.Propstats = append(response.Propstats,
+			davPropstat{Prop: davProp{Values: found}, Status: statusLine(http.StatusOK)})
+	}
+
+	if len(missing) > 0 {
+		response.Propstats = append(response.Propstats,
+			davPropstat{Prop: davProp{Values: missing}, Status: statusLine(http.StatusNotFound)})
+	}
+
+	return status.add(response)
+}
+
+// allProps are the properties of each kind of resource described when none are
+// named.
+var allProps = map[davKind][]xml.Name{
+	davPrincipal: {
+		propResourceType, propDisplayName, propCurrentUserPrincipal, propPrincipalURL, propAddressBookHomeSet,
+	},
+	davAddressBook: {
+		propResourceType, propDisplayName, propCurrentUserPrincipal, propAddressBookDescription,
+		propSupportedAddressData, propMaxResourceSize, propSyncToken, propGetCTag, propSupportedReportSet,
+	},
+	davCard: {
+		propResourceType, propGetETag, propGetContentType, propGetContentLength,
+	},
+}
+
+// property returns the value of the property name of resource, with the vCards
+// of address-data in version. ok is false when the resource does not have it.
+func property(resource davResource, name xml.Name, version string) (value element, ok bool, err error) {
+	if name == propCurrentUserPrincipal {
+		return hrefElement(name, CardDAVPath), true, nil
+	}
+
+	switch resource.kind {
+	case davPrincipal:
+		switch name {
+		case propResourceType:
+			return newElement(name, newElement(nameCollection), newElement(namePrincipal)), true, nil
+		case propDisplayName:
+			return textElement(name, "contacts-go"), true, nil
+		case propPrincipalURL, propAddressBookHomeSet:
+			return hrefElement(name, CardDAVPath), true, nil
+		}
+	case davAddressBook:
+		return addressBookProperty(resource, name)
+	case davCard:
+		return cardProperty(resource, name, version)
+	}
+
+	return element{}, false, nil
+}
+
+func addressBookProperty(resource davResource, name xml.Name) (element, bool, error) {
+	switch name {
+	case propResourceType:
+		return newElement(name, newElement(nameCollection), newElement(nameAddressBook)), true, nil
+	case propDisplayName:
+		return textElement(name, "Contacts"), true, nil
+	case propAddressBookDescription:
+		return textElement(name, "Every contact"), true, nil
+	case propSupportedAddressData:
+		types := make([]element, 0, 2)
+		for _, version := range []string{vcard.Version3, vcard.Version4} {
+			types = append(types, element{XMLName: nameAddressDataType, Attrs: []xml.Attr{
+				{Name: xml.Name{Local: "content-type"}, Value: vcard.MIMEType},
+				{Name: xml.Name{Local: "version"}, Value: version},
+			}})
+		}
+
+		return newElement(name, types...), true, nil
+	case propMaxResourceSize:
+		return textElement(name, strconv.Itoa(maxCardSize)), true, nil
+	case propSyncToken, propGetCTag:
+		return textElement(name, syncToken(resource.token)), true, nil
+	case propSupportedReportSet:
+		reports := make([]element, 0, 3)
+		for _, report := range []xml.Name{nameQuery, nameMultiGet, nameSyncCollection} {
+			reports = append(reports, newElement(nameSupportedReport, newElement(nameReport, newElement(report))))
+		}
+
+		return newElement(name, reports...), true, nil
+	}
+
+	return element{}, false, nil
+}
+
+func cardProperty(resource davResource, name xml.Name, version string) (element, bool, error) {
+	switch name {
+	case propResourceType:
+		return newElement(name), true, nil
+	case propGetETag:
+		return textElement(name, etag(resource.card.Contact.Version)), true, nil
+	case propGetContentType:
+		return textElement(name, MIMETextVCard), true, nil
+	case propGetContentLength:
+		data, err := encodeCard(resource.card, vcard.Version3)
+		return textElement(name, strconv.Itoa(len(data))), err == nil, err
+	case propAddressData:
+		data, err := encodeCard(resource.card, version)
+		return textElement(name, string(data)), err == nil, err
+	}
+
+	return element{}, false, nil
+}
+
+// cardDAVTarget finds the resource the request is sent to from the path under
+// CardDAVPath.
+func cardDAVTarget(ctx echo.Context) (davTarget, error) {
+	path := ctx.Param("*")
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+
+	path = strings.Trim(path, "/")
+	name := strings.TrimPrefix(path, addressBookName+"/")
+
+	switch {
+	case path == "":
+		return davTarget{kind: davPrincipal}, nil
+	case path == addressBookName:
+		return davTarget{kind: davAddressBook}, nil
+	case name != path && name != "" && !strings.Contains(name, "/"):
+		return davTarget{kind: davCard, name: name}, nil
+	default:
+		return davTarget{}, apperrors.NotFound(fmt.Sprintf("the resource %s was not found", path), nil)
+	}
+}
+
+func cardResource(resource models.CardResource, contact models.Contact) davResource {
+	return davResource{
+		kind: davCard,
+		href: cardHref(resource.Name),
+		card: vcard.Card{UID: resource.UID, Contact: contact},
+	}
+}
+
+func cardHref(name string) string {
+	return addressBookPath + url.PathEscape(name)
+}
+
+func encodeCard(card vcard.Card, version string) ([]byte, error) {
+	var data bytes.Buffer
+	if err := card.Encode(&data, version); err != nil {
+		return nil, apperrors.Internal(err)
+	}
+
+	return data.Bytes(), nil
+}
+
+// readCard reads the only vCard the body of a PUT must hold.
+func readCard(ctx echo.Context) (vcard.Card, error) {
+	contentType := ctx.Request().Header.Get(echo.HeaderContentType)
+	if mediaType, _, _ := mime.ParseMediaType(contentType); contentType != "" &&
+		mediaType != vcard.MIMEType && mediaType != MIMETextXVCard {
+		return vcard.Card{}, echo.ErrUnsupportedMediaType
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxCardSize+1))
+	if err != nil {
+		return vcard.Card{}, apperrors.Validation(invalidRequestMessage, err)
+	}
+
+	if len(body) > maxCardSize {
+		return vcard.Card{}, apperrors.Validation(fmt.Sprintf("the vCard is larger than %d bytes", maxCardSize), nil)
+	}
+
+	cards, err := vcard.Decode(bytes.NewReader(body))
+	if err != nil {
+		return vcard.Card{}, apperrors.Validation(fmt.Sprintf("the vCard is not valid: %s", err), err)
+	}
+
+	if len(cards) != 1 {
+		return vcard.Card{}, apperrors.Validation("the body must hold exactly one vCard", nil)
+	}
+
+	return cards[0], nil
+}
+
+// acceptedVersion is the vCard version named by the Accept header, 3.0 when it
+// names none.
+func acceptedVersion(accept string) string {
+	for _, value := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err == nil && mediaType == vcard.MIMEType && vcard.IsVersion(params["version"]) {
+			return params["version"]
+		}
+	}
+
+	return vcard.Version3
+}
+
+func syncToken(token uint) string {
+	return syncTokenPrefix + strconv.FormatUint(uint64(token), 10)
+}
+
+func parseSyncToken(value string) (uint, bool) {
+	if !strings.HasPrefix(value, syncTokenPrefix) {
+		return 0, false
+	}
+
+	token, err := strconv.ParseUint(strings.TrimPrefix(value, syncTokenPrefix), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return uint(token), true
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+)
+
+// Values of the test attribute of filters and of the match-type and collation
+// attributes of text matches.
+const (
+	testAllOf = "allof"
+
+	matchEquals     = "equals"
+	matchStartsWith = "starts-with"
+	matchEndsWith   = "ends-with"
+
+	collationOctet = "i;octet"
+)
+
+// queryFilter is the filter of an addressbook-query (RFC 6352, section 10.5).
+// Parameter filters are not supported: they are ignored, so the cards they would
+// reject are returned and left for the client to discard.
+type queryFilter struct {
+	Test  string       `xml:"test,attr"`
+	Props []propFilter `xml:"urn:ietf:params:xml:ns:carddav prop-filter"`
+}
+
+type propFilter struct {
+	Name         string      `xml:"name,attr"`
+	Test         string      `xml:"test,attr"`
+	IsNotDefined *struct{}   `xml:"urn:ietf:params:xml:ns:carddav is-not-defined"`
+	TextMatches  []textMatch `xml:"urn:ietf:params:xml:ns:carddav text-match"`
+}
+
+type textMatch struct {
+	Text      string `xml:",chardata"`
+	Collation string `xml:"collation,attr"`
+	Negate    string `xml:"negate-condition,attr"`
+	MatchType string `xml:"match-type,attr"`
+}
+
+// matches reports whether the card passes the filter, a filter without property
+// filters matches every card.
+func (filter queryFilter) matches(card vcard.Card) bool {
+	return matchAll(filter.Test, len(filter.Props), func(i int) bool {
+		return filter.Props[i].matches(card)
+	})
+}
+
+// matches reports whether the card has the property of the filter with a value
+// passing its text matches, or lacks it when it must not be defined.
+func (filter propFilter) matches(card vcard.Card) bool {
+	values := cardValues(card, strings.ToUpper(filter.Name))
+
+	if filter.IsNotDefined != nil {
+		return len(values) == 0
+	}
+
+	if len(values) == 0 {
+		return false
+	}
+
+	return matchAll(filter.Test, len(filter.TextMatches), func(i int) bool {
+		for _, value := range values {
+			if filter.TextMatches[i].matches(value) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (match textMatch) matches(value string) bool {
+	text := match.Text
+	if match.Collation != collationOctet {
+		text, value = strings.ToLower(text), strings.ToLower(value)
+	}
+
+	var found bool
+
+	switch match.MatchType {
+	case matchEquals:
+		found = value == text
+	case matchStartsWith:
+		found = strings.HasPrefix(value, text)
+	case matchEndsWith:
+		found = strings.HasSuffix(value, text)
+	default:
+		found = strings.Contains(value, text)
+	}
+
+	return found != (match.Negate == "yes")
+}
+
+// matchAll combines count tests: all of them must pass when test is allof and
+// any of them otherwise. No tests at all always pass.
+func matchAll(test string, count int, passes func(i int) bool) bool {
+	if count == 0 {
+		return true
+	}
+
+	allOf := test == testAllOf
+
+	// a failing test decides allof and a passing one anyof
+	for i := 0; i < count; i++ {
+		if passes(i) != allOf {
+			return !allOf
+		}
+	}
+
+	return allOf
+}
+
+// cardValues are the values of the property name in the card, as the text a
+// client would compare with.
+func cardValues(card vcard.Card, name string) []string {
+	contact := card.Contact
+
+	var values []string
+
+	add := func(value string) {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	switch name {
+	case "UID":
+		add(card.UID)
+	case "FN":
+		add(contact.Name)
+	case "N":
+		add(strings.Trim(strings.Join([]string{contact.FamilyName, contact.GivenName, "", contact.Prefix,
+			contact.Suffix}, ";"), ";"))
+	case "NICKNAME":
+		add(contact.Nickname)
+	case "TEL":
+		add(contact.PhoneNumber)
+
+		for _, phone := range contact.Phones {
+			add(phone.Number)
+		}
+	case "EMAIL":
+		for _, email := range contact.Emails {
+			add(email.Address)
+		}
+	case "ADR":
+		for _, address := range contact.Addresses {
+			add(strings.Trim(strings.Join([]string{"", "", address.Street, address.City, address.Region,
+				address.PostalCode, address.Country}, ";"), ";"))
+		}
+	case "ORG":
+		add(contact.Company)
+	case "NOTE":
+		add(contact.Notes)
+	case "PHOTO":
+		add(contact.Photo)
+	}
+
+	return values
+}
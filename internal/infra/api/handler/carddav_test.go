@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/app"
+	govcard "github.com/emersion/go-vcard"
+	"github.com/emersion/go-webdav/carddav"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	juan  = models.Contact{ID: 1, Name: "Juan", PhoneNumber: "+573000000001", Version: 2}
+	maria = models.Contact{ID: 2, Name: "María", PhoneNumber: "+573000000002", Version: 5,
+		Emails: []models.Email{{Label: "work", Address: "maria@example.com"}}}
+
+	juanResource  = models.CardResource{Name: "juan.vcf", ContactID: 1, UID: "juan-uid"}
+	mariaResource = models.CardResource{Name: "2.vcf", ContactID: 2, UID: "urn:contacts-go:contact:2"}
+)
+
+type cardDAVTestSuite struct {
+	suite.Suite
+	contacts *mocks.Contacts
+	book     *mocks.AddressBook
+	server   *httptest.Server
+	client   *carddav.Client
+}
+
+func TestCardDAVSuite(t *testing.T) {
+	suite.Run(t, new(cardDAVTestSuite))
+}
+
+func (suite *cardDAVTestSuite) SetupTest() {
+	suite.contacts = &mocks.Contacts{}
+	suite.book = &mocks.AddressBook{}
+
+	underTest := NewCardDAV(suite.contacts, suite.book)
+
+	server := echo.New()
+	server.HTTPErrorHandler = ErrorHandler
+	server.GET(CardDAVWellKnownPath, underTest.WellKnown)
+
+	for _, path := range []string{"/carddav", CardDAVPath + "*"} {
+		server.OPTIONS(path, underTest.Options)
+		server.Add(echo.PROPFIND, path, underTest.PropFind)
+		server.Add(echo.REPORT, path, underTest.Report)
+		server.GET(path, underTest.Get)
+		server.PUT(path, underTest.Put)
+		server.DELETE(path, underTest.Delete)
+	}
+
+	suite.server = httptest.NewServer(server)
+
+	client, err := carddav.NewClient(suite.server.Client(), suite.server.URL+CardDAVPath)
+	suite.Require().NoError(err)
+
+	suite.client = client
+}
+
+func (suite *cardDAVTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+// listContacts makes the address book hold juan and maria.
+func (suite *cardDAVTestSuite) listContacts() {
+	suite.contacts.Mock.On("Export", dto.Paginate{}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			write := args.Get(1).(func(contacts []models.Contact) error)
+			_ = write([]models.Contact{juan, maria})
+		}).
+		Return(nil)
+	suite.book.Mock.On("Resources", []uint{1, 2}).Return([]models.CardResource{juanResource, mariaResource}, nil)
+}
+
+func (suite *cardDAVTestSuite) TestWellKnown() {
+	client := suite.server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	response, err := client.Get(suite.server.URL + CardDAVWellKnownPath)
+	suite.Require().NoError(err)
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusMovedPermanently, response.StatusCode)
+	suite.Equal(CardDAVPath, response.Header.Get(echo.HeaderLocation))
+}
+
+func (suite *cardDAVTestSuite) TestDiscovery() {
+	suite.book.Mock.On("SyncToken").Return(uint(9), nil)
+
+	suite.NoError(suite.client.HasSupport(context.Background()))
+
+	principal, err := suite.client.FindCurrentUserPrincipal(context.Background())
+	suite.NoError(err)
+	suite.Equal(CardDAVPath, principal)
+
+	home, err := suite.client.FindAddressBookHomeSet(context.Background(), principal)
+	suite.NoError(err)
+	suite.Equal(CardDAVPath, home)
+
+	books, err := suite.client.FindAddressBooks(context.Background(), home)
+	suite.NoError(err)
+	suite.Equal([]carddav.AddressBook{{
+		Path:            "/carddav/contacts/",
+		Name:            "Contacts",
+		Description:     "Every contact",
+		MaxResourceSize: maxCardSize,
+		SupportedAddressData: []carddav.AddressDataType{
+			{ContentType: "text/vcard", Version: "3.0"},
+			{ContentType: "text/vcard", Version: "4.0"},
+		},
+	}}, books)
+}
+
+func (suite *cardDAVTestSuite) TestQuery() {
+	suite.listContacts()
+
+	cards, err := suite.client.QueryAddressBook(context.Background(), "/carddav/contacts/", &carddav.AddressBookQuery{
+		DataRequest: carddav.AddressDataRequest{AllProp: true},
+	})
+
+	suite.NoError(err)
+	suite.Require().Len(cards, 2)
+	suite.Equal("/carddav/contacts/juan.vcf", cards[0].Path)
+	suite.Equal("2", cards[0].ETag)
+	suite.Equal("Juan", cards[0].Card.PreferredValue(govcard.FieldFormattedName))
+	suite.Equal("juan-uid", cards[0].Card.Value(govcard.FieldUID))
+	suite.Equal("/carddav/contacts/2.vcf", cards[1].Path)
+	suite.Equal("maria@example.com", cards[1].Card.Value(govcard.FieldEmail))
+}
+
+func (suite *cardDAVTestSuite) TestQuery_WithFilter() {
+	suite.listContacts()
+
+	cards, err := suite.client.QueryAddressBook(context.Background(), "/carddav/contacts/", &carddav.AddressBookQuery{
+		DataRequest: carddav.AddressDataRequest{AllProp: true},
+		PropFilters: []carddav.PropFilter{{
+			Name:        govcard.FieldEmail,
+			TextMatches: []carddav.TextMatch{{Text: "MARIA@", MatchType: carddav.MatchStartsWith}},
+		}},
+	})
+
+	suite.NoError(err)
+	suite.Require().Len(cards, 1)
+	suite.Equal("/carddav/contacts/2.vcf", cards[0].Path)
+}
+
+func (suite *cardDAVTestSuite) TestQuery_WithLimit() {
+	suite.listContacts()
+
+	cards, err := suite.client.QueryAddressBook(context.Background(), "/carddav/contacts/", &carddav.AddressBookQuery{
+		DataRequest: carddav.AddressDataRequest{AllProp: true},
+		Limit:       1,
+	})
+
+	suite.NoError(err)
+	suite.Require().Len(cards, 1)
+	suite.Equal("/carddav/contacts/juan.vcf", cards[0].Path)
+}
+
+func (suite *cardDAVTestSuite) TestMultiGet() {
+	suite.book.Mock.On("Resolve", "juan.vcf").Return(juanResource, nil)
+	suite.contacts.Mock.On("GetByID", uint(1)).Return(juan, nil)
+
+	cards, err := suite.client.MultiGetAddressBook(context.Background(), "/carddav/contacts/",
+		&carddav.AddressBookMultiGet{
+			Paths:       []string{"/carddav/contacts/juan.vcf"},
+			DataRequest: carddav.AddressDataRequest{AllProp: true},
+		})
+
+	suite.NoError(err)
+	suite.Require().Len(cards, 1)
+	suite.Equal("/carddav/contacts/juan.vcf", cards[0].Path)
+	suite.Equal("+573000000001", cards[0].Card.Value(govcard.FieldTelephone))
+}
+
+func (suite *cardDAVTestSuite) TestMultiGet_WhenNotFound() {
+	suite.book.Mock.On("Resolve", "gone.vcf").Return(models.CardResource{}, apperrors.NotFound("", nil))
+
+	request, _ := http.NewRequest(echo.REPORT, suite.server.URL+"/carddav/contacts/", strings.NewReader(
+		`<C:addressbook-multiget xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:carddav">`+
+			`<D:prop><D:getetag/></D:prop><D:href>/carddav/contacts/gone.vcf</D:href><D:href>/elsewhere</D:href>`+
+			`</C:addressbook-multiget>`))
+
+	response, err := suite.server.Client().Do(request)
+	suite.Require().NoError(err)
+	defer response.Body.Close()
+
+	body := readBody(response)
+	suite.Equal(http.StatusMultiStatus, response.StatusCode)
+	suite.Contains(body, `<href xmlns="DAV:">/carddav/contacts/gone.vcf</href><status xmlns="DAV:">HTTP/1.1 404 Not Found</status>`)
+	suite.Contains(body, `<href xmlns="DAV:">/elsewhere</href><status xmlns="DAV:">HTTP/1.1 404 Not Found</status>`)
+}
+
+func (suite *cardDAVTestSuite) TestGet() {
+	suite.book.Mock.On("Resolve", "juan.vcf").Return(juanResource, nil)
+	suite.contacts.Mock.On("GetByID", uint(1)).Return(juan, nil)
+
+	card, err := suite.client.GetAddressObject(context.Background(), "/carddav/contacts/juan.vcf")
+
+	suite.NoError(err)
+	suite.Equal("2", card.ETag)
+	suite.Equal("3.0", card.Card.Value(govcard.FieldVersion))
+	suite.Equal("juan-uid", card.Card.Value(govcard.FieldUID))
+}
+
+func (suite *cardDAVTestSuite) TestGet_WhenCollection() {
+	response, err := suite.server.Client().Get(suite.server.URL + "/carddav/contacts/")
+	suite.Require().NoError(err)
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusMethodNotAllowed, response.StatusCode)
+}
+
+func (suite *cardDAVTestSuite) TestPut_WhenNew() {
+	expected := dto.Contact{Name: "Pedro", PhoneNumber: "+573000000003"}
+
+	suite.book.Mock.On("Resolve", "pedro.vcf").Return(models.CardResource{}, apperrors.NotFound("", nil))
+	suite.contacts.Mock.On("Create", anonymous, mock.MatchedBy(func(contact dto.Contact) bool {
+		return contact.Name == expected.Name && contact.PhoneNumber == expected.PhoneNumber
+	})).Return(models.Contact{ID: 3, Name: "Pedro", PhoneNumber: "+573000000003", Version: 1}, nil)
+	suite.book.Mock.On("Bind", models.CardResource{Name: "pedro.vcf", ContactID: 3, UID: "pedro-uid"}).Return(nil)
+
+	_, err := suite.client.PutAddressObject(context.Background(), "/carddav/contacts/pedro.vcf",
+		newCard("pedro-uid", "Pedro", "+573000000003"))
+
+	suite.NoError(err)
+	suite.book.Mock.AssertExpectations(suite.T())
+	suite.contacts.Mock.AssertExpectations(suite.T())
+}
+
+func (suite *cardDAVTestSuite) TestPut_WhenExisting() {
+	suite.book.Mock.On("Resolve", "juan.vcf").Return(juanResource, nil)
+	suite.contacts.Mock.On("GetByID", uint(1)).Return(juan, nil)
+	suite.contacts.Mock.On("Update", anonymous, uint(1), mock.MatchedBy(func(contact dto.Contact) bool {
+		return contact.Name == "Juan Carlos"
+	}), uint(0)).Return(models.Contact{ID: 1, Name: "Juan Carlos", Version: 3}, nil)
+
+	_, err := suite.client.PutAddressObject(context.Background(), "/carddav/contacts/juan.vcf",
+		newCard("juan-uid", "Juan Carlos", "+573000000001"))
+
+	suite.NoError(err)
+	suite.contacts.Mock.AssertExpectations(suite.T())
+	suite.book.Mock.AssertNotCalled(suite.T(), "Bind", mock.Anything)
+}
+
+func (suite *cardDAVTestSuite) TestPut_WhenInvalid() {
+	request, _ := http.NewRequest(http.MethodPut, suite.server.URL+"/carddav/contacts/bad.vcf",
+		strings.NewReader("BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Nobody\r\n"))
+	request.Header.Set(echo.HeaderContentType, "text/vcard")
+
+	response, err := suite.server.Client().Do(request)
+	suite.Require().NoError(err)
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+	suite.contacts.Mock.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *cardDAVTestSuite) TestDelete() {
+	suite.book.Mock.On("Resolve", "juan.vcf").Return(juanResource, nil)
+	suite.contacts.Mock.On("Delete", anonymous, uint(1), uint(0)).Return(nil)
+
+	suite.NoError(suite.client.RemoveAll(context.Background(), "/carddav/contacts/juan.vcf"))
+	suite.contacts.Mock.AssertExpectations(suite.T())
+}
+
+func (suite *cardDAVTestSuite) TestSyncCollection_WhenInitial() {
+	suite.book.Mock.On("SyncToken").Return(uint(9), nil)
+	suite.listContacts()
+
+	changes, err := suite.client.SyncCollection(context.Background(), "/carddav/contacts/", &carddav.SyncQuery{})
+
+	suite.NoError(err)
+	suite.Equal("urn:contacts-go:sync:9", changes.SyncToken)
+	suite.Require().Len(changes.Updated, 2)
+	suite.Equal("/carddav/contacts/juan.vcf", changes.Updated[0].Path)
+	suite.Equal("2", changes.Updated[0].ETag)
+	suite.Empty(changes.Deleted)
+}
+
+func (suite *cardDAVTestSuite) TestSyncCollection_WhenIncremental() {
+	suite.book.Mock.On("Changes", uint(9)).Return(models.AddressBookChanges{ContactIDs: []uint{2, 1}, Token: 14}, nil)
+	suite.book.Mock.On("Resources", []uint{2, 1}).Return([]models.CardResource{mariaResource, juanResource}, nil)
+	suite.contacts.Mock.On("GetByID", uint(2)).Return(maria, nil)
+	suite.contacts.Mock.On("GetByID", uint(1)).Return(models.Contact{}, apperrors.NotFound("", nil))
+
+	changes, err := suite.client.SyncCollection(context.Background(), "/carddav/contacts/",
+		&carddav.SyncQuery{SyncToken: "urn:contacts-go:sync:9"})
+
+	suite.NoError(err)
+	suite.Equal("urn:contacts-go:sync:14", changes.SyncToken)
+	suite.Require().Len(changes.Updated, 1)
+	suite.Equal("/carddav/contacts/2.vcf", changes.Updated[0].Path)
+	suite.Equal("5", changes.Updated[0].ETag)
+	suite.Equal([]string{"/carddav/contacts/juan.vcf"}, changes.Deleted)
+}
+
+func (suite *cardDAVTestSuite) TestSyncCollection_WhenTokenIsInvalid() {
+	suite.book.Mock.On("Changes", uint(99)).Return(models.AddressBookChanges{}, apperrors.Validation("", nil))
+
+	for _, token := range []string{"urn:contacts-go:sync:99", "http://example.com/sync/1"} {
+		_, err := suite.client.SyncCollection(context.Background(), "/carddav/contacts/",
+			&carddav.SyncQuery{SyncToken: token})
+
+		suite.ErrorContains(err, "403")
+	}
+}
+
+func newCard(uid, name, phone string) govcard.Card {
+	card := govcard.Card{}
+	card.SetValue(govcard.FieldVersion, "3.0")
+	card.SetValue(govcard.FieldUID, uid)
+	card.SetValue(govcard.FieldFormattedName, name)
+	card.SetValue(govcard.FieldTelephone, phone)
+
+	return card
+}
+
+func readBody(response *http.Response) string {
+	body, _ := io.ReadAll(response.Body)
+
+	return string(body)
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/labstack/echo/v4"
+)
+
+// Namespaces of the WebDAV, CardDAV and Calendar Server elements.
+const (
+	nsDAV            = "DAV:"
+	nsCardDAV        = "urn:ietf:params:xml:ns:carddav"
+	nsCalendarServer = "http://calendarserver.org/ns/"
+)
+
+var (
+	nameMultiStatus     = xml.Name{Space: nsDAV, Local: "multistatus"}
+	nameError           = xml.Name{Space: nsDAV, Local: "error"}
+	nameHref            = xml.Name{Space: nsDAV, Local: "href"}
+	nameCollection      = xml.Name{Space: nsDAV, Local: "collection"}
+	namePrincipal       = xml.Name{Space: nsDAV, Local: "principal"}
+	nameSupportedReport = xml.Name{Space: nsDAV, Local: "supported-report"}
+	nameReport          = xml.Name{Space: nsDAV, Local: "report"}
+	nameValidSyncToken  = xml.Name{Space: nsDAV, Local: "valid-sync-token"}
+	nameSyncCollection  = xml.Name{Space: nsDAV, Local: "sync-collection"}
+	nameAddressBook     = xml.Name{Space: nsCardDAV, Local: "addressbook"}
+	nameAddressDataType = xml.Name{Space: nsCardDAV, Local: "address-data-type"}
+	nameQuery           = xml.Name{Space: nsCardDAV, Local: "addressbook-query"}
+	nameMultiGet        = xml.Name{Space: nsCardDAV, Local: "addressbook-multiget"}
+)
+
+// Properties of the resources.
+var (
+	propResourceType           = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	propDisplayName            = xml.Name{Space: nsDAV, Local: "displayname"}
+	propCurrentUserPrincipal   = xml.Name{Space: nsDAV, Local: "current-user-principal"}
+	propPrincipalURL           = xml.Name{Space: nsDAV, Local: "principal-URL"}
+	propGetETag                = xml.Name{Space: nsDAV, Local: "getetag"}
+	propGetContentType         = xml.Name{Space: nsDAV, Local: "getcontenttype"}
+	propGetContentLength       = xml.Name{Space: nsDAV, Local: "getcontentlength"}
+	propSyncToken              = xml.Name{Space: nsDAV, Local: "sync-token"}
+	propSupportedReportSet     = xml.Name{Space: nsDAV, Local: "supported-report-set"}
+	propAddressBookHomeSet     = xml.Name{Space: nsCardDAV, Local: "addressbook-home-set"}
+	propAddressBookDescription = xml.Name{Space: nsCardDAV, Local: "addressbook-description"}
+	propSupportedAddressData   = xml.Name{Space: nsCardDAV, Local: "supported-address-data"}
+	propMaxResourceSize        = xml.Name{Space: nsCardDAV, Local: "max-resource-size"}
+	propAddressData            = xml.Name{Space: nsCardDAV, Local: "address-data"}
+	propGetCTag                = xml.Name{Space: nsCalendarServer, Local: "getctag"}
+)
+
+// element is an XML element built at run time, like the value of a property.
+type element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Text     string     `xml:",chardata"`
+	Children []element
+}
+
+func newElement(name xml.Name, children ...element) element {
+	return element{XMLName: name, Children: children}
+}
+
+func textElement(name xml.Name, text string) element {
+	return element{XMLName: name, Text: text}
+}
+
+func hrefElement(name xml.Name, href string) element {
+	return newElement(name, textElement(nameHref, href))
+}
+
+// davResponse describes one resource in a multistatus body: its properties or,
+// when it can not be described, just a status.
+type davResponse struct {
+	XMLName   xml.Name      `xml:"DAV: response"`
+	Href      string        `xml:"DAV: href"`
+	Status    string        `xml:"DAV: status,omitempty"`
+	Propstats []davPropstat `xml:"DAV: propstat"`
+}
+
+// davPropstat groups the properties of a resource that share a status.
+type davPropstat struct {
+	Prop   davProp `xml:"DAV: prop"`
+	Status string  `xml:"DAV: status"`
+}
+
+type davProp struct {
+	Values []element
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+// multiStatus streams a 207 Multi-Status body. Nothing is sent before the first
+// response, so failures found until then are still reported with their status.
+type multiStatus struct {
+	response *echo.Response
+	encoder  *xml.Encoder
+}
+
+func newMultiStatus(ctx echo.Context) *multiStatus {
+	return &multiStatus{response: ctx.Response()}
+}
+
+func (status *multiStatus) start() error {
+	if status.encoder != nil {
+		return nil
+	}
+
+	status.response.Header().Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
+	status.response.WriteHeader(http.StatusMultiStatus)
+
+	if _, err := io.WriteString(status.response, xml.Header); err != nil {
+		return err
+	}
+
+	status.encoder = xml.NewEncoder(status.response)
+
+	return status.encoder.EncodeToken(xml.StartElement{Name: nameMultiStatus})
+}
+
+func (status *multiStatus) add(response davResponse) error {
+	if err := status.start(); err != nil {
+		return err
+	}
+
+	return status.encoder.Encode(response)
+}
+
+// close ends the body, with the sync token of the address book when it is given.
+func (status *multiStatus) close(syncToken string) error {
+	if err := status.start(); err != nil {
+		return err
+	}
+
+	if syncToken != "" {
+		if err := status.encoder.EncodeElement(syncToken, xml.StartElement{Name: propSyncToken}); err != nil {
+			return err
+		}
+	}
+
+	if err := status.encoder.EncodeToken(xml.EndElement{Name: nameMultiStatus}); err != nil {
+		return err
+	}
+
+	return status.encoder.Flush()
+}
+
+// davError reports a failed precondition of WebDAV with the element naming it.
+func davError(ctx echo.Context, code int, condition xml.Name) error {
+	body, err := xml.Marshal(newElement(nameError, newElement(condition)))
+	if err != nil {
+		return apperrors.Internal(err)
+	}
+
+	return ctx.Blob(code, echo.MIMEApplicationXMLCharsetUTF8, append([]byte(xml.Header), body...))
+}
+
+// propRequest lists the properties asked for. Without names every property is
+// asked for, and with namesOnly only their names are wanted. version is the
+// version of the vCards asked for in address-data.
+type propRequest struct {
+	names     []xml.Name
+	namesOnly bool
+	version   string
+}
+
+func (request *propRequest) UnmarshalXML(decoder *xml.Decoder, _ xml.StartElement) error {
+	request.version = vcard.Version3
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			request.names = append(request.names, token.Name)
+
+			for _, attr := range token.Attr {
+				if token.Name == propAddressData && attr.Name.Local == "version" && vcard.IsVersion(attr.Value) {
+					request.version = attr.Value
+				}
+			}
+
+			if err := decoder.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// propFind is the body of a PROPFIND, an empty one asks for every property.
+type propFind struct {
+	XMLName  xml.Name     `xml:"DAV: propfind"`
+	Prop     *propRequest `xml:"DAV: prop"`
+	PropName *struct{}    `xml:"DAV: propname"`
+}
+
+// readPropFind reads the properties a PROPFIND asks for.
+func readPropFind(body io.Reader) (propRequest, error) {
+	var request propFind
+
+	err := xml.NewDecoder(body).Decode(&request)
+	if errors.Is(err, io.EOF) {
+		return propRequest{version: vcard.Version3}, nil
+	}
+
+	if err != nil {
+		return propRequest{}, apperrors.Validation(invalidRequestMessage, err)
+	}
+
+	if request.Prop != nil {
+		return *request.Prop, nil
+	}
+
+	return propRequest{namesOnly: request.PropName != nil, version: vcard.Version3}, nil
+}
+
+// report is the body of the REPORTs served, its root element tells which one it
+// is and the rest of the fields are only read by the matching report.
+type report struct {
+	XMLName   xml.Name
+	Prop      *propRequest `xml:"DAV: prop"`
+	Hrefs     []string     `xml:"DAV: href"`
+	Filter    queryFilter  `xml:"urn:ietf:params:xml:ns:carddav filter"`
+	Limit     *davLimit    `xml:"limit"`
+	SyncToken string       `xml:"DAV: sync-token"`
+}
+
+type davLimit struct {
+	NResults int `xml:"nresults"`
+}
+
+// props are the properties the report asks for, the ETag when it names none.
+func (request report) props() propRequest {
+	if request.Prop == nil {
+		return propRequest{names: []xml.Name{propGetETag}, version: vcard.Version3}
+	}
+
+	return *request.Prop
+}
+
+// limit is the most responses the report wants, 0 when it wants them all.
+func (request report) limit() int {
+	if request.Limit == nil || request.Limit.NResults < 0 {
+		return 0
+	}
+
+	return request.Limit.NResults
+}
@@ -3,11 +3,12 @@ package handler
 import (
 	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/AjxGnx/contacts-go/internal/app"
 	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
 	"github.com/labstack/echo/v4"
 )
 
@@ -15,17 +16,38 @@ type Contacts interface {
 	Create(ctx echo.Context) error
 	GetByID(ctx echo.Context) error
 	Update(ctx echo.Context) error
+	Patch(ctx echo.Context) error
 	Delete(ctx echo.Context) error
 	Get(ctx echo.Context) error
+	GetTrash(ctx echo.Context) error
+	Restore(ctx echo.Context) error
+	Purge(ctx echo.Context) error
+	GetRevisions(ctx echo.Context) error
+	Revert(ctx echo.Context) error
+	Import(ctx echo.Context) error
+	Export(ctx echo.Context) error
+	Batch(ctx echo.Context) error
+	Duplicates(ctx echo.Context) error
+	Merge(ctx echo.Context) error
+	Search(ctx echo.Context) error
 }
 
 type contacts struct {
-	app app.Contacts
+	app      app.Contacts
+	cursors  dto.CursorCodec
+	paginate dto.PaginateConfig
+	imports  dto.ImportConfig
+	batches  dto.BatchConfig
 }
 
-func NewContacts(app app.Contacts) Contacts {
+func NewContacts(app app.Contacts, cursors dto.CursorCodec, paginate dto.PaginateConfig,
+	imports dto.ImportConfig, batches dto.BatchConfig) Contacts {
 	return &contacts{
 		app,
+		cursors,
+		paginate,
+		imports,
+		batches,
 	}
 }
 
@@ -34,31 +56,34 @@ func NewContacts(app app.Contacts) Contacts {
 // @Description  Create a contact
 // @Accept       json
 // @Produce      json
-// @Param        request  body      dto.Contact  true  "Request Body"
-// @Success      200      {object}  dto.Message{data=models.Contact}
-// @Failure      400      {object}  dto.MessageError
-// @Failure      500      {object}  dto.MessageError
+// @Param        request          body      dto.Contact  true   "Request Body"
+// @Param        X-Actor          header    string       false  "author of the change, anonymous by default"
+// @Param        Idempotency-Key  header    string       false  "key making retries of the request replay its first response"
+// @Success      200              {object}  dto.Message{data=models.Contact}
+// @Header       200              {string}  ETag  "version of the contact"
+// @Failure      400              {object}  dto.Problem
+// @Failure      409              {object}  dto.Problem
+// @Failure      422              {object}  dto.Problem
+// @Failure      500              {object}  dto.Problem
 // @Router       /contacts/ [post]
 func (handler *contacts) Create(ctx echo.Context) error {
 	var contact dto.Contact
 
 	if err := ctx.Bind(&contact); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return bindError(err)
 	}
 
 	if err := contact.Validate(); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	result, err := handler.app.Create(contact)
+	result, err := handler.app.Create(origin(ctx), contact)
 	if err != nil {
-		if strings.Contains(err.Error(), "SQLSTATE 23505") {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("your contact number %s already exists",
-				contact.PhoneNumber))
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
+	ctx.Response().Header().Set(HeaderETag, etag(result.Version))
+
 	return ctx.JSON(http.StatusOK, dto.Message{
 		Message: "contact created successfully",
 		Data:    result,
@@ -67,21 +92,43 @@ func (handler *contacts) Create(ctx echo.Context) error {
 
 // @Tags         Contacts
 // @Summary      Get Contact by id
-// @Description  Get Contact by id
+// @Description  Get Contact by id, or as it was at the time given in as_of. Ending the id with .vcf gets it as a vCard
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "value of record to find"
-// @Success      200      {object}  models.Contact
-// @Failure      404      {object}  dto.MessageError
-// @Failure      500      {object}  dto.MessageError
+// @Param        id             path      int     true   "value of record to find"
+// @Param        as_of          query     string  false  "RFC 3339 timestamp, returns the contact as it was then"
+// @Param        If-None-Match  header    string  false  "ETag already known by the client"
+// @Success      200      {object}  dto.Message{data=models.Contact}
+// @Header       200      {string}  ETag  "version of the contact"
+// @Success      304      "the contact did not change"
+// @Failure      400      {object}  dto.Problem
+// @Failure      404      {object}  dto.Problem
+// @Failure      500      {object}  dto.Problem
 // @Router       /contacts/{id} [get]
 func (handler *contacts) GetByID(ctx echo.Context) error {
-	id, _ := strconv.Atoi(ctx.Param("id"))
+	if strings.HasSuffix(ctx.Param(idParam), vcard.Extension) {
+		return handler.getVCard(ctx)
+	}
 
-	contact, err := handler.app.GetByID(uint(id))
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if asOf := ctx.QueryParam("as_of"); asOf != "" {
+		return handler.getAsOf(ctx, id, asOf)
+	}
+
+	contact, err := handler.app.GetByID(id)
 
 	if err != nil {
-		return errorValidator(err.Error(), id)
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(contact.Version))
+
+	if ifNoneMatch(ctx, contact.Version) {
+		return ctx.NoContent(http.StatusNotModified)
 	}
 
 	return ctx.JSON(http.StatusOK, dto.Message{
@@ -92,33 +139,97 @@ func (handler *contacts) GetByID(ctx echo.Context) error {
 
 // @Tags         Contacts
 // @Summary      Update Contact by id
-// @Description  Update Contact by id
+// @Description  Replace every field of the Contact, fields left out are cleared
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dto.Contact  true  "Request Body"
 // @Param        id       path      int          true  "value of record to update"
+// @Param        If-Match header    string       false "ETag of the version being replaced"
+// @Param        X-Actor  header    string       false "author of the change, anonymous by default"
 // @Success      200  {object}  models.Contact
-// @Failure      404  {object}  dto.MessageError
-// @Failure      500  {object}  dto.MessageError
+// @Header       200  {string}  ETag  "version of the contact"
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      409  {object}  dto.Problem
+// @Failure      412  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
 // @Router       /contacts/{id} [put]
 func (handler *contacts) Update(ctx echo.Context) error {
 	var contact dto.Contact
 
-	contactID, _ := strconv.Atoi(ctx.Param("id"))
+	contactID, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
 
 	if err := ctx.Bind(&contact); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return bindError(err)
 	}
 
 	if err := contact.Validate(); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	result, err := handler.app.Update(uint(contactID), contact)
+	version, err := ifMatch(ctx, versionOf(handler.app.GetByID, contactID))
 	if err != nil {
-		return errorValidator(err.Error(), contactID)
+		return err
 	}
 
+	result, err := handler.app.Update(origin(ctx), contactID, contact, version)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(result.Version))
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "contact updated successfully",
+		Data:    result,
+	})
+}
+
+// @Tags         Contacts
+// @Summary      Partially update Contact by id
+// @Description  Patch the Contact with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) document,
+// @Description  the patched Contact is validated as a whole before being saved
+// @Accept       application/merge-patch+json,application/json-patch+json
+// @Produce      json
+// @Param        request  body      object  true  "merge patch object or array of patch operations"
+// @Param        id       path      int     true  "value of record to update"
+// @Param        If-Match header    string  false "ETag of the version being patched"
+// @Param        X-Actor  header    string  false "author of the change, anonymous by default"
+// @Success      200  {object}  dto.Message{data=models.Contact}
+// @Header       200  {string}  ETag  "version of the contact"
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      409  {object}  dto.Problem
+// @Failure      412  {object}  dto.Problem
+// @Failure      415  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /contacts/{id} [patch]
+func (handler *contacts) Patch(ctx echo.Context) error {
+	contactID, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	patch, err := bindPatch(ctx)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatch(ctx, versionOf(handler.app.GetByID, contactID))
+	if err != nil {
+		return err
+	}
+
+	result, err := handler.app.Patch(origin(ctx), contactID, patch, version)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(result.Version))
+
 	return ctx.JSON(http.StatusOK, dto.Message{
 		Message: "contact updated successfully",
 		Data:    result,
@@ -127,19 +238,31 @@ func (handler *contacts) Update(ctx echo.Context) error {
 
 // @Tags         Contacts
 // @Summary      Delete Contact by id
-// @Description  Delete Contact by id
+// @Description  Move the Contact to the trash, it can be restored until it is purged
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "value of record to delete"
+// @Param        id        path      int     true   "value of record to delete"
+// @Param        If-Match  header    string  false  "ETag of the version being deleted"
+// @Param        X-Actor   header    string  false  "author of the change, anonymous by default"
 // @Success      200  {object}  dto.Message{}
-// @Failure      404  {object}  dto.MessageError
-// @Failure      500  {object}  dto.MessageError
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      412  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
 // @Router       /contacts/{id} [delete]
 func (handler *contacts) Delete(ctx echo.Context) error {
-	id, _ := strconv.Atoi(ctx.Param("id"))
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatch(ctx, versionOf(handler.app.GetByID, id))
+	if err != nil {
+		return err
+	}
 
-	if err := handler.app.Delete(uint(id)); err != nil {
-		return errorValidator(err.Error(), id)
+	if err := handler.app.Delete(origin(ctx), id, version); err != nil {
+		return err
 	}
 
 	return ctx.JSON(http.StatusOK, dto.Message{
@@ -149,26 +272,59 @@ func (handler *contacts) Delete(ctx echo.Context) error {
 
 // @Tags         Contacts
 // @Summary      Get contacts
-// @Description  Get contacts using pagination
+// @Description  Get contacts using pagination, search, filters and sorting. Sending the cursor param, even empty,
+// @Description  switches to keyset pagination: pages are walked with the next_cursor and prev_cursor of the response.
 // @Accept       json
 // @Produce      json
-// @Param        limit  query     string  true  "limit to find records"
-// @Param        page   query     string  true  "page to find records"
+// @Param        limit                query     int     false  "limit to find records, 10 by default"
+// @Param        page                 query     int     false  "page to find records, 1 by default"
+// @Param        cursor               query     string  false  "opaque cursor returned by a previous page, empty for the first one"
+// @Param        search               query     string  false  "text to search in name, nickname and phone number"
+// @Param        name                 query     string  false  "exact display name"
+// @Param        name_prefix          query     string  false  "display name prefix"
+// @Param        given_name           query     string  false  "exact given name"
+// @Param        given_name_prefix    query     string  false  "given name prefix"
+// @Param        family_name          query     string  false  "exact family name"
+// @Param        family_name_prefix   query     string  false  "family name prefix"
+// @Param        nickname             query     string  false  "exact nickname"
+// @Param        phone_number         query     string  false  "exact phone number"
+// @Param        phone_number_prefix  query     string  false  "phone number prefix"
+// @Param        tag                  query     []string  false  "tag names, repeated or comma separated"  collectionFormat(multi)
+// @Param        tag_match            query     string  false  "all, the default, keeps contacts having every tag, any the ones having one of them"  Enums(all, any)
+// @Param        sort                 query     string  false  "comma separated fields, prefix with - for descending order"  example(name,-id)
 // @Success      200    {object}  dto.Message{data=models.Paginator{records=[]models.Contact}}
-// @Failure      500    {object}  dto.MessageError
+// @Failure      400    {object}  dto.Problem
+// @Failure      500    {object}  dto.Problem
 // @Router       /contacts/ [get]
 func (handler *contacts) Get(context echo.Context) error {
-	page, _ := strconv.Atoi(context.QueryParam("page"))
-	limit, _ := strconv.Atoi(context.QueryParam("limit"))
-	paginate := dto.Paginate{
-		Page:  page,
-		Limit: limit,
+	paginate, err := dto.ParsePaginate(context.QueryParam("page"), context.QueryParam("limit"), handler.paginate)
+	if err != nil {
+		return err
+	}
+
+	if err := listParams(context, &paginate); err != nil {
+		return err
+	}
+
+	if err := handler.setCursor(context, &paginate); err != nil {
+		return err
+	}
+
+	if err := paginate.Validate(); err != nil {
+		return err
 	}
-	paginate.SetDefaultLimitAndPage()
 
 	categorizations, err := handler.app.Get(paginate)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
+	}
+
+	if categorizations.Next != nil {
+		categorizations.NextCursor = handler.cursors.Encode(*categorizations.Next, paginate.Sort)
+	}
+
+	if categorizations.Prev != nil {
+		categorizations.PrevCursor = handler.cursors.Encode(*categorizations.Prev, paginate.Sort)
 	}
 
 	return context.JSON(http.StatusOK, dto.Message{
@@ -178,10 +334,279 @@ func (handler *contacts) Get(context echo.Context) error {
 
 }
 
-func errorValidator(errMessage string, id ...int) error {
-	if errMessage == "record not found" {
-		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("the contact: %v does not exist", id))
+// @Tags         Contacts
+// @Summary      Search contacts
+// @Description  Full-text search over the names, nicknames, companies, notes and email addresses of the contacts. Accents
+// @Description  are ignored and typos tolerated; results are ranked by how well they match, with the words found
+// @Description  highlighted
+// @Produce      json
+// @Param        q      query     string  true   "search, between 2 and 200 characters"
+// @Param        limit  query     int     false  "limit to find records, 10 by default"
+// @Param        page   query     int     false  "page to find records, 1 by default"
+// @Success      200    {object}  dto.Message{data=models.Paginator{records=[]models.SearchResult}}
+// @Failure      400    {object}  dto.Problem
+// @Failure      500    {object}  dto.Problem
+// @Router       /contacts/search [get]
+func (handler *contacts) Search(ctx echo.Context) error {
+	query, err := dto.ParseSearch("q", ctx.QueryParam("q"))
+	if err != nil {
+		return err
+	}
+
+	paginate, err := dto.ParsePaginate(ctx.QueryParam("page"), ctx.QueryParam("limit"), handler.paginate)
+	if err != nil {
+		return err
+	}
+
+	results, err := handler.app.Search(query, paginate)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "search successfully completed",
+		Data:    results,
+	})
+}
+
+// @Tags         Contacts
+// @Summary      Get the contacts in the trash
+// @Description  Get the deleted contacts that were not purged yet, the most recently deleted first
+// @Produce      json
+// @Param        limit  query     int  false  "limit to find records, 10 by default"
+// @Param        page   query     int  false  "page to find records, 1 by default"
+// @Success      200    {object}  dto.Message{data=models.Paginator{records=[]models.Contact}}
+// @Failure      400    {object}  dto.Problem
+// @Failure      500    {object}  dto.Problem
+// @Router       /contacts/trash [get]
+func (handler *contacts) GetTrash(ctx echo.Context) error {
+	paginate, err := dto.ParsePaginate(ctx.QueryParam("page"), ctx.QueryParam("limit"), handler.paginate)
+	if err != nil {
+		return err
+	}
+
+	trash, err := handler.app.GetTrash(paginate)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "trash successfully loaded",
+		Data:    trash,
+	})
+}
+
+// @Tags         Contacts
+// @Summary      Restore Contact by id
+// @Description  Take the Contact out of the trash, it fails with a 409 when its phone number was taken meanwhile
+// @Produce      json
+// @Param        id       path      int     true   "value of record to restore"
+// @Param        X-Actor  header    string  false  "author of the change, anonymous by default"
+// @Success      200      {object}  dto.Message{data=models.Contact}
+// @Header       200      {string}  ETag  "version of the contact"
+// @Failure      400      {object}  dto.Problem
+// @Failure      404      {object}  dto.Problem
+// @Failure      409      {object}  dto.Problem
+// @Failure      500      {object}  dto.Problem
+// @Router       /contacts/{id}/restore [post]
+func (handler *contacts) Restore(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	contact, err := handler.app.Restore(origin(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(contact.Version))
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "contact successfully restored",
+		Data:    contact,
+	})
+}
+
+// @Tags         Contacts
+// @Summary      Purge Contact by id
+// @Description  Delete for good a Contact that is in the trash
+// @Produce      json
+// @Param        id       path      int     true   "value of record to purge"
+// @Param        X-Actor  header    string  false  "author of the change, anonymous by default"
+// @Success      200      {object}  dto.Message{}
+// @Failure      400      {object}  dto.Problem
+// @Failure      404      {object}  dto.Problem
+// @Failure      500      {object}  dto.Problem
+// @Router       /contacts/trash/{id} [delete]
+func (handler *contacts) Purge(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.app.Purge(origin(ctx), id); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "contact successfully purged",
+	})
+}
+
+// getAsOf responds with the contact as it was at asOf. No ETag is sent, the
+// revision returned may not be the current one.
+func (handler *contacts) getAsOf(ctx echo.Context, id uint, asOf string) error {
+	at, err := dto.ParseTime("as_of", asOf)
+	if err != nil {
+		return err
+	}
+
+	contact, err := handler.app.GetAsOf(id, at)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "contact successfully loaded",
+		Data:    contact,
+	})
+}
+
+// @Tags         Contacts
+// @Summary      Get the revisions of a Contact
+// @Description  Get the states the Contact had after each write, the newest first
+// @Produce      json
+// @Param        id     path      int  true   "value of the contact"
+// @Param        limit  query     int  false  "limit to find records, 10 by default"
+// @Param        page   query     int  false  "page to find records, 1 by default"
+// @Success      200    {object}  dto.Message{data=models.Paginator{records=[]models.Revision}}
+// @Failure      400    {object}  dto.Problem
+// @Failure      404    {object}  dto.Problem
+// @Failure      500    {object}  dto.Problem
+// @Router       /contacts/{id}/revisions [get]
+func (handler *contacts) GetRevisions(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	paginate, err := dto.ParsePaginate(ctx.QueryParam("page"), ctx.QueryParam("limit"), handler.paginate)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := handler.app.GetRevisions(id, paginate)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "revisions successfully loaded",
+		Data:    revisions,
+	})
+}
+
+// @Tags         Contacts
+// @Summary      Revert Contact to a revision
+// @Description  Write again the content the Contact had at a revision, the revert is saved as a new revision
+// @Produce      json
+// @Param        id        path      int     true   "value of the contact"
+// @Param        rev       path      int     true   "version of the revision to go back to"
+// @Param        If-Match  header    string  false  "ETag of the version being reverted"
+// @Param        X-Actor   header    string  false  "author of the change, anonymous by default"
+// @Success      200       {object}  dto.Message{data=models.Contact}
+// @Header       200       {string}  ETag  "version of the contact"
+// @Failure      400       {object}  dto.Problem
+// @Failure      404       {object}  dto.Problem
+// @Failure      409       {object}  dto.Problem
+// @Failure      412       {object}  dto.Problem
+// @Failure      500       {object}  dto.Problem
+// @Router       /contacts/{id}/revisions/{rev}/revert [post]
+func (handler *contacts) Revert(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	revision, err := dto.ParseID(revisionParam, ctx.Param(revisionParam))
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatch(ctx, versionOf(handler.app.GetByID, id))
+	if err != nil {
+		return err
+	}
+
+	contact, err := handler.app.Revert(origin(ctx), id, revision, version)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(contact.Version))
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "contact successfully reverted",
+		Data:    contact,
+	})
+}
+
+// setCursor switches paginate to keyset pagination when the cursor query param is
+// sent, the sort of the listing is the one the cursor was built for.
+func (handler *contacts) setCursor(context echo.Context, paginate *dto.Paginate) error {
+	if !context.QueryParams().Has("cursor") {
+		return nil
+	}
+
+	value := context.QueryParam("cursor")
+	if value == "" {
+		paginate.Cursor = &models.Cursor{}
+		return nil
+	}
+
+	cursor, sort, err := handler.cursors.Decode(value, paginate.Sort)
+	if err != nil {
+		return err
+	}
+
+	paginate.Cursor = &cursor
+	paginate.Sort = sort
+
+	return nil
+}
+
+// listParams reads into paginate the search, filters, tags and sort of a listing.
+func listParams(context echo.Context, paginate *dto.Paginate) error {
+	var err error
+
+	paginate.Search = strings.TrimSpace(context.QueryParam("search"))
+	paginate.Filters = fieldFilters(context)
+
+	paginate.Tags, paginate.AnyTag, err = dto.ParseTags(context.QueryParams()["tag"], context.QueryParam("tag_match"))
+	if err != nil {
+		return err
+	}
+
+	paginate.Sort = dto.ParseSort(context.QueryParam("sort"))
+
+	return nil
+}
+
+// fieldFilters reads the "<field>" (exact match) and "<field>_prefix" query params
+// of every filterable field.
+func fieldFilters(context echo.Context) []models.FieldFilter {
+	var filters []models.FieldFilter
+
+	for _, field := range models.ContactFilterFields {
+		if value := context.QueryParam(field); value != "" {
+			filters = append(filters, models.FieldFilter{Field: field, Value: value})
+		}
+
+		if value := context.QueryParam(field + "_prefix"); value != "" {
+			filters = append(filters, models.FieldFilter{Field: field, Value: value, Prefix: true})
+		}
 	}
 
-	return echo.NewHTTPError(http.StatusInternalServerError, errMessage)
+	return filters
 }
@@ -10,7 +10,9 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
 	"github.com/AjxGnx/contacts-go/internal/domain/dto"
 	"github.com/AjxGnx/contacts-go/internal/domain/models"
 	mocks "github.com/AjxGnx/contacts-go/mocks/app"
@@ -18,6 +20,10 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// anonymous is the origin of the changes made by requests that do not name their
+// actor.
+var anonymous = models.Origin{Actor: anonymousActor}
+
 type contactsTestSuite struct {
 	suite.Suite
 	app       *mocks.Contacts
@@ -30,24 +36,23 @@ func TestContactsSuite(t *testing.T) {
 
 func (suite *contactsTestSuite) SetupTest() {
 	suite.app = &mocks.Contacts{}
-	suite.underTest = NewContacts(suite.app)
+	suite.underTest = NewContacts(suite.app, dto.NewCursorCodec("secret"), dto.PaginateConfig{MaxLimit: 100},
+		dto.ImportConfig{MaxRows: 3}, dto.BatchConfig{MaxOperations: 3})
 }
 
 func (suite *contactsTestSuite) TestCreate_WhenBindFail() {
-	var httpError *echo.HTTPError
-
 	body, _ := json.Marshal("")
 
 	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/", bytes.NewBuffer(body))
 	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
-	suite.ErrorAs(suite.underTest.Create(setupCase.context), &httpError)
-	suite.Equal(http.StatusBadRequest, httpError.Code)
+	err := suite.underTest.Create(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestCreate_WhenValidateFail() {
-	var httpError *echo.HTTPError
-
 	body := `{
 		"name": "some string"
 	}`
@@ -55,13 +60,14 @@ func (suite *contactsTestSuite) TestCreate_WhenValidateFail() {
 	setupCase := SetupControllerCase(http.MethodPost, "/api/exercise/numbers/", strings.NewReader(body))
 	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
-	suite.ErrorAs(suite.underTest.Create(setupCase.context), &httpError)
-	suite.Equal(http.StatusBadRequest, httpError.Code)
+	err := suite.underTest.Create(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestCreate_WhenFailByDuplicateContact() {
-	var httpError *echo.HTTPError
-	err := errors.New("SQLSTATE 23505")
+	expectedError := apperrors.Conflict("your contact number +570000000 already exists", nil)
 	contact := dto.Contact{
 		Name:        "test1",
 		PhoneNumber: "+570000000",
@@ -71,15 +77,16 @@ func (suite *contactsTestSuite) TestCreate_WhenFailByDuplicateContact() {
 	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/", bytes.NewBuffer(body))
 	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
-	suite.app.Mock.On("Create", contact).Return(models.Contact{}, err)
+	suite.app.Mock.On("Create", anonymous, contact).Return(models.Contact{}, expectedError)
 
-	suite.ErrorAs(suite.underTest.Create(setupCase.context), &httpError)
-	suite.Equal(http.StatusBadRequest, httpError.Code)
+	err := suite.underTest.Create(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusConflict, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestCreate_WhenFailByInternalError() {
-	var httpError *echo.HTTPError
-	err := errors.New("some error")
+	expectedError := errors.New("some error")
 	contact := dto.Contact{
 		Name:        "test2",
 		PhoneNumber: "+570000001",
@@ -89,10 +96,12 @@ func (suite *contactsTestSuite) TestCreate_WhenFailByInternalError() {
 	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/", bytes.NewBuffer(body))
 	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
-	suite.app.Mock.On("Create", contact).Return(models.Contact{}, err)
+	suite.app.Mock.On("Create", anonymous, contact).Return(models.Contact{}, expectedError)
 
-	suite.ErrorAs(suite.underTest.Create(setupCase.context), &httpError)
-	suite.Equal(http.StatusInternalServerError, httpError.Code)
+	err := suite.underTest.Create(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusInternalServerError, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestCreate_WhenSuccess() {
@@ -105,7 +114,7 @@ func (suite *contactsTestSuite) TestCreate_WhenSuccess() {
 	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/", bytes.NewBuffer(body))
 	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
-	suite.app.Mock.On("Create", contact).
+	suite.app.Mock.On("Create", anonymous, contact).
 		Return(models.Contact{Name: contact.Name, PhoneNumber: contact.PhoneNumber}, nil)
 
 	suite.NoError(suite.underTest.Create(setupCase.context))
@@ -128,11 +137,9 @@ func (suite *contactsTestSuite) TestGetByID_WhenSuccess() {
 }
 
 func (suite *contactsTestSuite) TestGetByID_WhenContactNotFound() {
-	var httpError *echo.HTTPError
-
 	paramValue := 10
 	param := "id"
-	expectedError := errors.New("record not found")
+	expectedError := apperrors.NotFound("the contact: 10 does not exist", nil)
 
 	suite.app.Mock.On("GetByID", uint(paramValue)).
 		Return(models.Contact{}, expectedError)
@@ -142,13 +149,13 @@ func (suite *contactsTestSuite) TestGetByID_WhenContactNotFound() {
 	setupCase.context.SetParamNames(param)
 	setupCase.context.SetParamValues(strconv.Itoa(paramValue))
 
-	suite.ErrorAs(suite.underTest.GetByID(setupCase.context), &httpError)
-	suite.Equal(http.StatusNotFound, httpError.Code)
+	err := suite.underTest.GetByID(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestGetByID_WhenFail() {
-	var httpError *echo.HTTPError
-
 	paramValue := 10
 	param := "id"
 	expectedError := errors.New("some error")
@@ -161,8 +168,10 @@ func (suite *contactsTestSuite) TestGetByID_WhenFail() {
 	setupCase.context.SetParamNames(param)
 	setupCase.context.SetParamValues(strconv.Itoa(paramValue))
 
-	suite.ErrorAs(suite.underTest.GetByID(setupCase.context), &httpError)
-	suite.Equal(http.StatusInternalServerError, httpError.Code)
+	err := suite.underTest.GetByID(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusInternalServerError, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestUpdate_WhenSuccess() {
@@ -176,7 +185,7 @@ func (suite *contactsTestSuite) TestUpdate_WhenSuccess() {
 
 	body, _ := json.Marshal(contact)
 
-	suite.app.Mock.On("Update", uint(paramValue), contact).
+	suite.app.Mock.On("Update", anonymous, uint(paramValue), contact, uint(0)).
 		Return(models.Contact{ID: 10}, nil)
 
 	setupCase := SetupControllerCase(http.MethodPut, "/api/contacts/10", bytes.NewBuffer(body))
@@ -189,8 +198,6 @@ func (suite *contactsTestSuite) TestUpdate_WhenSuccess() {
 }
 
 func (suite *contactsTestSuite) TestUpdate_WhenContactNotFound() {
-	var httpError *echo.HTTPError
-
 	paramValue := 10
 	param := "id"
 
@@ -199,11 +206,11 @@ func (suite *contactsTestSuite) TestUpdate_WhenContactNotFound() {
 		PhoneNumber: "+570000002",
 	}
 
-	expectedError := errors.New("record not found")
+	expectedError := apperrors.NotFound("the contact: 10 does not exist", nil)
 
 	body, _ := json.Marshal(contact)
 
-	suite.app.Mock.On("Update", uint(paramValue), contact).
+	suite.app.Mock.On("Update", anonymous, uint(paramValue), contact, uint(0)).
 		Return(models.Contact{}, expectedError)
 
 	setupCase := SetupControllerCase(http.MethodPut, "/api/contacts/10", bytes.NewBuffer(body))
@@ -211,13 +218,13 @@ func (suite *contactsTestSuite) TestUpdate_WhenContactNotFound() {
 	setupCase.context.SetParamNames(param)
 	setupCase.context.SetParamValues(strconv.Itoa(paramValue))
 
-	suite.ErrorAs(suite.underTest.Update(setupCase.context), &httpError)
-	suite.Equal(http.StatusNotFound, httpError.Code)
+	err := suite.underTest.Update(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestUpdate_WhenFail() {
-	var httpError *echo.HTTPError
-
 	paramValue := 10
 	param := "id"
 
@@ -230,7 +237,7 @@ func (suite *contactsTestSuite) TestUpdate_WhenFail() {
 
 	body, _ := json.Marshal(contact)
 
-	suite.app.Mock.On("Update", uint(paramValue), contact).
+	suite.app.Mock.On("Update", anonymous, uint(paramValue), contact, uint(0)).
 		Return(models.Contact{}, expectedError)
 
 	setupCase := SetupControllerCase(http.MethodPut, "/api/contacts/10", bytes.NewBuffer(body))
@@ -238,24 +245,26 @@ func (suite *contactsTestSuite) TestUpdate_WhenFail() {
 	setupCase.context.SetParamNames(param)
 	setupCase.context.SetParamValues(strconv.Itoa(paramValue))
 
-	suite.ErrorAs(suite.underTest.Update(setupCase.context), &httpError)
-	suite.Equal(http.StatusInternalServerError, httpError.Code)
+	err := suite.underTest.Update(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusInternalServerError, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestUpdate_WhenBindFail() {
-	var httpError *echo.HTTPError
-
 	body, _ := json.Marshal("")
 
-	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/", bytes.NewBuffer(body))
+	setupCase := SetupControllerCase(http.MethodPut, "/api/contacts/10", bytes.NewBuffer(body))
 	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Update(setupCase.context)
 
-	suite.ErrorAs(suite.underTest.Update(setupCase.context), &httpError)
-	suite.Equal(http.StatusBadRequest, httpError.Code)
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
 }
 func (suite *contactsTestSuite) TestUpdate_WhenValidateFail() {
-	var httpError *echo.HTTPError
-
 	paramValue := 10
 	param := "id"
 
@@ -271,15 +280,17 @@ func (suite *contactsTestSuite) TestUpdate_WhenValidateFail() {
 	setupCase.context.SetParamNames(param)
 	setupCase.context.SetParamValues(strconv.Itoa(paramValue))
 
-	suite.ErrorAs(suite.underTest.Update(setupCase.context), &httpError)
-	suite.Equal(http.StatusBadRequest, httpError.Code)
+	err := suite.underTest.Update(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestDelete_WhenSuccess() {
 	paramValue := 10
 	param := "id"
 
-	suite.app.Mock.On("Delete", uint(paramValue)).
+	suite.app.Mock.On("Delete", anonymous, uint(paramValue), uint(0)).
 		Return(nil)
 
 	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
@@ -292,13 +303,11 @@ func (suite *contactsTestSuite) TestDelete_WhenSuccess() {
 }
 
 func (suite *contactsTestSuite) TestDelete_WhenContactNotFound() {
-	var httpError *echo.HTTPError
-
 	paramValue := 10
 	param := "id"
-	expectedError := errors.New("record not found")
+	expectedError := apperrors.NotFound("the contact: 10 does not exist", nil)
 
-	suite.app.Mock.On("Delete", uint(paramValue)).
+	suite.app.Mock.On("Delete", anonymous, uint(paramValue), uint(0)).
 		Return(expectedError)
 
 	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
@@ -306,18 +315,18 @@ func (suite *contactsTestSuite) TestDelete_WhenContactNotFound() {
 	setupCase.context.SetParamNames(param)
 	setupCase.context.SetParamValues(strconv.Itoa(paramValue))
 
-	suite.ErrorAs(suite.underTest.Delete(setupCase.context), &httpError)
-	suite.Equal(http.StatusNotFound, httpError.Code)
+	err := suite.underTest.Delete(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestDelete_WhenFail() {
-	var httpError *echo.HTTPError
-
 	paramValue := 10
 	param := "id"
 	expectedError := errors.New("some error")
 
-	suite.app.Mock.On("Delete", uint(paramValue)).
+	suite.app.Mock.On("Delete", anonymous, uint(paramValue), uint(0)).
 		Return(expectedError)
 
 	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
@@ -325,8 +334,10 @@ func (suite *contactsTestSuite) TestDelete_WhenFail() {
 	setupCase.context.SetParamNames(param)
 	setupCase.context.SetParamValues(strconv.Itoa(paramValue))
 
-	suite.ErrorAs(suite.underTest.Delete(setupCase.context), &httpError)
-	suite.Equal(http.StatusInternalServerError, httpError.Code)
+	err := suite.underTest.Delete(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusInternalServerError, StatusCode(err))
 }
 
 func (suite *contactsTestSuite) TestGet_WhenSuccess() {
@@ -346,8 +357,6 @@ func (suite *contactsTestSuite) TestGet_WhenSuccess() {
 }
 
 func (suite *contactsTestSuite) TestGet_WhenFail() {
-	var httpError *echo.HTTPError
-
 	paginateValues := dto.Paginate{
 		Page:  1,
 		Limit: 10,
@@ -360,8 +369,390 @@ func (suite *contactsTestSuite) TestGet_WhenFail() {
 	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/?page=1&limit=10", nil)
 	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 
-	suite.ErrorAs(suite.underTest.Get(setupCase.context), &httpError)
-	suite.Equal(http.StatusInternalServerError, httpError.Code)
+	err := suite.underTest.Get(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusInternalServerError, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestGet_WhenSearchFilterAndSort() {
+	paginateValues := dto.Paginate{
+		Page:   2,
+		Limit:  5,
+		Search: "juan",
+		Filters: []models.FieldFilter{
+			{Field: "name", Value: "Ju", Prefix: true},
+			{Field: "phone_number", Value: "+570000000"},
+		},
+		Sort: []models.SortField{{Field: "name"}, {Field: "id", Desc: true}},
+	}
+
+	suite.app.Mock.On("Get", paginateValues).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet,
+		"/api/contacts/?page=2&limit=5&search=juan&name_prefix=Ju&phone_number=%2B570000000&sort=name,-id", nil)
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Get(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestGet_WhenNameComponents() {
+	paginateValues := dto.Paginate{
+		Page:  1,
+		Limit: 10,
+		Filters: []models.FieldFilter{
+			{Field: "given_name", Value: "Juan"},
+			{Field: "family_name", Value: "Pé", Prefix: true},
+		},
+		Sort: []models.SortField{{Field: "family_name"}, {Field: "given_name"}},
+	}
+
+	suite.app.Mock.On("Get", paginateValues).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet,
+		"/api/contacts/?given_name=Juan&family_name_prefix=P%C3%A9&sort=family_name,given_name", nil)
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Get(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestGet_WhenTags() {
+	paginateValues := dto.Paginate{
+		Page:   1,
+		Limit:  10,
+		Tags:   []string{"family", "work"},
+		AnyTag: true,
+	}
+
+	suite.app.Mock.On("Get", paginateValues).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/?tag=family&tag=work,family&tag_match=any", nil)
+
+	suite.NoError(suite.underTest.Get(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestGet_WhenTagMatchIsInvalid() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/?tag=family&tag_match=some", nil)
+
+	err := suite.underTest.Get(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Get")
+}
+
+func (suite *contactsTestSuite) TestGet_WhenSortFieldIsNotAllowed() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/?sort=password", nil)
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Get(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Get")
+}
+
+func (suite *contactsTestSuite) TestGet_WhenFirstCursorPage() {
+	paginateValues := dto.Paginate{
+		Page:   1,
+		Limit:  5,
+		Cursor: &models.Cursor{},
+	}
+
+	suite.app.Mock.On("Get", paginateValues).
+		Return(&models.Paginator{Next: &models.Cursor{Keys: []string{"5"}}}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/?cursor=&limit=5", nil)
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Get(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Contains(setupCase.Res.Body.String(), `"next_cursor":"`)
+	suite.NotContains(setupCase.Res.Body.String(), `"prev_cursor"`)
+}
+
+func (suite *contactsTestSuite) TestGet_WhenNextCursorPage() {
+	cursors := dto.NewCursorCodec("secret")
+	sort := []models.SortField{{Field: "name", Desc: true}}
+	cursor := models.Cursor{Keys: []string{"Juan", "5"}}
+
+	paginateValues := dto.Paginate{
+		Page:   1,
+		Limit:  5,
+		Sort:   sort,
+		Cursor: &cursor,
+	}
+
+	suite.app.Mock.On("Get", paginateValues).
+		Return(&models.Paginator{Prev: &models.Cursor{Keys: []string{"Juan", "6"}, Backward: true}}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet,
+		"/api/contacts/?limit=5&cursor="+cursors.Encode(cursor, sort), nil)
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Get(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Contains(setupCase.Res.Body.String(), `"prev_cursor":"`)
+}
+
+func (suite *contactsTestSuite) TestGet_WhenCursorIsInvalid() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/?cursor=forged&limit=5", nil)
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Get(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Get")
+}
+
+func (suite *contactsTestSuite) TestGetByID_WhenIDIsMalformed() {
+	for _, paramValue := range []string{"abc", "0", "-5", "18446744073709551616"} {
+		setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/"+paramValue, nil)
+		setupCase.context.SetParamNames("id")
+		setupCase.context.SetParamValues(paramValue)
+
+		err := suite.underTest.GetByID(setupCase.context)
+
+		suite.Error(err)
+		suite.Equal(http.StatusBadRequest, StatusCode(err), paramValue)
+	}
+
+	suite.app.Mock.AssertNotCalled(suite.T(), "GetByID")
+}
+
+func (suite *contactsTestSuite) TestUpdate_WhenIDIsMalformed() {
+	body, _ := json.Marshal(dto.Contact{Name: "test3", PhoneNumber: "+570000002"})
+
+	setupCase := SetupControllerCase(http.MethodPut, "/api/contacts/abc", bytes.NewBuffer(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("abc")
+
+	err := suite.underTest.Update(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Update")
+}
+
+func (suite *contactsTestSuite) TestDelete_WhenIDIsMalformed() {
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/-5", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("-5")
+
+	err := suite.underTest.Delete(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Delete")
+}
+
+func (suite *contactsTestSuite) TestGet_WhenLimitIsOutOfRange() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/?page=1&limit=1000", nil)
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Get(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Get")
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenMergePatch() {
+	body := `{"name": "new name"}`
+	patch, _ := dto.NewMergePatch([]byte(body))
+
+	suite.app.Mock.On("Patch", anonymous, uint(10), patch, uint(0)).
+		Return(models.Contact{ID: 10, Name: "new name"}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPatch, "/api/contacts/10", strings.NewReader(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, dto.MIMEApplicationMergePatchJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Patch(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenJSONPatch() {
+	body := `[{"op": "replace", "path": "/name", "value": "new name"}]`
+	patch, _ := dto.NewJSONPatch([]byte(body))
+
+	suite.app.Mock.On("Patch", anonymous, uint(10), patch, uint(0)).
+		Return(models.Contact{ID: 10, Name: "new name"}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPatch, "/api/contacts/10", strings.NewReader(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, dto.MIMEApplicationJSONPatchJSON+"; charset=utf-8")
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Patch(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenMediaTypeIsNotSupported() {
+	setupCase := SetupControllerCase(http.MethodPatch, "/api/contacts/10", strings.NewReader(`{"name": "x"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Patch(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusUnsupportedMediaType, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenDocumentIsMalformed() {
+	setupCase := SetupControllerCase(http.MethodPatch, "/api/contacts/10", strings.NewReader(`{"op": "add"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, dto.MIMEApplicationJSONPatchJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Patch(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestPatch_WhenFail() {
+	body := `{"phone_number": null}`
+	patch, _ := dto.NewMergePatch([]byte(body))
+
+	suite.app.Mock.On("Patch", anonymous, uint(10), patch, uint(0)).
+		Return(models.Contact{}, dto.Contact{Name: "test"}.Validate())
+
+	setupCase := SetupControllerCase(http.MethodPatch, "/api/contacts/10", strings.NewReader(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, dto.MIMEApplicationMergePatchJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Patch(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestGetByID_WhenETag() {
+	suite.app.Mock.On("GetByID", uint(10)).
+		Return(models.Contact{ID: 10, Version: 3}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.GetByID(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal(`"3"`, setupCase.Res.Header().Get(HeaderETag))
+}
+
+func (suite *contactsTestSuite) TestGetByID_WhenNotModified() {
+	suite.app.Mock.On("GetByID", uint(10)).
+		Return(models.Contact{ID: 10, Version: 3}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10", nil)
+	setupCase.Req.Header.Set(HeaderIfNoneMatch, `"2", W/"3"`)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.GetByID(setupCase.context))
+	suite.Equal(http.StatusNotModified, setupCase.Res.Code)
+	suite.Empty(setupCase.Res.Body.String())
+}
+
+func (suite *contactsTestSuite) TestUpdate_WhenIfMatch() {
+	contact := dto.Contact{Name: "test3", PhoneNumber: "+570000002"}
+	body, _ := json.Marshal(contact)
+
+	suite.app.Mock.On("Update", anonymous, uint(10), contact, uint(3)).
+		Return(models.Contact{ID: 10, Version: 4}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPut, "/api/contacts/10", bytes.NewBuffer(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.Req.Header.Set(HeaderIfMatch, `"3"`)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Update(setupCase.context))
+	suite.Equal(`"4"`, setupCase.Res.Header().Get(HeaderETag))
+}
+
+func (suite *contactsTestSuite) TestUpdate_WhenVersionDoesNotMatch() {
+	contact := dto.Contact{Name: "test3", PhoneNumber: "+570000002"}
+	body, _ := json.Marshal(contact)
+
+	suite.app.Mock.On("Update", anonymous, uint(10), contact, uint(3)).
+		Return(models.Contact{}, apperrors.PreconditionFailed("modified", nil))
+
+	setupCase := SetupControllerCase(http.MethodPut, "/api/contacts/10", bytes.NewBuffer(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.Req.Header.Set(HeaderIfMatch, `"3"`)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Update(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusPreconditionFailed, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestDelete_WhenIfMatchIsWeak() {
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
+	setupCase.Req.Header.Set(HeaderIfMatch, `W/"3"`)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Delete(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusPreconditionFailed, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Delete")
+}
+
+func (suite *contactsTestSuite) TestDelete_WhenIfMatchIsAny() {
+	suite.app.Mock.On("Delete", anonymous, uint(10), uint(0)).Return(nil)
+
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
+	setupCase.Req.Header.Set(HeaderIfMatch, "*")
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Delete(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestDelete_WhenIfMatchListsTheCurrentVersion() {
+	suite.app.Mock.On("GetByID", uint(10)).Return(models.Contact{ID: 10, Version: 4}, nil)
+	suite.app.Mock.On("Delete", anonymous, uint(10), uint(4)).Return(nil)
+
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
+	setupCase.Req.Header.Set(HeaderIfMatch, `"3", W/"5", "4"`)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Delete(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestDelete_WhenIfMatchDoesNotListTheCurrentVersion() {
+	suite.app.Mock.On("GetByID", uint(10)).Return(models.Contact{ID: 10, Version: 5}, nil)
+
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
+	setupCase.Req.Header.Set(HeaderIfMatch, `"3","4"`)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Delete(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusPreconditionFailed, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Delete")
 }
 
 type ControllerCase struct {
@@ -378,3 +769,184 @@ func SetupControllerCase(method string, url string, body io.Reader) ControllerCa
 
 	return ControllerCase{req, res, ctxEngine}
 }
+
+func (suite *contactsTestSuite) TestGetTrash_WhenSuccess() {
+	suite.app.Mock.On("GetTrash", dto.Paginate{Page: 2, Limit: 5}).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/trash?page=2&limit=5", nil)
+
+	suite.NoError(suite.underTest.GetTrash(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestGetTrash_WhenLimitIsOutOfRange() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/trash?limit=1000", nil)
+
+	err := suite.underTest.GetTrash(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestRestore_WhenSuccess() {
+	suite.app.Mock.On("Restore", anonymous, uint(10)).
+		Return(models.Contact{ID: 10, Version: 4}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/10/restore", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Restore(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal(`"4"`, setupCase.Res.Header().Get(HeaderETag))
+}
+
+func (suite *contactsTestSuite) TestRestore_WhenPhoneNumberIsTaken() {
+	suite.app.Mock.On("Restore", anonymous, uint(10)).
+		Return(models.Contact{}, apperrors.Conflict("the number belongs to another contact", nil))
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/10/restore", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Restore(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusConflict, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestPurge_WhenSuccess() {
+	suite.app.Mock.On("Purge", anonymous, uint(10)).
+		Return(nil)
+
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/trash/10", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Purge(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestPurge_WhenContactIsNotInTrash() {
+	suite.app.Mock.On("Purge", anonymous, uint(10)).
+		Return(apperrors.NotFound("the contact: 10 is not in the trash", nil))
+
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/trash/10", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.Purge(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestDelete_WhenActorIsSent() {
+	suite.app.Mock.On("Delete", models.Origin{Actor: "ana", RequestID: "request-1"}, uint(10), uint(0)).
+		Return(nil)
+
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/contacts/10", nil)
+	setupCase.Req.Header.Set(HeaderActor, "ana")
+	setupCase.Req.Header.Set(echo.HeaderXRequestID, "request-1")
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.Delete(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestGetByID_WhenAsOf() {
+	suite.app.Mock.On("GetAsOf", uint(10), time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)).
+		Return(models.Contact{ID: 10, Version: 2}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10?as_of=2024-03-01T12:00:00Z", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.GetByID(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Empty(setupCase.Res.Header().Get(HeaderETag))
+	suite.app.AssertNotCalled(suite.T(), "GetByID", uint(10))
+}
+
+func (suite *contactsTestSuite) TestGetByID_WhenAsOfIsMalformed() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10?as_of=yesterday", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	err := suite.underTest.GetByID(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestGetRevisions_WhenSuccess() {
+	suite.app.Mock.On("GetRevisions", uint(10), dto.Paginate{Page: 1, Limit: 10}).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10/revisions", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10")
+
+	suite.NoError(suite.underTest.GetRevisions(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestRevert_WhenSuccess() {
+	suite.app.Mock.On("Revert", anonymous, uint(10), uint(2), uint(4)).
+		Return(models.Contact{ID: 10, Version: 5}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/10/revisions/2/revert", nil)
+	setupCase.Req.Header.Set(HeaderIfMatch, `"4"`)
+	setupCase.context.SetParamNames("id", "rev")
+	setupCase.context.SetParamValues("10", "2")
+
+	suite.NoError(suite.underTest.Revert(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal(`"5"`, setupCase.Res.Header().Get(HeaderETag))
+}
+
+func (suite *contactsTestSuite) TestRevert_WhenRevisionIsMalformed() {
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/10/revisions/abc/revert", nil)
+	setupCase.context.SetParamNames("id", "rev")
+	setupCase.context.SetParamValues("10", "abc")
+
+	err := suite.underTest.Revert(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestRevert_WhenRevisionIsNoLongerValid() {
+	suite.app.Mock.On("Revert", anonymous, uint(10), uint(2), uint(0)).
+		Return(models.Contact{}, apperrors.InvalidFields("invalid fields", nil, nil))
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/10/revisions/2/revert", nil)
+	setupCase.context.SetParamNames("id", "rev")
+	setupCase.context.SetParamValues("10", "2")
+
+	err := suite.underTest.Revert(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestSearch_WhenSuccess() {
+	suite.app.Mock.On("Search", "juan pérez", dto.Paginate{Page: 2, Limit: 5}).
+		Return(&models.Paginator{}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/search?q=+juan+p%C3%A9rez&page=2&limit=5", nil)
+
+	suite.NoError(suite.underTest.Search(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestSearch_WhenQueryIsMissing() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/search", nil)
+
+	err := suite.underTest.Search(setupCase.context)
+
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.Equal("q", apperrors.FieldsOf(err)[0].Field)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/duplicates"
+	"github.com/labstack/echo/v4"
+)
+
+const minScoreParam = "min_score"
+
+// @Tags         Contacts
+// @Summary      Find duplicate contacts
+// @Description  List the clusters of contacts that look like the same person. Pairs of contacts are scored from the
+// @Description  similarity of their normalized names and the phone numbers and emails they share
+// @Produce      json
+// @Param        min_score  query     number  false  "score from 0 to 1 a pair needs to be suspected, 0.6 by default"
+// @Success      200        {object}  dto.Message{data=[]models.DuplicateCluster}
+// @Failure      400        {object}  dto.Problem
+// @Failure      500        {object}  dto.Problem
+// @Router       /contacts/duplicates [get]
+func (handler *contacts) Duplicates(ctx echo.Context) error {
+	minScore, err := dto.ParseScore(minScoreParam, ctx.QueryParam(minScoreParam), duplicates.DefaultMinScore)
+	if err != nil {
+		return err
+	}
+
+	clusters, err := handler.app.Duplicates(minScore)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "duplicates successfully loaded",
+		Data:    clusters,
+	})
+}
+
+// @Tags         Contacts
+// @Summary      Merge contacts
+// @Description  Merge contacts into a survivor, which keeps its id. Fields takes, for name, phone_number, company, notes
+// @Description  and photo, the contact whose value is kept; phones, emails, addresses, tags and groups of every contact
+// @Description  are joined.
+// @Description  The merged contacts are moved to the trash and the merge is audited for every contact
+// @Accept       json
+// @Produce      json
+// @Param        request          body      dto.Merge  true   "Request Body"
+// @Param        If-Match         header    string     false  "ETag of the version of the survivor being replaced"
+// @Param        X-Actor          header    string     false  "author of the change, anonymous by default"
+// @Param        Idempotency-Key  header    string     false  "key making retries of the request replay its first response"
+// @Success      200              {object}  dto.Message{data=models.Contact}
+// @Header       200              {string}  ETag  "version of the survivor"
+// @Failure      400              {object}  dto.Problem
+// @Failure      404              {object}  dto.Problem
+// @Failure      409              {object}  dto.Problem
+// @Failure      412              {object}  dto.Problem
+// @Failure      422              {object}  dto.Problem
+// @Failure      500              {object}  dto.Problem
+// @Router       /contacts/merge [post]
+func (handler *contacts) Merge(ctx echo.Context) error {
+	var merge dto.Merge
+
+	if err := ctx.Bind(&merge); err != nil {
+		return bindError(err)
+	}
+
+	if err := merge.Validate(); err != nil {
+		return err
+	}
+
+	version, err := ifMatch(ctx, versionOf(handler.app.GetByID, merge.SurvivorID))
+	if err != nil {
+		return err
+	}
+
+	contact, err := handler.app.Merge(origin(ctx), merge, version)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(contact.Version))
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "contacts successfully merged",
+		Data:    contact,
+	})
+}
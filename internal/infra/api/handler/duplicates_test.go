@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/labstack/echo/v4"
+)
+
+func (suite *contactsTestSuite) TestDuplicates_WhenSuccess() {
+	suite.app.Mock.On("Duplicates", 0.8).Return([]models.DuplicateCluster{{Score: 0.9}}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/duplicates?min_score=0.8", nil)
+
+	suite.NoError(suite.underTest.Duplicates(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestDuplicates_WhenDefaultScore() {
+	suite.app.Mock.On("Duplicates", 0.6).Return(nil, errors.New("connection refused"))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/duplicates", nil)
+
+	suite.Error(suite.underTest.Duplicates(setupCase.context))
+}
+
+func (suite *contactsTestSuite) TestDuplicates_WhenInvalidScore() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/duplicates?min_score=2", nil)
+
+	err := suite.underTest.Duplicates(setupCase.context)
+
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.Equal("min_score", apperrors.FieldsOf(err)[0].Field)
+}
+
+func (suite *contactsTestSuite) TestMerge_WhenSuccess() {
+	merge := dto.Merge{SurvivorID: 1, ContactIDs: []uint{2, 3}, Fields: map[string]uint{"name": 2}}
+
+	suite.app.Mock.On("Merge", anonymous, merge, uint(4)).Return(models.Contact{ID: 1, Version: 5}, nil)
+
+	body := `{"survivor_id": 1, "contact_ids": [2, 3], "fields": {"name": 2}}`
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/merge", strings.NewReader(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.Req.Header.Set(HeaderIfMatch, `"4"`)
+
+	suite.NoError(suite.underTest.Merge(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal(`"5"`, setupCase.Res.Header().Get(HeaderETag))
+}
+
+func (suite *contactsTestSuite) TestMerge_WhenInvalid() {
+	body := `{"survivor_id": 1, "contact_ids": [1]}`
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/merge", strings.NewReader(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Merge(setupCase.context)
+
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.Equal("contact_ids", apperrors.FieldsOf(err)[0].Field)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	MIMEApplicationProblemJSON = "application/problem+json"
+
+	problemTypeBase       = "/problems/"
+	internalErrorMessage  = "an unexpected error occurred"
+	invalidRequestMessage = "invalid request body"
+)
+
+// StatusCode returns the http status used to report err to clients.
+func StatusCode(err error) int {
+	var appErr *apperrors.Error
+	var httpError *echo.HTTPError
+	if !errors.As(err, &appErr) && errors.As(err, &httpError) {
+		return httpError.Code
+	}
+
+	switch apperrors.KindOf(err) {
+	case apperrors.KindNotFound:
+		return http.StatusNotFound
+	case apperrors.KindConflict:
+		return http.StatusConflict
+	case apperrors.KindValidation:
+		return http.StatusBadRequest
+	case apperrors.KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	case apperrors.KindFailedDependency:
+		return http.StatusFailedDependency
+	case apperrors.KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// NewProblem builds the RFC 7807 document that describes err.
+func NewProblem(err error, instance string) dto.Problem {
+	code := StatusCode(err)
+
+	problem := dto.Problem{
+		Type:     problemType(err, code),
+		Title:    http.StatusText(code),
+		Status:   code,
+		Detail:   problemDetail(err, code),
+		Instance: instance,
+	}
+
+	for _, field := range apperrors.FieldsOf(err) {
+		problem.Errors = append(problem.Errors, dto.FieldError{
+			Field:   field.Field,
+			Rule:    field.Rule,
+			Message: field.Message,
+		})
+	}
+
+	return problem
+}
+
+// ErrorHandler is the echo.HTTPErrorHandler of the server, every failed request
+// is answered with an application/problem+json document. Errors raised once the
+// response was committed, like in the middle of an export, can only be logged.
+func ErrorHandler(err error, ctx echo.Context) {
+	if ctx.Response().Committed {
+		ctx.Logger().Error(err)
+		return
+	}
+
+	problem := NewProblem(err, ctx.Request().URL.Path)
+	if problem.Status >= http.StatusInternalServerError {
+		ctx.Logger().Error(err)
+	}
+
+	if ctx.Request().Method == http.MethodHead {
+		err = ctx.NoContent(problem.Status)
+	} else {
+		err = problemJSON(ctx, problem)
+	}
+
+	if err != nil {
+		ctx.Logger().Error(err)
+	}
+}
+
+func problemJSON(ctx echo.Context, problem dto.Problem) error {
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Blob(problem.Status, MIMEApplicationProblemJSON, body)
+}
+
+// problemType identifies the kind of problem, errors that carry no more meaning
+// than their status code use "about:blank" as RFC 7807 recommends.
+func problemType(err error, code int) string {
+	var appErr *apperrors.Error
+	if code == http.StatusInternalServerError || !errors.As(err, &appErr) {
+		return "about:blank"
+	}
+
+	return problemTypeBase + strings.ReplaceAll(appErr.Kind.String(), " ", "-")
+}
+
+func problemDetail(err error, code int) string {
+	if code == http.StatusInternalServerError {
+		return internalErrorMessage
+	}
+
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		return appErr.Error()
+	}
+
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return fmt.Sprint(httpError.Message)
+	}
+
+	return err.Error()
+}
+
+// bindError reports a request that could not be decoded as a validation error.
+func bindError(err error) error {
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return apperrors.Validation(fmt.Sprint(httpError.Message), err)
+	}
+
+	return apperrors.Validation(invalidRequestMessage, err)
+}
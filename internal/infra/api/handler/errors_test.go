@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, StatusCode(apperrors.NotFound("", nil)))
+	assert.Equal(t, http.StatusConflict, StatusCode(apperrors.Conflict("", nil)))
+	assert.Equal(t, http.StatusBadRequest, StatusCode(apperrors.Validation("", nil)))
+	assert.Equal(t, http.StatusPreconditionFailed, StatusCode(apperrors.PreconditionFailed("", nil)))
+	assert.Equal(t, http.StatusFailedDependency, StatusCode(apperrors.FailedDependency("", nil)))
+	assert.Equal(t, http.StatusUnprocessableEntity, StatusCode(apperrors.Unprocessable("", nil)))
+	assert.Equal(t, http.StatusInternalServerError, StatusCode(errors.New("some error")))
+	assert.Equal(t, http.StatusNotFound, StatusCode(echo.ErrNotFound))
+	assert.Equal(t, http.StatusBadRequest, StatusCode(bindError(echo.ErrUnsupportedMediaType)))
+}
+
+func TestErrorHandler_WhenAppError(t *testing.T) {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10", nil)
+
+	ErrorHandler(apperrors.NotFound("the contact: 10 does not exist", nil), setupCase.context)
+
+	assert.Equal(t, http.StatusNotFound, setupCase.Res.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, setupCase.Res.Header().Get(echo.HeaderContentType))
+	assert.JSONEq(t, `{
+		"type": "/problems/not-found",
+		"title": "Not Found",
+		"status": 404,
+		"detail": "the contact: 10 does not exist",
+		"instance": "/api/contacts/10"
+	}`, setupCase.Res.Body.String())
+}
+
+func TestErrorHandler_WhenValidationError(t *testing.T) {
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/", nil)
+
+	ErrorHandler(dto.Contact{Name: "name"}.Validate(), setupCase.context)
+
+	assert.Equal(t, http.StatusBadRequest, setupCase.Res.Code)
+	assert.JSONEq(t, `{
+		"type": "/problems/validation",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "the request has invalid fields",
+		"instance": "/api/contacts/",
+		"errors": [
+			{"field": "phone_number", "rule": "required", "message": "phone_number is required"}
+		]
+	}`, setupCase.Res.Body.String())
+}
+
+func TestErrorHandler_WhenInternalError(t *testing.T) {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/", nil)
+
+	ErrorHandler(errors.New("connection refused"), setupCase.context)
+
+	assert.Equal(t, http.StatusInternalServerError, setupCase.Res.Code)
+	assert.NotContains(t, setupCase.Res.Body.String(), "connection refused")
+}
+
+func TestErrorHandler_WhenHTTPError(t *testing.T) {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/unknown", nil)
+
+	ErrorHandler(echo.ErrMethodNotAllowed, setupCase.context)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, setupCase.Res.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, setupCase.Res.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, setupCase.Res.Body.String(), `"type":"about:blank"`)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/labstack/echo/v4"
+)
+
+const MIMEApplicationNDJSON = "application/x-ndjson"
+
+// @Tags         Contacts
+// @Summary      Export contacts
+// @Description  Stream every contact matching the search, filters and sort of the listing as a CSV file, whose columns
+// @Description  can be imported back, as newline delimited JSON or as vCards. An error in the middle of the stream cuts it short.
+// @Produce      text/csv,application/x-ndjson,text/vcard
+// @Param        format               query     string  true   "format of the file"  Enums(csv, ndjson, vcf)
+// @Param        version              query     string  false  "version of the vCards, 3.0 by default"  Enums(3.0, 4.0)
+// @Param        search               query     string  false  "text to search in name, nickname and phone number"
+// @Param        name                 query     string  false  "exact display name"
+// @Param        name_prefix          query     string  false  "display name prefix"
+// @Param        given_name           query     string  false  "exact given name"
+// @Param        given_name_prefix    query     string  false  "given name prefix"
+// @Param        family_name          query     string  false  "exact family name"
+// @Param        family_name_prefix   query     string  false  "family name prefix"
+// @Param        nickname             query     string  false  "exact nickname"
+// @Param        phone_number         query     string  false  "exact phone number"
+// @Param        phone_number_prefix  query     string  false  "phone number prefix"
+// @Param        tag                  query     []string  false  "tag names, repeated or comma separated"  collectionFormat(multi)
+// @Param        tag_match            query     string  false  "all, the default, keeps contacts having every tag, any the ones having one of them"  Enums(all, any)
+// @Param        sort                 query     string  false  "comma separated fields, prefix with - for descending order"  example(name,-id)
+// @Success      200                  {file}    file
+// @Header       200                  {string}  Content-Disposition  "attachment; filename=\"contacts.csv\""
+// @Failure      400                  {object}  dto.Problem
+// @Failure      500                  {object}  dto.Problem
+// @Router       /contacts/export [get]
+func (handler *contacts) Export(ctx echo.Context) error {
+	format, err := dto.ParseExportFormat(ctx.QueryParam("format"))
+	if err != nil {
+		return err
+	}
+
+	version, err := dto.ParseVCardVersion(ctx.QueryParam("version"))
+	if err != nil {
+		return err
+	}
+
+	var paginate dto.Paginate
+
+	if err := listParams(ctx, &paginate); err != nil {
+		return err
+	}
+
+	if err := paginate.Validate(); err != nil {
+		return err
+	}
+
+	export := newExport(ctx.Response(), format, version)
+
+	err = handler.app.Export(paginate, func(contacts []models.Contact) error {
+		for _, contact := range contacts {
+			if err := export.Encode(contact); err != nil {
+				return err
+			}
+		}
+
+		return export.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return export.Flush()
+}
+
+// export writes the contacts of an export to the response in its format. The
+// headers are only sent with the first write, so that errors raised before any
+// contact is read are still answered with a problem.
+type export struct {
+	response *echo.Response
+	format   string
+	version  string
+	csv      *csv.Writer
+	json     *json.Encoder
+}
+
+func newExport(response *echo.Response, format string, version string) *export {
+	return &export{
+		response: response,
+		format:   format,
+		version:  version,
+		csv:      csv.NewWriter(response),
+		json:     json.NewEncoder(response),
+	}
+}
+
+// Encode writes contact, after the headers when it is the first one.
+func (export *export) Encode(contact models.Contact) error {
+	export.start()
+
+	switch export.format {
+	case dto.ExportNDJSON:
+		return export.json.Encode(contact)
+	case dto.ExportVCard:
+		return vcard.Encode(export.response, contact, export.version)
+	}
+
+	return export.csv.Write(dto.ExportCSVRecord(contact))
+}
+
+// Flush sends what was written so far to the client.
+func (export *export) Flush() error {
+	export.start()
+	export.csv.Flush()
+
+	if err := export.csv.Error(); err != nil {
+		return err
+	}
+
+	export.response.Flush()
+
+	return nil
+}
+
+func (export *export) start() {
+	if export.response.Committed {
+		return
+	}
+
+	contentType := MIMETextCSV + "; charset=utf-8"
+	switch export.format {
+	case dto.ExportNDJSON:
+		contentType = MIMEApplicationNDJSON
+	case dto.ExportVCard:
+		contentType = MIMETextVCard
+	}
+
+	header := export.response.Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="contacts.%s"`, export.format))
+	export.response.WriteHeader(http.StatusOK)
+
+	if export.format == dto.ExportCSV {
+		_ = export.csv.Write(dto.ExportCSVHeader())
+	}
+}
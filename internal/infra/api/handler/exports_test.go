@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+)
+
+// exportBatches makes the mocked Export hand batches to the writer of the handler.
+func exportBatches(batches ...[]models.Contact) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		write := args.Get(1).(func([]models.Contact) error)
+		for _, batch := range batches {
+			_ = write(batch)
+		}
+	}
+}
+
+func (suite *contactsTestSuite) TestExport_WhenCSV() {
+	paginate := dto.Paginate{
+		Search:  "juan",
+		Filters: []models.FieldFilter{{Field: "name", Value: "Ju", Prefix: true}},
+		Tags:    []string{"family"},
+		Sort:    []models.SortField{{Field: "name", Desc: true}},
+	}
+
+	suite.app.Mock.On("Export", paginate, mock.Anything).Return(nil).Run(exportBatches(
+		[]models.Contact{{ID: 1, Name: "Juan", PhoneNumber: "+573000000000", Version: 1}},
+		[]models.Contact{{ID: 2, Name: "Juana", PhoneNumber: "+573000000001", Version: 3}},
+	))
+
+	setupCase := SetupControllerCase(http.MethodGet,
+		"/api/contacts/export?format=csv&search=juan&name_prefix=Ju&tag=family&sort=-name", nil)
+
+	suite.NoError(suite.underTest.Export(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal("text/csv; charset=utf-8", setupCase.Res.Header().Get(echo.HeaderContentType))
+	suite.Equal(`attachment; filename="contacts.csv"`, setupCase.Res.Header().Get(echo.HeaderContentDisposition))
+
+	lines := strings.Split(strings.TrimSpace(setupCase.Res.Body.String()), "\n")
+	suite.Len(lines, 3)
+	suite.True(strings.HasPrefix(lines[0], "id,name,"))
+	suite.True(strings.HasPrefix(lines[1], "1,Juan,"))
+	suite.True(strings.HasPrefix(lines[2], "2,Juana,"))
+}
+
+func (suite *contactsTestSuite) TestExport_WhenNDJSON() {
+	suite.app.Mock.On("Export", dto.Paginate{}, mock.Anything).Return(nil).Run(exportBatches(
+		[]models.Contact{{ID: 1, Name: "Juan"}, {ID: 2, Name: "Juana"}},
+	))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/export?format=ndjson", nil)
+
+	suite.NoError(suite.underTest.Export(setupCase.context))
+	suite.Equal(MIMEApplicationNDJSON, setupCase.Res.Header().Get(echo.HeaderContentType))
+	suite.Equal(`attachment; filename="contacts.ndjson"`, setupCase.Res.Header().Get(echo.HeaderContentDisposition))
+
+	lines := strings.Split(strings.TrimSpace(setupCase.Res.Body.String()), "\n")
+	suite.Len(lines, 2)
+	suite.Contains(lines[1], `"name":"Juana"`)
+}
+
+func (suite *contactsTestSuite) TestExport_WhenVCard() {
+	suite.app.Mock.On("Export", dto.Paginate{}, mock.Anything).Return(nil).Run(exportBatches(
+		[]models.Contact{{ID: 1, Name: "Juan", PhoneNumber: "+573000000000"}},
+		[]models.Contact{{ID: 2, Name: "Juana", PhoneNumber: "+573000000001"}},
+	))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/export?format=vcf&version=4.0", nil)
+
+	suite.NoError(suite.underTest.Export(setupCase.context))
+	suite.Equal(MIMETextVCard, setupCase.Res.Header().Get(echo.HeaderContentType))
+	suite.Equal(`attachment; filename="contacts.vcf"`, setupCase.Res.Header().Get(echo.HeaderContentDisposition))
+
+	cards, err := vcard.Decode(setupCase.Res.Body)
+
+	suite.NoError(err)
+	suite.Len(cards, 2)
+	suite.Equal("Juana", cards[1].Contact.Name)
+}
+
+func (suite *contactsTestSuite) TestExport_WhenEmpty() {
+	suite.app.Mock.On("Export", dto.Paginate{}, mock.Anything).Return(nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/export?format=csv", nil)
+
+	suite.NoError(suite.underTest.Export(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal(strings.Join(dto.ExportCSVHeader(), ",")+"\n", setupCase.Res.Body.String())
+}
+
+func (suite *contactsTestSuite) TestExport_WhenFormatIsInvalid() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/export?format=xml", nil)
+
+	err := suite.underTest.Export(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Export")
+}
+
+func (suite *contactsTestSuite) TestExport_WhenFailBeforeWriting() {
+	suite.app.Mock.On("Export", dto.Paginate{}, mock.Anything).Return(errors.New("some error"))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/export?format=csv", nil)
+
+	err := suite.underTest.Export(setupCase.context)
+
+	suite.Error(err)
+	suite.False(setupCase.context.Response().Committed)
+	suite.Empty(setupCase.Res.Header().Get(echo.HeaderContentDisposition))
+}
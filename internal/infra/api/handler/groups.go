@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AjxGnx/contacts-go/internal/app"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/labstack/echo/v4"
+)
+
+type Groups interface {
+	Create(ctx echo.Context) error
+	GetByID(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	AddMembers(ctx echo.Context) error
+	RemoveMembers(ctx echo.Context) error
+	Members(ctx echo.Context) error
+}
+
+type groups struct {
+	app app.Groups
+}
+
+func NewGroups(app app.Groups) Groups {
+	return &groups{
+		app,
+	}
+}
+
+// @Tags         Groups
+// @Summary      Create a group
+// @Description  Create an empty group, group names are unique
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.Group  true  "Request Body"
+// @Success      200      {object}  dto.Message{data=models.Group}
+// @Failure      400      {object}  dto.Problem
+// @Failure      409      {object}  dto.Problem
+// @Failure      500      {object}  dto.Problem
+// @Router       /groups/ [post]
+func (handler *groups) Create(ctx echo.Context) error {
+	var group dto.Group
+
+	if err := ctx.Bind(&group); err != nil {
+		return bindError(err)
+	}
+
+	if err := group.Validate(); err != nil {
+		return err
+	}
+
+	result, err := handler.app.Create(group)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "group created successfully",
+		Data:    result,
+	})
+}
+
+// @Tags         Groups
+// @Summary      Get Group by id
+// @Description  Get the Group with its direct members, contacts and nested groups
+// @Produce      json
+// @Param        id   path      int  true  "value of record to find"
+// @Success      200  {object}  dto.Message{data=models.Group}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /groups/{id} [get]
+func (handler *groups) GetByID(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	group, err := handler.app.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "group successfully loaded",
+		Data:    group,
+	})
+}
+
+// @Tags         Groups
+// @Summary      Update Group by id
+// @Description  Rename the Group or change its description, members are kept
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.Group  true  "Request Body"
+// @Param        id       path      int        true  "value of record to update"
+// @Success      200  {object}  dto.Message{data=models.Group}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      409  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /groups/{id} [put]
+func (handler *groups) Update(ctx echo.Context) error {
+	var group dto.Group
+
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Bind(&group); err != nil {
+		return bindError(err)
+	}
+
+	if err := group.Validate(); err != nil {
+		return err
+	}
+
+	result, err := handler.app.Update(id, group)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "group updated successfully",
+		Data:    result,
+	})
+}
+
+// @Tags         Groups
+// @Summary      Delete Group by id
+// @Description  Delete the Group, the groups it was nested in lose it as member
+// @Produce      json
+// @Param        id   path      int  true  "value of record to delete"
+// @Success      200  {object}  dto.Message{}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /groups/{id} [delete]
+func (handler *groups) Delete(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.app.Delete(id); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "group successfully deleted",
+	})
+}
+
+// @Tags         Groups
+// @Summary      Get groups
+// @Description  Get every group sorted by name, without their members
+// @Produce      json
+// @Success      200  {object}  dto.Message{data=[]models.Group}
+// @Failure      500  {object}  dto.Problem
+// @Router       /groups/ [get]
+func (handler *groups) Get(ctx echo.Context) error {
+	result, err := handler.app.Get()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "groups successfully loaded",
+		Data:    result,
+	})
+}
+
+// @Tags         Groups
+// @Summary      Add members to a Group
+// @Description  Add contacts and nested groups to the Group. Nesting a group that already contains this one,
+// @Description  at any depth, is rejected with a 409 as it would create a cycle.
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.GroupMembers  true  "Request Body"
+// @Param        id       path      int               true  "value of record to update"
+// @Success      200  {object}  dto.Message{}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      409  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /groups/{id}/members [post]
+func (handler *groups) AddMembers(ctx echo.Context) error {
+	id, members, err := bindMembers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.app.AddMembers(id, members); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "members successfully added",
+	})
+}
+
+// @Tags         Groups
+// @Summary      Remove members from a Group
+// @Description  Remove contacts and nested groups from the Group
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.GroupMembers  true  "Request Body"
+// @Param        id       path      int               true  "value of record to update"
+// @Success      200  {object}  dto.Message{}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /groups/{id}/members [delete]
+func (handler *groups) RemoveMembers(ctx echo.Context) error {
+	id, members, err := bindMembers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.app.RemoveMembers(id, members); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "members successfully removed",
+	})
+}
+
+// @Tags         Groups
+// @Summary      Get every contact of a Group
+// @Description  Resolve the Group to the contacts it holds directly or through its nested groups, at any depth.
+// @Description  Every contact is listed once, sorted by name.
+// @Produce      json
+// @Param        id   path      int  true  "value of record to find"
+// @Success      200  {object}  dto.Message{data=[]models.Contact}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /groups/{id}/members [get]
+func (handler *groups) Members(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := handler.app.Members(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "members successfully loaded",
+		Data:    contacts,
+	})
+}
+
+func bindMembers(ctx echo.Context) (uint, dto.GroupMembers, error) {
+	var members dto.GroupMembers
+
+	id, err := pathID(ctx)
+	if err != nil {
+		return 0, members, err
+	}
+
+	if err := ctx.Bind(&members); err != nil {
+		return 0, members, bindError(err)
+	}
+
+	return id, members, members.Validate()
+}
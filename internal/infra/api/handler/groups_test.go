@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/app"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type groupsTestSuite struct {
+	suite.Suite
+	app       *mocks.Groups
+	underTest Groups
+}
+
+func TestGroupsSuite(t *testing.T) {
+	suite.Run(t, new(groupsTestSuite))
+}
+
+func (suite *groupsTestSuite) SetupTest() {
+	suite.app = &mocks.Groups{}
+	suite.underTest = NewGroups(suite.app)
+}
+
+func (suite *groupsTestSuite) TestCreate_WhenSuccess() {
+	suite.app.Mock.On("Create", dto.Group{Name: "clients"}).Return(models.Group{ID: 1, Name: "clients"}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/groups/", strings.NewReader(`{"name": "clients"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Create(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *groupsTestSuite) TestCreate_WhenValidateFail() {
+	setupCase := SetupControllerCase(http.MethodPost, "/api/groups/", strings.NewReader(`{"description": "none"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Create(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Create")
+}
+
+func (suite *groupsTestSuite) TestGetByID_WhenGroupNotFound() {
+	suite.app.Mock.On("GetByID", uint(3)).
+		Return(models.Group{}, apperrors.NotFound("the group: 3 does not exist", nil))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/groups/3", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("3")
+
+	err := suite.underTest.GetByID(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, StatusCode(err))
+}
+
+func (suite *groupsTestSuite) TestAddMembers_WhenSuccess() {
+	members := dto.GroupMembers{ContactIDs: []uint{3}, GroupIDs: []uint{2}}
+	suite.app.Mock.On("AddMembers", uint(1), members).Return(nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/groups/1/members",
+		strings.NewReader(`{"contact_ids": [3], "group_ids": [2]}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("1")
+
+	suite.NoError(suite.underTest.AddMembers(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *groupsTestSuite) TestAddMembers_WhenCycle() {
+	members := dto.GroupMembers{GroupIDs: []uint{2}}
+	suite.app.Mock.On("AddMembers", uint(1), members).
+		Return(apperrors.Conflict("adding the group: 2 to the group: 1 would create a cycle", nil))
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/groups/1/members", strings.NewReader(`{"group_ids": [2]}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("1")
+
+	err := suite.underTest.AddMembers(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusConflict, StatusCode(err))
+}
+
+func (suite *groupsTestSuite) TestRemoveMembers_WhenEmpty() {
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/groups/1/members", strings.NewReader(`{}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("1")
+
+	err := suite.underTest.RemoveMembers(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "RemoveMembers")
+}
+
+func (suite *groupsTestSuite) TestMembers_WhenSuccess() {
+	suite.app.Mock.On("Members", uint(1)).Return([]models.Contact{{ID: 3}}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/groups/1/members", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("1")
+
+	suite.NoError(suite.underTest.Members(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
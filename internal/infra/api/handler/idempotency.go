@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/AjxGnx/contacts-go/internal/app"
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// Idempotency is the middleware that makes the POST requests sent with an
+// Idempotency-Key header safe to retry.
+type Idempotency interface {
+	Handle(next echo.HandlerFunc) echo.HandlerFunc
+}
+
+type idempotency struct {
+	app    app.Idempotency
+	config app.IdempotencyConfig
+}
+
+func NewIdempotency(app app.Idempotency, config app.IdempotencyConfig) Idempotency {
+	return &idempotency{
+		app,
+		config,
+	}
+}
+
+// Handle processes the first POST request sent with a key and keeps its response,
+// retries of the request get that response replayed with the Idempotent-Replayed
+// header. A request is told apart from others reusing its key by its method,
+// URI, actor and body. Requests without the header go through untouched, those
+// with a body larger than MaxSize are too large.
+func (handler *idempotency) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		request := ctx.Request()
+
+		value := request.Header.Get(HeaderIdempotencyKey)
+		if request.Method != http.MethodPost || value == "" {
+			return next(ctx)
+		}
+
+		key, err := dto.ParseIdempotencyKey(value)
+		if err != nil {
+			return err
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Response(), request.Body, handler.config.MaxSize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf(
+					"the body of a request sent with an %s can not be larger than %d bytes", HeaderIdempotencyKey,
+					handler.config.MaxSize))
+			}
+
+			return bindError(err)
+		}
+
+		request.Body = io.NopCloser(bytes.NewReader(body))
+
+		stored, err := handler.app.Begin(key, fingerprint(ctx, body))
+		if err != nil {
+			return err
+		}
+
+		if stored != nil {
+			return replay(ctx, stored.Status, stored.Header, stored.Body)
+		}
+
+		return handler.record(ctx, key, next)
+	}
+}
+
+// record runs next keeping a copy of the response it writes, and completes the
+// request with it. Failed requests are answered by the ErrorHandler right away,
+// so the problem they get is kept too. A response larger than MaxSize reaches
+// the client, but its retries are told it is too large to be replayed rather
+// than processed again.
+func (handler *idempotency) record(ctx echo.Context, key string, next echo.HandlerFunc) error {
+	response := ctx.Response()
+	recorder := &responseRecorder{ResponseWriter: response.Writer, maxSize: handler.config.MaxSize}
+	response.Writer = recorder
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = handler.app.Complete(key, http.StatusInternalServerError, nil, nil)
+			panic(recovered)
+		}
+	}()
+
+	if err := next(ctx); err != nil {
+		ctx.Error(err)
+	}
+
+	if recorder.truncated && response.Status < http.StatusInternalServerError {
+		return handler.completeTooLarge(ctx, key)
+	}
+
+	header := response.Header().Clone()
+	header.Del(echo.HeaderXRequestID)
+
+	return handler.app.Complete(key, response.Status, header, recorder.body.Bytes())
+}
+
+// completeTooLarge completes the request with the problem replayed to retries of
+// a request whose response was too large to be kept.
+func (handler *idempotency) completeTooLarge(ctx echo.Context, key string) error {
+	problem := NewProblem(apperrors.Unprocessable(fmt.Sprintf(
+		"the response of the request with the %s %s was larger than %d bytes and can not be replayed",
+		HeaderIdempotencyKey, key, handler.config.MaxSize), nil), ctx.Request().URL.Path)
+
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+
+	header := map[string][]string{echo.HeaderContentType: {MIMEApplicationProblemJSON}}
+
+	return handler.app.Complete(key, problem.Status, header, body)
+}
+
+// replay answers the request with a stored response.
+func replay(ctx echo.Context, status int, header map[string][]string, body []byte) error {
+	response := ctx.Response()
+
+	for name, values := range header {
+		response.Header()[http.CanonicalHeaderKey(name)] = values
+	}
+
+	response.Header().Set(HeaderIdempotentReplayed, strconv.FormatBool(true))
+	response.WriteHeader(status)
+
+	_, err := response.Write(body)
+
+	return err
+}
+
+// fingerprint identifies the request sent with an idempotency key.
+func fingerprint(ctx echo.Context, body []byte) string {
+	hash := sha256.New()
+
+	for _, part := range []string{ctx.Request().Method, ctx.Request().URL.RequestURI(), origin(ctx).Actor} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder copies the body written to the response, up to maxSize bytes.
+// A larger body is not copied at all and the recorder is left truncated.
+type responseRecorder struct {
+	http.ResponseWriter
+	body      bytes.Buffer
+	maxSize   int64
+	truncated bool
+}
+
+func (recorder *responseRecorder) Write(data []byte) (int, error) {
+	if !recorder.truncated && int64(recorder.body.Len()+len(data)) > recorder.maxSize {
+		recorder.truncated = true
+		recorder.body = bytes.Buffer{}
+	}
+
+	if !recorder.truncated {
+		recorder.body.Write(data)
+	}
+
+	return recorder.ResponseWriter.Write(data)
+}
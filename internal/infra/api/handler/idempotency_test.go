@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AjxGnx/contacts-go/internal/app"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/app"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type idempotencyTestSuite struct {
+	suite.Suite
+	app       *mocks.Idempotency
+	underTest Idempotency
+}
+
+func TestIdempotencySuite(t *testing.T) {
+	suite.Run(t, new(idempotencyTestSuite))
+}
+
+func (suite *idempotencyTestSuite) SetupTest() {
+	suite.app = &mocks.Idempotency{}
+	suite.underTest = NewIdempotency(suite.app, app.IdempotencyConfig{TTL: time.Hour, MaxSize: 1024})
+}
+
+func (suite *idempotencyTestSuite) request(method, key, body string) ControllerCase {
+	setupCase := SetupControllerCase(method, "/api/contacts/", strings.NewReader(body))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.Echo().HTTPErrorHandler = ErrorHandler
+
+	if key != "" {
+		setupCase.Req.Header.Set(HeaderIdempotencyKey, key)
+	}
+
+	return setupCase
+}
+
+func created(ctx echo.Context) error {
+	ctx.Response().Header().Set(HeaderETag, `"1"`)
+
+	return ctx.JSON(http.StatusOK, map[string]string{"message": "contact created successfully"})
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenFirstRequest() {
+	setupCase := suite.request(http.MethodPost, "key-1", `{"name": "Juan"}`)
+
+	suite.app.Mock.On("Begin", "key-1", fingerprint(setupCase.context, []byte(`{"name": "Juan"}`))).
+		Return(nil, nil)
+	suite.app.Mock.On("Complete", "key-1", http.StatusOK, mock.MatchedBy(func(header map[string][]string) bool {
+		return http.Header(header).Get(HeaderETag) == `"1"`
+	}), []byte("{\"message\":\"contact created successfully\"}\n")).Return(nil)
+
+	suite.NoError(suite.underTest.Handle(created)(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Empty(setupCase.Res.Header().Get(HeaderIdempotentReplayed))
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenFailedRequest() {
+	setupCase := suite.request(http.MethodPost, "key-1", `{}`)
+
+	suite.app.Mock.On("Begin", "key-1", mock.Anything).Return(nil, nil)
+	suite.app.Mock.On("Complete", "key-1", http.StatusConflict, mock.Anything, mock.MatchedBy(func(body []byte) bool {
+		return strings.Contains(string(body), "already exists")
+	})).Return(nil)
+
+	err := suite.underTest.Handle(func(ctx echo.Context) error {
+		return apperrors.Conflict("your contact number +573000000000 already exists", nil)
+	})(setupCase.context)
+
+	suite.NoError(err)
+	suite.Equal(http.StatusConflict, setupCase.Res.Code)
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenRetried() {
+	setupCase := suite.request(http.MethodPost, "key-1", `{"name": "Juan"}`)
+
+	suite.app.Mock.On("Begin", "key-1", mock.Anything).Return(&models.IdempotentRequest{
+		Status: http.StatusOK,
+		Header: map[string][]string{HeaderETag: {`"1"`}},
+		Body:   []byte(`{"message":"contact created successfully"}`),
+	}, nil)
+
+	suite.NoError(suite.underTest.Handle(func(ctx echo.Context) error {
+		suite.Fail("the request must not be processed again")
+		return nil
+	})(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal("true", setupCase.Res.Header().Get(HeaderIdempotentReplayed))
+	suite.Equal(`"1"`, setupCase.Res.Header().Get(HeaderETag))
+	suite.Equal(`{"message":"contact created successfully"}`, setupCase.Res.Body.String())
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenKeyIsReused() {
+	setupCase := suite.request(http.MethodPost, "key-1", `{"name": "Ana"}`)
+
+	suite.app.Mock.On("Begin", "key-1", mock.Anything).
+		Return(nil, apperrors.Unprocessable("the Idempotency-Key key-1 was already used for another request", nil))
+
+	err := suite.underTest.Handle(created)(setupCase.context)
+
+	suite.Equal(http.StatusUnprocessableEntity, StatusCode(err))
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenKeyIsInvalid() {
+	setupCase := suite.request(http.MethodPost, strings.Repeat("k", 256), `{}`)
+
+	err := suite.underTest.Handle(created)(setupCase.context)
+
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Begin")
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenNotApplicable() {
+	for _, setupCase := range []ControllerCase{
+		suite.request(http.MethodPost, "", `{}`),
+		suite.request(http.MethodPut, "key-1", `{}`),
+	} {
+		suite.NoError(suite.underTest.Handle(created)(setupCase.context))
+		suite.Equal(http.StatusOK, setupCase.Res.Code)
+	}
+
+	suite.app.Mock.AssertNotCalled(suite.T(), "Begin")
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenCompleteFails() {
+	setupCase := suite.request(http.MethodPost, "key-1", `{}`)
+
+	suite.app.Mock.On("Begin", "key-1", mock.Anything).Return(nil, nil)
+	suite.app.Mock.On("Complete", "key-1", http.StatusOK, mock.Anything, mock.Anything).
+		Return(errors.New("connection refused"))
+
+	suite.Error(suite.underTest.Handle(created)(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenBodyIsTooLarge() {
+	setupCase := suite.request(http.MethodPost, "key-1", `{"name": "`+strings.Repeat("a", 1024)+`"}`)
+
+	err := suite.underTest.Handle(created)(setupCase.context)
+
+	suite.Equal(http.StatusRequestEntityTooLarge, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Begin")
+}
+
+func (suite *idempotencyTestSuite) TestHandle_WhenResponseIsTooLarge() {
+	setupCase := suite.request(http.MethodPost, "key-1", `{}`)
+	large := strings.Repeat("a", 1025)
+
+	suite.app.Mock.On("Begin", "key-1", mock.Anything).Return(nil, nil)
+	suite.app.Mock.On("Complete", "key-1", http.StatusUnprocessableEntity, map[string][]string{
+		echo.HeaderContentType: {MIMEApplicationProblemJSON},
+	}, mock.MatchedBy(func(body []byte) bool {
+		return strings.Contains(string(body), "larger than 1024 bytes and can not be replayed")
+	})).Return(nil)
+
+	suite.NoError(suite.underTest.Handle(func(ctx echo.Context) error {
+		return ctx.String(http.StatusOK, large)
+	})(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal(large, setupCase.Res.Body.String())
+}
+
+func (suite *idempotencyTestSuite) TestFingerprint() {
+	juan := suite.request(http.MethodPost, "key-1", "")
+	ana := suite.request(http.MethodPost, "key-1", "")
+	ana.Req.Header.Set(HeaderActor, "ana")
+
+	suite.Equal(fingerprint(juan.context, []byte("{}")), fingerprint(juan.context, []byte("{}")))
+	suite.NotEqual(fingerprint(juan.context, []byte("{}")), fingerprint(juan.context, []byte("[]")))
+	suite.NotEqual(fingerprint(juan.context, []byte("{}")), fingerprint(ana.context, []byte("{}")))
+}
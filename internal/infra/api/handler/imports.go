@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	MIMETextCSV    = "text/csv"
+	MIMETextXVCard = "text/x-vcard"
+)
+
+// @Tags         Contacts
+// @Summary      Import contacts
+// @Description  Create, or update, the contacts of a CSV file whose first row names its columns, or of a vCard file
+// @Description  holding one card per contact. Each row is validated as a new contact and the report tells what was done with it
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file             formData  file    true   "CSV or vCard (.vcf) file"
+// @Param        mapping          formData  string  false  "JSON object mapping column names to fields, columns named after a field are read without mapping. Ignored for vCard files"
+// @Param        dry_run          formData  bool    false  "report what would be done without saving anything"
+// @Param        on_duplicate     formData  string  false  "what to do with rows whose phone number belongs to a contact, skip by default"  Enums(skip, update)
+// @Param        X-Actor          header    string  false  "author of the change, anonymous by default"
+// @Param        Idempotency-Key  header    string  false  "key making retries of the request replay its first response"
+// @Success      200              {object}  dto.Message{data=dto.ImportReport}
+// @Success      207              {object}  dto.Message{data=dto.ImportReport}  "the import stopped at a row because of an unexpected error"
+// @Failure      400              {object}  dto.Problem
+// @Failure      415              {object}  dto.Problem
+// @Failure      422              {object}  dto.Problem
+// @Failure      500              {object}  dto.Problem
+// @Router       /contacts/import [post]
+func (handler *contacts) Import(ctx echo.Context) error {
+	options, err := dto.ParseImportOptions(ctx.FormValue("dry_run"), ctx.FormValue("on_duplicate"))
+	if err != nil {
+		return err
+	}
+
+	mapping, err := dto.ParseImportMapping(ctx.FormValue("mapping"))
+	if err != nil {
+		return err
+	}
+
+	rows, err := handler.readImport(ctx, mapping)
+	if err != nil {
+		return err
+	}
+
+	report, err := handler.app.Import(origin(ctx), rows, options)
+	if err != nil && report.StoppedAt == 0 {
+		return err
+	}
+
+	code, message := http.StatusOK, "contacts successfully imported"
+	if options.DryRun {
+		message = "import successfully checked, nothing was saved"
+	}
+
+	if err != nil {
+		ctx.Logger().Error(err)
+
+		code = http.StatusMultiStatus
+		message = fmt.Sprintf("import stopped at the row %d by an unexpected error, the rows before it were saved",
+			report.StoppedAt)
+	}
+
+	return ctx.JSON(code, dto.Message{
+		Message: message,
+		Data:    report,
+	})
+}
+
+// readImport reads the contacts of the file uploaded as the file field of the
+// form, its name or its Content-Type tell whether it is a CSV or a vCard file.
+func (handler *contacts) readImport(ctx echo.Context, mapping dto.ImportMapping) ([]dto.ImportRow, error) {
+	header, err := ctx.FormFile("file")
+	if errors.Is(err, http.ErrMissingFile) || errors.Is(err, http.ErrNotMultipart) {
+		return nil, apperrors.InvalidFields("the file to import is missing", err, []apperrors.FieldError{{
+			Field:   "file",
+			Rule:    "required",
+			Message: "file is required",
+		}})
+	}
+
+	if err != nil {
+		return nil, apperrors.Validation(invalidRequestMessage, err)
+	}
+
+	csv, vcf := isFile(header, MIMETextCSV, ".csv"), isFile(header, vcard.MIMEType, vcard.Extension, MIMETextXVCard)
+	if !csv && !vcf {
+		return nil, echo.ErrUnsupportedMediaType
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, apperrors.Internal(err)
+	}
+
+	defer file.Close()
+
+	if vcf {
+		return dto.ParseVCards(file, handler.imports.MaxRows)
+	}
+
+	return dto.ParseCSV(file, mapping, handler.imports.MaxRows)
+}
+
+// isFile reports whether the uploaded file has the extension or one of the media
+// types.
+func isFile(header *multipart.FileHeader, mediaType string, extension string, aliases ...string) bool {
+	sent, _, _ := mime.ParseMediaType(header.Header.Get(echo.HeaderContentType))
+	if strings.EqualFold(filepath.Ext(header.Filename), extension) {
+		return true
+	}
+
+	for _, current := range append(aliases, mediaType) {
+		if sent == current {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/labstack/echo/v4"
+)
+
+// importForm builds a multipart form with fields and, when filename is not
+// empty, the file to import.
+func importForm(filename, contentType, content string, fields map[string]string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for name, value := range fields {
+		_ = writer.WriteField(name, value)
+	}
+
+	if filename != "" {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+		header.Set(echo.HeaderContentType, contentType)
+
+		part, _ := writer.CreatePart(header)
+		_, _ = part.Write([]byte(content))
+	}
+
+	_ = writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func (suite *contactsTestSuite) TestImport_WhenCSV() {
+	rows := []dto.ImportRow{{Row: 2, Contact: dto.Contact{Name: "Juan", PhoneNumber: "3000000000"}}}
+	report := dto.ImportReport{Created: 1, Rows: []dto.ImportResult{{Row: 2, Status: dto.ImportCreated, ContactID: 1}}}
+
+	suite.app.Mock.On("Import", anonymous, rows, dto.ImportOptions{DryRun: true, OnDuplicate: dto.DuplicateUpdate}).
+		Return(report, nil)
+
+	body, contentType := importForm("contacts.csv", "application/octet-stream", "Full name,Mobile\nJuan,3000000000\n",
+		map[string]string{
+			"mapping":      `{"Full name": "name", "Mobile": "phone_number"}`,
+			"dry_run":      "true",
+			"on_duplicate": "update",
+		})
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	suite.NoError(suite.underTest.Import(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Contains(setupCase.Res.Body.String(), `"created":1`)
+}
+
+func (suite *contactsTestSuite) TestImport_WhenVCard() {
+	rows := []dto.ImportRow{{Row: 1, Contact: dto.Contact{Name: "Juan", PhoneNumber: "3000000000"}}}
+	report := dto.ImportReport{Created: 1, Rows: []dto.ImportResult{{Row: 1, Status: dto.ImportCreated, ContactID: 1}}}
+
+	suite.app.Mock.On("Import", anonymous, rows, dto.ImportOptions{OnDuplicate: dto.DuplicateSkip}).Return(report, nil)
+
+	body, contentType := importForm("contacts.vcf", "application/octet-stream",
+		"BEGIN:VCARD\r\nVERSION:3.0\r\nFN:Juan\r\nTEL;TYPE=CELL:3000000000\r\nEND:VCARD\r\n", nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	suite.NoError(suite.underTest.Import(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestImport_WhenVCardIsMalformed() {
+	body, contentType := importForm("contacts", "text/x-vcard", "BEGIN:VCARD\r\nVERSION:3.0\r\n", nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	err := suite.underTest.Import(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Import")
+}
+
+func (suite *contactsTestSuite) TestImport_WhenFileIsMissing() {
+	body, contentType := importForm("", "", "", map[string]string{"dry_run": "true"})
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	err := suite.underTest.Import(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestImport_WhenFormatIsNotSupported() {
+	body, contentType := importForm("contacts.xlsx", "application/vnd.ms-excel", "binary", nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	err := suite.underTest.Import(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusUnsupportedMediaType, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestImport_WhenFileHasTooManyRows() {
+	body, contentType := importForm("contacts.csv", MIMETextCSV, "name,phone_number\na,1\nb,2\nc,3\nd,4\n", nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	err := suite.underTest.Import(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
+
+func (suite *contactsTestSuite) TestImport_WhenStopped() {
+	rows := []dto.ImportRow{{Row: 2, Contact: dto.Contact{Name: "Juan", PhoneNumber: "3000000000"}}}
+	report := dto.ImportReport{Failed: 1, StoppedAt: 2, Rows: []dto.ImportResult{{Row: 2, Status: dto.ImportFailed}}}
+
+	suite.app.Mock.On("Import", anonymous, rows, dto.ImportOptions{OnDuplicate: dto.DuplicateSkip}).
+		Return(report, apperrors.Internal(errors.New("connection reset")))
+
+	body, contentType := importForm("contacts.csv", MIMETextCSV, "name,phone_number\nJuan,3000000000\n", nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	suite.NoError(suite.underTest.Import(setupCase.context))
+	suite.Equal(http.StatusMultiStatus, setupCase.Res.Code)
+	suite.Contains(setupCase.Res.Body.String(), `"stopped_at":2`)
+	suite.NotContains(setupCase.Res.Body.String(), "connection reset")
+}
+
+func (suite *contactsTestSuite) TestImport_WhenDryRunIsInvalid() {
+	body, contentType := importForm("contacts.csv", MIMETextCSV, "name,phone_number\n", map[string]string{"dry_run": "yes!"})
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/contacts/import", body)
+	setupCase.Req.Header.Set(echo.HeaderContentType, contentType)
+
+	err := suite.underTest.Import(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	idParam       = "id"
+	revisionParam = "rev"
+
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+	HeaderActor       = "X-Actor"
+
+	anonymousActor = "anonymous"
+)
+
+// pathID binds the id path param of the request, malformed ids are rejected
+// instead of being looked up.
+func pathID(ctx echo.Context) (uint, error) {
+	return dto.ParseID(idParam, ctx.Param(idParam))
+}
+
+// origin identifies the author of the changes made by the request: the actor
+// named in the X-Actor header, anonymous when it is missing, and the id of the
+// request.
+func origin(ctx echo.Context) models.Origin {
+	actor := strings.TrimSpace(ctx.Request().Header.Get(HeaderActor))
+	if actor == "" {
+		actor = anonymousActor
+	}
+
+	requestID := ctx.Response().Header().Get(echo.HeaderXRequestID)
+	if requestID == "" {
+		requestID = ctx.Request().Header.Get(echo.HeaderXRequestID)
+	}
+
+	return models.Origin{Actor: actor, RequestID: requestID}
+}
+
+// bindPatch reads the patch document of the request, the Content-Type decides if
+// it is a merge patch or a JSON patch.
+func bindPatch(ctx echo.Context) (dto.ContactPatch, error) {
+	mediaType, _, _ := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+	if mediaType != dto.MIMEApplicationMergePatchJSON && mediaType != dto.MIMEApplicationJSONPatchJSON {
+		return dto.ContactPatch{}, echo.ErrUnsupportedMediaType
+	}
+
+	document, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return dto.ContactPatch{}, apperrors.Validation(invalidRequestMessage, err)
+	}
+
+	if mediaType == dto.MIMEApplicationMergePatchJSON {
+		return dto.NewMergePatch(document)
+	}
+
+	return dto.NewJSONPatch(document)
+}
+
+// etag is the strong entity tag of a record version.
+func etag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ifMatch reads the If-Match header, returning the version the request requires or
+// 0 when any version is accepted. The header can list several tags, in which case
+// current is called for the version of the resource, which is required when it
+// is one of them. Tags are compared strongly, so weak or unknown tags never match.
+func ifMatch(ctx echo.Context, current func() (uint, error)) (uint, error) {
+	value := strings.TrimSpace(ctx.Request().Header.Get(HeaderIfMatch))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	var versions []uint
+	for _, tag := range strings.Split(value, ",") {
+		if version, ok := parseETag(strings.TrimSpace(tag)); ok {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 1 {
+		return versions[0], nil
+	}
+
+	if len(versions) > 1 {
+		version, err := current()
+		if err != nil {
+			return 0, err
+		}
+
+		for _, listed := range versions {
+			if listed == version {
+				return version, nil
+			}
+		}
+	}
+
+	return 0, apperrors.PreconditionFailed(fmt.Sprintf("%s does not match the current ETag", value), nil)
+}
+
+// versionOf returns the current version of the contact id, read with get.
+func versionOf(get func(id uint) (models.Contact, error), id uint) func() (uint, error) {
+	return func() (uint, error) {
+		contact, err := get(id)
+
+		return contact.Version, err
+	}
+}
+
+// ifNoneMatch reports if the If-None-Match header of the request matches version,
+// using the weak comparison RFC 9110 requires for this header.
+func ifNoneMatch(ctx echo.Context, version uint) bool {
+	value := strings.TrimSpace(ctx.Request().Header.Get(HeaderIfNoneMatch))
+	if value == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(value, ",") {
+		if current, ok := parseETag(strings.TrimPrefix(strings.TrimSpace(tag), "W/")); ok && current == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseETag(value string) (uint, bool) {
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, false
+	}
+
+	version, err := strconv.ParseUint(value[1:len(value)-1], 10, 64)
+	if err != nil || version == 0 {
+		return 0, false
+	}
+
+	return uint(version), true
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/AjxGnx/contacts-go/internal/app"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/labstack/echo/v4"
+)
+
+type Tags interface {
+	Create(ctx echo.Context) error
+	GetByID(ctx echo.Context) error
+	Update(ctx echo.Context) error
+	Delete(ctx echo.Context) error
+	Get(ctx echo.Context) error
+	Assign(ctx echo.Context) error
+	Unassign(ctx echo.Context) error
+}
+
+type tags struct {
+	app app.Tags
+}
+
+func NewTags(app app.Tags) Tags {
+	return &tags{
+		app,
+	}
+}
+
+// @Tags         Tags
+// @Summary      Create a tag
+// @Description  Create a tag, tag names are unique
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.Tag  true  "Request Body"
+// @Success      200      {object}  dto.Message{data=models.Tag}
+// @Failure      400      {object}  dto.Problem
+// @Failure      409      {object}  dto.Problem
+// @Failure      500      {object}  dto.Problem
+// @Router       /tags/ [post]
+func (handler *tags) Create(ctx echo.Context) error {
+	var tag dto.Tag
+
+	if err := ctx.Bind(&tag); err != nil {
+		return bindError(err)
+	}
+
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+
+	result, err := handler.app.Create(tag)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "tag created successfully",
+		Data:    result,
+	})
+}
+
+// @Tags         Tags
+// @Summary      Get Tag by id
+// @Description  Get Tag by id
+// @Produce      json
+// @Param        id   path      int  true  "value of record to find"
+// @Success      200  {object}  dto.Message{data=models.Tag}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /tags/{id} [get]
+func (handler *tags) GetByID(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := handler.app.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "tag successfully loaded",
+		Data:    tag,
+	})
+}
+
+// @Tags         Tags
+// @Summary      Update Tag by id
+// @Description  Rename or recolor the Tag, the contacts having it keep it
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.Tag  true  "Request Body"
+// @Param        id       path      int      true  "value of record to update"
+// @Param        X-Actor  header    string   false "author of the change, anonymous by default"
+// @Success      200  {object}  dto.Message{data=models.Tag}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      409  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /tags/{id} [put]
+func (handler *tags) Update(ctx echo.Context) error {
+	var tag dto.Tag
+
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Bind(&tag); err != nil {
+		return bindError(err)
+	}
+
+	if err := tag.Validate(); err != nil {
+		return err
+	}
+
+	result, err := handler.app.Update(origin(ctx), id, tag)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "tag updated successfully",
+		Data:    result,
+	})
+}
+
+// @Tags         Tags
+// @Summary      Delete Tag by id
+// @Description  Delete the Tag and remove it from every contact
+// @Produce      json
+// @Param        id       path      int     true   "value of record to delete"
+// @Param        X-Actor  header    string  false  "author of the change, anonymous by default"
+// @Success      200  {object}  dto.Message{}
+// @Failure      400  {object}  dto.Problem
+// @Failure      404  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /tags/{id} [delete]
+func (handler *tags) Delete(ctx echo.Context) error {
+	id, err := pathID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.app.Delete(origin(ctx), id); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "tag successfully deleted",
+	})
+}
+
+// @Tags         Tags
+// @Summary      Get tags
+// @Description  Get every tag sorted by name
+// @Produce      json
+// @Success      200  {object}  dto.Message{data=[]models.Tag}
+// @Failure      500  {object}  dto.Problem
+// @Router       /tags/ [get]
+func (handler *tags) Get(ctx echo.Context) error {
+	result, err := handler.app.Get()
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "tags successfully loaded",
+		Data:    result,
+	})
+}
+
+// @Tags         Tags
+// @Summary      Add tags to contacts
+// @Description  Add every tag to every contact, the whole request fails if a tag or a contact does not exist
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TagAssignment  true   "Request Body"
+// @Param        X-Actor  header    string             false  "author of the change, anonymous by default"
+// @Success      200  {object}  dto.Message{}
+// @Failure      400  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /tags/assign [post]
+func (handler *tags) Assign(ctx echo.Context) error {
+	assignment, err := bindAssignment(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.app.Assign(origin(ctx), assignment); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "tags successfully assigned",
+	})
+}
+
+// @Tags         Tags
+// @Summary      Remove tags from contacts
+// @Description  Remove every tag from every contact, the whole request fails if a tag or a contact does not exist
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.TagAssignment  true   "Request Body"
+// @Param        X-Actor  header    string             false  "author of the change, anonymous by default"
+// @Success      200  {object}  dto.Message{}
+// @Failure      400  {object}  dto.Problem
+// @Failure      500  {object}  dto.Problem
+// @Router       /tags/unassign [post]
+func (handler *tags) Unassign(ctx echo.Context) error {
+	assignment, err := bindAssignment(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.app.Unassign(origin(ctx), assignment); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, dto.Message{
+		Message: "tags successfully unassigned",
+	})
+}
+
+func bindAssignment(ctx echo.Context) (dto.TagAssignment, error) {
+	var assignment dto.TagAssignment
+
+	if err := ctx.Bind(&assignment); err != nil {
+		return assignment, bindError(err)
+	}
+
+	return assignment, assignment.Validate()
+}
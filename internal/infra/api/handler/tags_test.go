@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	mocks "github.com/AjxGnx/contacts-go/mocks/app"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type tagsTestSuite struct {
+	suite.Suite
+	app       *mocks.Tags
+	underTest Tags
+}
+
+func TestTagsSuite(t *testing.T) {
+	suite.Run(t, new(tagsTestSuite))
+}
+
+func (suite *tagsTestSuite) SetupTest() {
+	suite.app = &mocks.Tags{}
+	suite.underTest = NewTags(suite.app)
+}
+
+func (suite *tagsTestSuite) TestCreate_WhenSuccess() {
+	suite.app.Mock.On("Create", dto.Tag{Name: "family"}).Return(models.Tag{ID: 1, Name: "family"}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/tags/", strings.NewReader(`{"name": "family"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	suite.NoError(suite.underTest.Create(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *tagsTestSuite) TestCreate_WhenValidateFail() {
+	setupCase := SetupControllerCase(http.MethodPost, "/api/tags/", strings.NewReader(`{"color": "orange"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Create(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Create")
+}
+
+func (suite *tagsTestSuite) TestCreate_WhenTagExists() {
+	suite.app.Mock.On("Create", dto.Tag{Name: "family"}).
+		Return(models.Tag{}, apperrors.Conflict("the tag family already exists", nil))
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/tags/", strings.NewReader(`{"name": "family"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Create(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusConflict, StatusCode(err))
+}
+
+func (suite *tagsTestSuite) TestGetByID_WhenTagNotFound() {
+	suite.app.Mock.On("GetByID", uint(3)).Return(models.Tag{}, apperrors.NotFound("the tag: 3 does not exist", nil))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/tags/3", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("3")
+
+	err := suite.underTest.GetByID(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, StatusCode(err))
+}
+
+func (suite *tagsTestSuite) TestUpdate_WhenSuccess() {
+	suite.app.Mock.On("Update", anonymous, uint(3), dto.Tag{Name: "friends"}).Return(models.Tag{ID: 3, Name: "friends"}, nil)
+
+	setupCase := SetupControllerCase(http.MethodPut, "/api/tags/3", strings.NewReader(`{"name": "friends"}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("3")
+
+	suite.NoError(suite.underTest.Update(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *tagsTestSuite) TestDelete_WhenIDIsMalformed() {
+	setupCase := SetupControllerCase(http.MethodDelete, "/api/tags/abc", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("abc")
+
+	err := suite.underTest.Delete(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Delete")
+}
+
+func (suite *tagsTestSuite) TestGet_WhenFail() {
+	suite.app.Mock.On("Get").Return(nil, errors.New("some error"))
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/tags/", nil)
+
+	err := suite.underTest.Get(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusInternalServerError, StatusCode(err))
+}
+
+func (suite *tagsTestSuite) TestAssign_WhenSuccess() {
+	assignment := dto.TagAssignment{ContactIDs: []uint{1, 2}, Tags: []string{"family"}}
+	suite.app.Mock.On("Assign", models.Origin{Actor: "ana"}, assignment).Return(nil)
+
+	setupCase := SetupControllerCase(http.MethodPost, "/api/tags/assign",
+		strings.NewReader(`{"contact_ids": [1, 2], "tags": ["family"]}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	setupCase.Req.Header.Set(HeaderActor, "ana")
+
+	suite.NoError(suite.underTest.Assign(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+}
+
+func (suite *tagsTestSuite) TestUnassign_WhenValidateFail() {
+	setupCase := SetupControllerCase(http.MethodPost, "/api/tags/unassign", strings.NewReader(`{"tags": ["family"]}`))
+	setupCase.Req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	err := suite.underTest.Unassign(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "Unassign")
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/apperrors"
+	"github.com/AjxGnx/contacts-go/internal/domain/dto"
+	"github.com/AjxGnx/contacts-go/internal/domain/vcard"
+	"github.com/labstack/echo/v4"
+)
+
+// MIMETextVCard is the Content-Type of the vCards served.
+const MIMETextVCard = vcard.MIMEType + "; charset=utf-8"
+
+// @Tags         Contacts
+// @Summary      Get Contact by id as a vCard
+// @Description  Get Contact by id as a vCard, version 3.0 unless another one is asked for
+// @Produce      text/vcard
+// @Param        id             path      int     true   "value of record to find"
+// @Param        version        query     string  false  "version of the vCard, 3.0 by default"  Enums(3.0, 4.0)
+// @Param        If-None-Match  header    string  false  "ETag already known by the client"
+// @Success      200            {file}    file
+// @Header       200            {string}  ETag  "version of the contact"
+// @Success      304            "the contact did not change"
+// @Failure      400            {object}  dto.Problem
+// @Failure      404            {object}  dto.Problem
+// @Failure      500            {object}  dto.Problem
+// @Router       /contacts/{id}.vcf [get]
+func (handler *contacts) getVCard(ctx echo.Context) error {
+	id, err := dto.ParseID(idParam, strings.TrimSuffix(ctx.Param(idParam), vcard.Extension))
+	if err != nil {
+		return err
+	}
+
+	version, err := dto.ParseVCardVersion(ctx.QueryParam("version"))
+	if err != nil {
+		return err
+	}
+
+	contact, err := handler.app.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderETag, etag(contact.Version))
+
+	if ifNoneMatch(ctx, contact.Version) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	var card bytes.Buffer
+	if err := vcard.Encode(&card, contact, version); err != nil {
+		return apperrors.Internal(err)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="contact-%d%s"`, id, vcard.Extension))
+
+	return ctx.Blob(http.StatusOK, MIMETextVCard, card.Bytes())
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/domain/models"
+	"github.com/labstack/echo/v4"
+)
+
+func (suite *contactsTestSuite) TestGetByID_WhenVCard() {
+	suite.app.Mock.On("GetByID", uint(10)).
+		Return(models.Contact{ID: 10, Name: "Juan", PhoneNumber: "+573000000000", Version: 4}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10.vcf?version=4.0", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10.vcf")
+
+	suite.NoError(suite.underTest.GetByID(setupCase.context))
+	suite.Equal(http.StatusOK, setupCase.Res.Code)
+	suite.Equal(MIMETextVCard, setupCase.Res.Header().Get(echo.HeaderContentType))
+	suite.Equal(`attachment; filename="contact-10.vcf"`, setupCase.Res.Header().Get(echo.HeaderContentDisposition))
+	suite.Equal(`"4"`, setupCase.Res.Header().Get(HeaderETag))
+	suite.True(strings.HasPrefix(setupCase.Res.Body.String(), "BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Juan\r\n"))
+}
+
+func (suite *contactsTestSuite) TestGetByID_WhenVCardIsNotModified() {
+	suite.app.Mock.On("GetByID", uint(10)).Return(models.Contact{ID: 10, Name: "Juan", Version: 4}, nil)
+
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10.vcf", nil)
+	setupCase.Req.Header.Set(HeaderIfNoneMatch, `"4"`)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10.vcf")
+
+	suite.NoError(suite.underTest.GetByID(setupCase.context))
+	suite.Equal(http.StatusNotModified, setupCase.Res.Code)
+}
+
+func (suite *contactsTestSuite) TestGetByID_WhenVCardVersionIsInvalid() {
+	setupCase := SetupControllerCase(http.MethodGet, "/api/contacts/10.vcf?version=2.1", nil)
+	setupCase.context.SetParamNames("id")
+	setupCase.context.SetParamValues("10.vcf")
+
+	err := suite.underTest.GetByID(setupCase.context)
+
+	suite.Error(err)
+	suite.Equal(http.StatusBadRequest, StatusCode(err))
+	suite.app.Mock.AssertNotCalled(suite.T(), "GetByID")
+}
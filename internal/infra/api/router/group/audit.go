@@ -0,0 +1,29 @@
+package group
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/infra/api/handler"
+	"github.com/labstack/echo/v4"
+)
+
+const auditPath = "/audit"
+
+type Audit interface {
+	Resource(c *echo.Group)
+}
+
+type audit struct {
+	handler handler.Audit
+}
+
+func NewAudit(handler handler.Audit) Audit {
+	return &audit{
+		handler,
+	}
+}
+
+// Resource registers the audit log, along with the history of each contact
+// which lives under the contacts path.
+func (routes *audit) Resource(c *echo.Group) {
+	c.GET(auditPath, routes.handler.Get)
+	c.GET(contactsPath+":id/history", routes.handler.History)
+}
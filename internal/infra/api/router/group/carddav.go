@@ -0,0 +1,41 @@
+package group
+
+import (
+	"strings"
+
+	"github.com/AjxGnx/contacts-go/internal/infra/api/handler"
+	"github.com/labstack/echo/v4"
+)
+
+type CardDAV interface {
+	Resource(c *echo.Group)
+}
+
+type cardDAV struct {
+	handler handler.CardDAV
+}
+
+func NewCardDAV(handler handler.CardDAV) CardDAV {
+	return &cardDAV{
+		handler,
+	}
+}
+
+// Resource registers the CardDAV server, which lives at the root of the server
+// instead of under the API path, so that clients find it at the well-known URL.
+func (routes *cardDAV) Resource(c *echo.Group) {
+	c.GET(handler.CardDAVWellKnownPath, routes.handler.WellKnown)
+	c.Add(echo.PROPFIND, handler.CardDAVWellKnownPath, routes.handler.WellKnown)
+
+	// clients reach the principal with or without the trailing slash
+	groupPath := c.Group(strings.TrimSuffix(handler.CardDAVPath, "/"))
+	for _, path := range []string{"", "/*"} {
+		groupPath.OPTIONS(path, routes.handler.Options)
+		groupPath.Add(echo.PROPFIND, path, routes.handler.PropFind)
+		groupPath.Add(echo.REPORT, path, routes.handler.Report)
+		groupPath.GET(path, routes.handler.Get)
+		groupPath.HEAD(path, routes.handler.Get)
+		groupPath.PUT(path, routes.handler.Put)
+		groupPath.DELETE(path, routes.handler.Delete)
+	}
+}
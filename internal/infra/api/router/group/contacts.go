@@ -25,7 +25,19 @@ func (routes *contacts) Resource(c *echo.Group) {
 	groupPath := c.Group(contactsPath)
 	groupPath.POST("", routes.handler.Create)
 	groupPath.GET("", routes.handler.Get)
+	groupPath.POST("import", routes.handler.Import)
+	groupPath.GET("export", routes.handler.Export)
+	groupPath.POST("batch", routes.handler.Batch)
+	groupPath.GET("duplicates", routes.handler.Duplicates)
+	groupPath.POST("merge", routes.handler.Merge)
+	groupPath.GET("search", routes.handler.Search)
+	groupPath.GET("trash", routes.handler.GetTrash)
+	groupPath.DELETE("trash/:id", routes.handler.Purge)
 	groupPath.GET(":id", routes.handler.GetByID)
 	groupPath.PUT(":id", routes.handler.Update)
+	groupPath.PATCH(":id", routes.handler.Patch)
 	groupPath.DELETE(":id", routes.handler.Delete)
+	groupPath.POST(":id/restore", routes.handler.Restore)
+	groupPath.GET(":id/revisions", routes.handler.GetRevisions)
+	groupPath.POST(":id/revisions/:rev/revert", routes.handler.Revert)
 }
@@ -0,0 +1,34 @@
+package group
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/infra/api/handler"
+	"github.com/labstack/echo/v4"
+)
+
+const groupsPath = "/groups/"
+
+type Groups interface {
+	Resource(c *echo.Group)
+}
+
+type groups struct {
+	handler handler.Groups
+}
+
+func NewGroups(handler handler.Groups) Groups {
+	return &groups{
+		handler,
+	}
+}
+
+func (routes *groups) Resource(c *echo.Group) {
+	groupPath := c.Group(groupsPath)
+	groupPath.POST("", routes.handler.Create)
+	groupPath.GET("", routes.handler.Get)
+	groupPath.GET(":id", routes.handler.GetByID)
+	groupPath.PUT(":id", routes.handler.Update)
+	groupPath.DELETE(":id", routes.handler.Delete)
+	groupPath.GET(":id/members", routes.handler.Members)
+	groupPath.POST(":id/members", routes.handler.AddMembers)
+	groupPath.DELETE(":id/members", routes.handler.RemoveMembers)
+}
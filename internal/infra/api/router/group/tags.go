@@ -0,0 +1,33 @@
+package group
+
+import (
+	"github.com/AjxGnx/contacts-go/internal/infra/api/handler"
+	"github.com/labstack/echo/v4"
+)
+
+const tagsPath = "/tags/"
+
+type Tags interface {
+	Resource(c *echo.Group)
+}
+
+type tags struct {
+	handler handler.Tags
+}
+
+func NewTags(handler handler.Tags) Tags {
+	return &tags{
+		handler,
+	}
+}
+
+func (routes *tags) Resource(c *echo.Group) {
+	groupPath := c.Group(tagsPath)
+	groupPath.POST("", routes.handler.Create)
+	groupPath.GET("", routes.handler.Get)
+	groupPath.POST("assign", routes.handler.Assign)
+	groupPath.POST("unassign", routes.handler.Unassign)
+	groupPath.GET(":id", routes.handler.GetByID)
+	groupPath.PUT(":id", routes.handler.Update)
+	groupPath.DELETE(":id", routes.handler.Delete)
+}
@@ -12,29 +12,51 @@ import (
 type Router struct {
 	server        *echo.Echo
 	contactsGroup group.Contacts
+	tagsGroup     group.Tags
+	groupsGroup   group.Groups
+	auditGroup    group.Audit
+	cardDAVGroup  group.CardDAV
+	idempotency   handler.Idempotency
 }
 
 func New(
 	server *echo.Echo,
 	contactsGroup group.Contacts,
+	tagsGroup group.Tags,
+	groupsGroup group.Groups,
+	auditGroup group.Audit,
+	cardDAVGroup group.CardDAV,
+	idempotency handler.Idempotency,
 ) *Router {
 	return &Router{
 		server,
 		contactsGroup,
+		tagsGroup,
+		groupsGroup,
+		auditGroup,
+		cardDAVGroup,
+		idempotency,
 	}
 }
 
 func (router *Router) Init() {
+	router.server.Use(middleware.RequestID())
 	router.server.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Format: "method=${method}, uri=${uri}, status=${status} latency=${latency_human}\n",
+		Format: "id=${id}, method=${method}, uri=${uri}, status=${status} latency=${latency_human}\n",
 	}))
 
 	router.server.Use(middleware.Recover())
+	router.server.HTTPErrorHandler = handler.ErrorHandler
 
-	basePath := router.server.Group("/api")
+	basePath := router.server.Group("/api", router.idempotency.Handle)
 
 	basePath.GET("/swagger/*", echoSwagger.WrapHandler)
 	basePath.GET("/health", handler.HealthCheck)
 
 	router.contactsGroup.Resource(basePath)
+	router.tagsGroup.Resource(basePath)
+	router.groupsGroup.Resource(basePath)
+	router.auditGroup.Resource(basePath)
+
+	router.cardDAVGroup.Resource(router.server.Group(""))
 }
@@ -0,0 +1,159 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/AjxGnx/contacts-go/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AddressBook is an autogenerated mock type for the AddressBook type
+type AddressBook struct {
+	mock.Mock
+}
+
+// Bind provides a mock function with given fields: resource
+func (_m *AddressBook) Bind(resource models.CardResource) error {
+	ret := _m.Called(resource)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Bind")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.CardResource) error); ok {
+		r0 = rf(resource)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Changes provides a mock function with given fields: since
+func (_m *AddressBook) Changes(since uint) (models.AddressBookChanges, error) {
+	ret := _m.Called(since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Changes")
+	}
+
+	var r0 models.AddressBookChanges
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (models.AddressBookChanges, error)); ok {
+		return rf(since)
+	}
+	if rf, ok := ret.Get(0).(func(uint) models.AddressBookChanges); ok {
+		r0 = rf(since)
+	} else {
+		r0 = ret.Get(0).(models.AddressBookChanges)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resolve provides a mock function with given fields: name
+func (_m *AddressBook) Resolve(name string) (models.CardResource, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 models.CardResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.CardResource, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.CardResource); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(models.CardResource)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resources provides a mock function with given fields: contactIDs
+func (_m *AddressBook) Resources(contactIDs []uint) ([]models.CardResource, error) {
+	ret := _m.Called(contactIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resources")
+	}
+
+	var r0 []models.CardResource
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]uint) ([]models.CardResource, error)); ok {
+		return rf(contactIDs)
+	}
+	if rf, ok := ret.Get(0).(func([]uint) []models.CardResource); ok {
+		r0 = rf(contactIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CardResource)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]uint) error); ok {
+		r1 = rf(contactIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncToken provides a mock function with no fields
+func (_m *AddressBook) SyncToken() (uint, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncToken")
+	}
+
+	var r0 uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (uint, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() uint); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAddressBook creates a new instance of AddressBook. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAddressBook(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AddressBook {
+	mock := &AddressBook{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
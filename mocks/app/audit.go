@@ -0,0 +1,89 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	dto "github.com/AjxGnx/contacts-go/internal/domain/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Audit is an autogenerated mock type for the Audit type
+type Audit struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: paginate, filter
+func (_m *Audit) Get(paginate dto.Paginate, filter models.AuditFilter) (*models.Paginator, error) {
+	ret := _m.Called(paginate, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(dto.Paginate, models.AuditFilter) (*models.Paginator, error)); ok {
+		return rf(paginate, filter)
+	}
+	if rf, ok := ret.Get(0).(func(dto.Paginate, models.AuditFilter) *models.Paginator); ok {
+		r0 = rf(paginate, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(dto.Paginate, models.AuditFilter) error); ok {
+		r1 = rf(paginate, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// History provides a mock function with given fields: contactID, paginate
+func (_m *Audit) History(contactID uint, paginate dto.Paginate) (*models.Paginator, error) {
+	ret := _m.Called(contactID, paginate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, dto.Paginate) (*models.Paginator, error)); ok {
+		return rf(contactID, paginate)
+	}
+	if rf, ok := ret.Get(0).(func(uint, dto.Paginate) *models.Paginator); ok {
+		r0 = rf(contactID, paginate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, dto.Paginate) error); ok {
+		r1 = rf(contactID, paginate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAudit creates a new instance of Audit. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAudit(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Audit {
+	mock := &Audit{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
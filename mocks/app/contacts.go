@@ -7,6 +7,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/AjxGnx/contacts-go/internal/domain/models"
+
+	time "time"
 )
 
 // Contacts is an autogenerated mock type for the Contacts type
@@ -14,9 +16,39 @@ type Contacts struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: contact
-func (_m *Contacts) Create(contact dto.Contact) (models.Contact, error) {
-	ret := _m.Called(contact)
+// Batch provides a mock function with given fields: origin, operations, atomic
+func (_m *Contacts) Batch(origin models.Origin, operations []dto.BatchOperation, atomic bool) ([]models.BatchResult, error) {
+	ret := _m.Called(origin, operations, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 []models.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, []dto.BatchOperation, bool) ([]models.BatchResult, error)); ok {
+		return rf(origin, operations, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, []dto.BatchOperation, bool) []models.BatchResult); ok {
+		r0 = rf(origin, operations, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, []dto.BatchOperation, bool) error); ok {
+		r1 = rf(origin, operations, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: origin, contact
+func (_m *Contacts) Create(origin models.Origin, contact dto.Contact) (models.Contact, error) {
+	ret := _m.Called(origin, contact)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -24,17 +56,17 @@ func (_m *Contacts) Create(contact dto.Contact) (models.Contact, error) {
 
 	var r0 models.Contact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(dto.Contact) (models.Contact, error)); ok {
-		return rf(contact)
+	if rf, ok := ret.Get(0).(func(models.Origin, dto.Contact) (models.Contact, error)); ok {
+		return rf(origin, contact)
 	}
-	if rf, ok := ret.Get(0).(func(dto.Contact) models.Contact); ok {
-		r0 = rf(contact)
+	if rf, ok := ret.Get(0).(func(models.Origin, dto.Contact) models.Contact); ok {
+		r0 = rf(origin, contact)
 	} else {
 		r0 = ret.Get(0).(models.Contact)
 	}
 
-	if rf, ok := ret.Get(1).(func(dto.Contact) error); ok {
-		r1 = rf(contact)
+	if rf, ok := ret.Get(1).(func(models.Origin, dto.Contact) error); ok {
+		r1 = rf(origin, contact)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,17 +74,65 @@ func (_m *Contacts) Create(contact dto.Contact) (models.Contact, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Contacts) Delete(id uint) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: origin, id, version
+func (_m *Contacts) Delete(origin models.Origin, id uint, version uint) error {
+	ret := _m.Called(origin, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, uint) error); ok {
+		r0 = rf(origin, id, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Duplicates provides a mock function with given fields: minScore
+func (_m *Contacts) Duplicates(minScore float64) ([]models.DuplicateCluster, error) {
+	ret := _m.Called(minScore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Duplicates")
+	}
+
+	var r0 []models.DuplicateCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(float64) ([]models.DuplicateCluster, error)); ok {
+		return rf(minScore)
+	}
+	if rf, ok := ret.Get(0).(func(float64) []models.DuplicateCluster); ok {
+		r0 = rf(minScore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DuplicateCluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(float64) error); ok {
+		r1 = rf(minScore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: paginate, write
+func (_m *Contacts) Export(paginate dto.Paginate, write func([]models.Contact) error) error {
+	ret := _m.Called(paginate, write)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(dto.Paginate, func([]models.Contact) error) error); ok {
+		r0 = rf(paginate, write)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -90,6 +170,34 @@ func (_m *Contacts) Get(paginate dto.Paginate) (*models.Paginator, error) {
 	return r0, r1
 }
 
+// GetAsOf provides a mock function with given fields: id, at
+func (_m *Contacts) GetAsOf(id uint, at time.Time) (models.Contact, error) {
+	ret := _m.Called(id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAsOf")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) (models.Contact, error)); ok {
+		return rf(id, at)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) models.Contact); ok {
+		r0 = rf(id, at)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: id
 func (_m *Contacts) GetByID(id uint) (models.Contact, error) {
 	ret := _m.Called(id)
@@ -118,9 +226,257 @@ func (_m *Contacts) GetByID(id uint) (models.Contact, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, contact
-func (_m *Contacts) Update(id uint, contact dto.Contact) (models.Contact, error) {
-	ret := _m.Called(id, contact)
+// GetRevisions provides a mock function with given fields: id, paginate
+func (_m *Contacts) GetRevisions(id uint, paginate dto.Paginate) (*models.Paginator, error) {
+	ret := _m.Called(id, paginate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, dto.Paginate) (*models.Paginator, error)); ok {
+		return rf(id, paginate)
+	}
+	if rf, ok := ret.Get(0).(func(uint, dto.Paginate) *models.Paginator); ok {
+		r0 = rf(id, paginate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, dto.Paginate) error); ok {
+		r1 = rf(id, paginate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: paginate
+func (_m *Contacts) GetTrash(paginate dto.Paginate) (*models.Paginator, error) {
+	ret := _m.Called(paginate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(dto.Paginate) (*models.Paginator, error)); ok {
+		return rf(paginate)
+	}
+	if rf, ok := ret.Get(0).(func(dto.Paginate) *models.Paginator); ok {
+		r0 = rf(paginate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(dto.Paginate) error); ok {
+		r1 = rf(paginate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: origin, rows, options
+func (_m *Contacts) Import(origin models.Origin, rows []dto.ImportRow, options dto.ImportOptions) (dto.ImportReport, error) {
+	ret := _m.Called(origin, rows, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Import")
+	}
+
+	var r0 dto.ImportReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, []dto.ImportRow, dto.ImportOptions) (dto.ImportReport, error)); ok {
+		return rf(origin, rows, options)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, []dto.ImportRow, dto.ImportOptions) dto.ImportReport); ok {
+		r0 = rf(origin, rows, options)
+	} else {
+		r0 = ret.Get(0).(dto.ImportReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, []dto.ImportRow, dto.ImportOptions) error); ok {
+		r1 = rf(origin, rows, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: origin, merge, version
+func (_m *Contacts) Merge(origin models.Origin, merge dto.Merge, version uint) (models.Contact, error) {
+	ret := _m.Called(origin, merge, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, dto.Merge, uint) (models.Contact, error)); ok {
+		return rf(origin, merge, version)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, dto.Merge, uint) models.Contact); ok {
+		r0 = rf(origin, merge, version)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, dto.Merge, uint) error); ok {
+		r1 = rf(origin, merge, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Patch provides a mock function with given fields: origin, id, patch, version
+func (_m *Contacts) Patch(origin models.Origin, id uint, patch dto.ContactPatch, version uint) (models.Contact, error) {
+	ret := _m.Called(origin, id, patch, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, dto.ContactPatch, uint) (models.Contact, error)); ok {
+		return rf(origin, id, patch, version)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, dto.ContactPatch, uint) models.Contact); ok {
+		r0 = rf(origin, id, patch, version)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, uint, dto.ContactPatch, uint) error); ok {
+		r1 = rf(origin, id, patch, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: origin, id
+func (_m *Contacts) Purge(origin models.Origin, id uint) error {
+	ret := _m.Called(origin, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint) error); ok {
+		r0 = rf(origin, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: origin, id
+func (_m *Contacts) Restore(origin models.Origin, id uint) (models.Contact, error) {
+	ret := _m.Called(origin, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint) (models.Contact, error)); ok {
+		return rf(origin, id)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, uint) models.Contact); ok {
+		r0 = rf(origin, id)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, uint) error); ok {
+		r1 = rf(origin, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revert provides a mock function with given fields: origin, id, revision, version
+func (_m *Contacts) Revert(origin models.Origin, id uint, revision uint, version uint) (models.Contact, error) {
+	ret := _m.Called(origin, id, revision, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revert")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, uint, uint) (models.Contact, error)); ok {
+		return rf(origin, id, revision, version)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, uint, uint) models.Contact); ok {
+		r0 = rf(origin, id, revision, version)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, uint, uint, uint) error); ok {
+		r1 = rf(origin, id, revision, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: query, paginate
+func (_m *Contacts) Search(query string, paginate dto.Paginate) (*models.Paginator, error) {
+	ret := _m.Called(query, paginate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, dto.Paginate) (*models.Paginator, error)); ok {
+		return rf(query, paginate)
+	}
+	if rf, ok := ret.Get(0).(func(string, dto.Paginate) *models.Paginator); ok {
+		r0 = rf(query, paginate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, dto.Paginate) error); ok {
+		r1 = rf(query, paginate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: origin, id, contact, version
+func (_m *Contacts) Update(origin models.Origin, id uint, contact dto.Contact, version uint) (models.Contact, error) {
+	ret := _m.Called(origin, id, contact, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -128,17 +484,17 @@ func (_m *Contacts) Update(id uint, contact dto.Contact) (models.Contact, error)
 
 	var r0 models.Contact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint, dto.Contact) (models.Contact, error)); ok {
-		return rf(id, contact)
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, dto.Contact, uint) (models.Contact, error)); ok {
+		return rf(origin, id, contact, version)
 	}
-	if rf, ok := ret.Get(0).(func(uint, dto.Contact) models.Contact); ok {
-		r0 = rf(id, contact)
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, dto.Contact, uint) models.Contact); ok {
+		r0 = rf(origin, id, contact, version)
 	} else {
 		r0 = ret.Get(0).(models.Contact)
 	}
 
-	if rf, ok := ret.Get(1).(func(uint, dto.Contact) error); ok {
-		r1 = rf(id, contact)
+	if rf, ok := ret.Get(1).(func(models.Origin, uint, dto.Contact, uint) error); ok {
+		r1 = rf(origin, id, contact, version)
 	} else {
 		r1 = ret.Error(1)
 	}
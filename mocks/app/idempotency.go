@@ -0,0 +1,75 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/AjxGnx/contacts-go/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Idempotency is an autogenerated mock type for the Idempotency type
+type Idempotency struct {
+	mock.Mock
+}
+
+// Begin provides a mock function with given fields: key, fingerprint
+func (_m *Idempotency) Begin(key string, fingerprint string) (*models.IdempotentRequest, error) {
+	ret := _m.Called(key, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Begin")
+	}
+
+	var r0 *models.IdempotentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*models.IdempotentRequest, error)); ok {
+		return rf(key, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *models.IdempotentRequest); ok {
+		r0 = rf(key, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(key, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Complete provides a mock function with given fields: key, status, header, body
+func (_m *Idempotency) Complete(key string, status int, header map[string][]string, body []byte) error {
+	ret := _m.Called(key, status, header, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, map[string][]string, []byte) error); ok {
+		r0 = rf(key, status, header, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIdempotency creates a new instance of Idempotency. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotency(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Idempotency {
+	mock := &Idempotency{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
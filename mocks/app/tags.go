@@ -0,0 +1,197 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	dto "github.com/AjxGnx/contacts-go/internal/domain/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/AjxGnx/contacts-go/internal/domain/models"
+)
+
+// Tags is an autogenerated mock type for the Tags type
+type Tags struct {
+	mock.Mock
+}
+
+// Assign provides a mock function with given fields: origin, assignment
+func (_m *Tags) Assign(origin models.Origin, assignment dto.TagAssignment) error {
+	ret := _m.Called(origin, assignment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Assign")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Origin, dto.TagAssignment) error); ok {
+		r0 = rf(origin, assignment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: tag
+func (_m *Tags) Create(tag dto.Tag) (models.Tag, error) {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(dto.Tag) (models.Tag, error)); ok {
+		return rf(tag)
+	}
+	if rf, ok := ret.Get(0).(func(dto.Tag) models.Tag); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Get(0).(models.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(dto.Tag) error); ok {
+		r1 = rf(tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: origin, id
+func (_m *Tags) Delete(origin models.Origin, id uint) error {
+	ret := _m.Called(origin, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint) error); ok {
+		r0 = rf(origin, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with no fields
+func (_m *Tags) Get() ([]models.Tag, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Tag, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Tag); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *Tags) GetByID(id uint) (models.Tag, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (models.Tag, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) models.Tag); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unassign provides a mock function with given fields: origin, assignment
+func (_m *Tags) Unassign(origin models.Origin, assignment dto.TagAssignment) error {
+	ret := _m.Called(origin, assignment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unassign")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Origin, dto.TagAssignment) error); ok {
+		r0 = rf(origin, assignment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: origin, id, tag
+func (_m *Tags) Update(origin models.Origin, id uint, tag dto.Tag) (models.Tag, error) {
+	ret := _m.Called(origin, id, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 models.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, dto.Tag) (models.Tag, error)); ok {
+		return rf(origin, id, tag)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, dto.Tag) models.Tag); ok {
+		r0 = rf(origin, id, tag)
+	} else {
+		r0 = ret.Get(0).(models.Tag)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, uint, dto.Tag) error); ok {
+		r1 = rf(origin, id, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTags creates a new instance of Tags. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTags(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Tags {
+	mock := &Tags{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	models "github.com/AjxGnx/contacts-go/internal/domain/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Contacts is an autogenerated mock type for the Contacts type
@@ -12,9 +14,39 @@ type Contacts struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: contact
-func (_m *Contacts) Create(contact models.Contact) (models.Contact, error) {
-	ret := _m.Called(contact)
+// Batch provides a mock function with given fields: origin, operations, atomic
+func (_m *Contacts) Batch(origin models.Origin, operations []models.BatchOperation, atomic bool) ([]models.BatchResult, error) {
+	ret := _m.Called(origin, operations, atomic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 []models.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, []models.BatchOperation, bool) ([]models.BatchResult, error)); ok {
+		return rf(origin, operations, atomic)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, []models.BatchOperation, bool) []models.BatchResult); ok {
+		r0 = rf(origin, operations, atomic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, []models.BatchOperation, bool) error); ok {
+		r1 = rf(origin, operations, atomic)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: origin, contact
+func (_m *Contacts) Create(origin models.Origin, contact models.Contact) (models.Contact, error) {
+	ret := _m.Called(origin, contact)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
@@ -22,17 +54,17 @@ func (_m *Contacts) Create(contact models.Contact) (models.Contact, error) {
 
 	var r0 models.Contact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(models.Contact) (models.Contact, error)); ok {
-		return rf(contact)
+	if rf, ok := ret.Get(0).(func(models.Origin, models.Contact) (models.Contact, error)); ok {
+		return rf(origin, contact)
 	}
-	if rf, ok := ret.Get(0).(func(models.Contact) models.Contact); ok {
-		r0 = rf(contact)
+	if rf, ok := ret.Get(0).(func(models.Origin, models.Contact) models.Contact); ok {
+		r0 = rf(origin, contact)
 	} else {
 		r0 = ret.Get(0).(models.Contact)
 	}
 
-	if rf, ok := ret.Get(1).(func(models.Contact) error); ok {
-		r1 = rf(contact)
+	if rf, ok := ret.Get(1).(func(models.Origin, models.Contact) error); ok {
+		r1 = rf(origin, contact)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,17 +72,17 @@ func (_m *Contacts) Create(contact models.Contact) (models.Contact, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: id
-func (_m *Contacts) Delete(id uint) error {
-	ret := _m.Called(id)
+// Delete provides a mock function with given fields: origin, id, version
+func (_m *Contacts) Delete(origin models.Origin, id uint, version uint) error {
+	ret := _m.Called(origin, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, uint) error); ok {
+		r0 = rf(origin, id, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,9 +90,27 @@ func (_m *Contacts) Delete(id uint) error {
 	return r0
 }
 
-// Get provides a mock function with given fields: paginate
-func (_m *Contacts) Get(paginate models.Paginator) (*models.Paginator, error) {
-	ret := _m.Called(paginate)
+// Export provides a mock function with given fields: filter, write
+func (_m *Contacts) Export(filter models.ContactFilter, write func([]models.Contact) error) error {
+	ret := _m.Called(filter, write)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Export")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ContactFilter, func([]models.Contact) error) error); ok {
+		r0 = rf(filter, write)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: paginate, filter
+func (_m *Contacts) Get(paginate models.Paginator, filter models.ContactFilter) (*models.Paginator, error) {
+	ret := _m.Called(paginate, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
@@ -68,19 +118,19 @@ func (_m *Contacts) Get(paginate models.Paginator) (*models.Paginator, error) {
 
 	var r0 *models.Paginator
 	var r1 error
-	if rf, ok := ret.Get(0).(func(models.Paginator) (*models.Paginator, error)); ok {
-		return rf(paginate)
+	if rf, ok := ret.Get(0).(func(models.Paginator, models.ContactFilter) (*models.Paginator, error)); ok {
+		return rf(paginate, filter)
 	}
-	if rf, ok := ret.Get(0).(func(models.Paginator) *models.Paginator); ok {
-		r0 = rf(paginate)
+	if rf, ok := ret.Get(0).(func(models.Paginator, models.ContactFilter) *models.Paginator); ok {
+		r0 = rf(paginate, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Paginator)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(models.Paginator) error); ok {
-		r1 = rf(paginate)
+	if rf, ok := ret.Get(1).(func(models.Paginator, models.ContactFilter) error); ok {
+		r1 = rf(paginate, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -116,9 +166,315 @@ func (_m *Contacts) GetByID(id uint) (models.Contact, error) {
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: id, account
-func (_m *Contacts) Update(id uint, account models.Contact) (models.Contact, error) {
-	ret := _m.Called(id, account)
+// GetByPhoneNumbers provides a mock function with given fields: numbers
+func (_m *Contacts) GetByPhoneNumbers(numbers []string) ([]models.Contact, error) {
+	ret := _m.Called(numbers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPhoneNumbers")
+	}
+
+	var r0 []models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]models.Contact, error)); ok {
+		return rf(numbers)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []models.Contact); ok {
+		r0 = rf(numbers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(numbers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevision provides a mock function with given fields: id, version
+func (_m *Contacts) GetRevision(id uint, version uint) (models.Revision, error) {
+	ret := _m.Called(id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, uint) (models.Revision, error)); ok {
+		return rf(id, version)
+	}
+	if rf, ok := ret.Get(0).(func(uint, uint) models.Revision); ok {
+		r0 = rf(id, version)
+	} else {
+		r0 = ret.Get(0).(models.Revision)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, uint) error); ok {
+		r1 = rf(id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisionAt provides a mock function with given fields: id, at
+func (_m *Contacts) GetRevisionAt(id uint, at time.Time) (models.Revision, error) {
+	ret := _m.Called(id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisionAt")
+	}
+
+	var r0 models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, time.Time) (models.Revision, error)); ok {
+		return rf(id, at)
+	}
+	if rf, ok := ret.Get(0).(func(uint, time.Time) models.Revision); ok {
+		r0 = rf(id, at)
+	} else {
+		r0 = ret.Get(0).(models.Revision)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, time.Time) error); ok {
+		r1 = rf(id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: id, paginate
+func (_m *Contacts) GetRevisions(id uint, paginate models.Paginator) (*models.Paginator, error) {
+	ret := _m.Called(id, paginate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, models.Paginator) (*models.Paginator, error)); ok {
+		return rf(id, paginate)
+	}
+	if rf, ok := ret.Get(0).(func(uint, models.Paginator) *models.Paginator); ok {
+		r0 = rf(id, paginate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, models.Paginator) error); ok {
+		r1 = rf(id, paginate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: paginate
+func (_m *Contacts) GetTrash(paginate models.Paginator) (*models.Paginator, error) {
+	ret := _m.Called(paginate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Paginator) (*models.Paginator, error)); ok {
+		return rf(paginate)
+	}
+	if rf, ok := ret.Get(0).(func(models.Paginator) *models.Paginator); ok {
+		r0 = rf(paginate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Paginator) error); ok {
+		r1 = rf(paginate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: origin, merge
+func (_m *Contacts) Merge(origin models.Origin, merge models.Merge) (models.Contact, error) {
+	ret := _m.Called(origin, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, models.Merge) (models.Contact, error)); ok {
+		return rf(origin, merge)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, models.Merge) models.Contact); ok {
+		r0 = rf(origin, merge)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, models.Merge) error); ok {
+		r1 = rf(origin, merge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purge provides a mock function with given fields: origin, id
+func (_m *Contacts) Purge(origin models.Origin, id uint) error {
+	ret := _m.Called(origin, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Purge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint) error); ok {
+		r0 = rf(origin, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeDeletedBefore provides a mock function with given fields: origin, before
+func (_m *Contacts) PurgeDeletedBefore(origin models.Origin, before time.Time) (int64, error) {
+	ret := _m.Called(origin, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedBefore")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, time.Time) (int64, error)); ok {
+		return rf(origin, before)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, time.Time) int64); ok {
+		r0 = rf(origin, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, time.Time) error); ok {
+		r1 = rf(origin, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: origin, id
+func (_m *Contacts) Restore(origin models.Origin, id uint) (models.Contact, error) {
+	ret := _m.Called(origin, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint) (models.Contact, error)); ok {
+		return rf(origin, id)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, uint) models.Contact); ok {
+		r0 = rf(origin, id)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, uint) error); ok {
+		r1 = rf(origin, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revert provides a mock function with given fields: origin, id, contact, version
+func (_m *Contacts) Revert(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error) {
+	ret := _m.Called(origin, id, contact, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revert")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, models.Contact, uint) (models.Contact, error)); ok {
+		return rf(origin, id, contact, version)
+	}
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, models.Contact, uint) models.Contact); ok {
+		r0 = rf(origin, id, contact, version)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Origin, uint, models.Contact, uint) error); ok {
+		r1 = rf(origin, id, contact, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: paginate, query
+func (_m *Contacts) Search(paginate models.Paginator, query string) (*models.Paginator, error) {
+	ret := _m.Called(paginate, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 *models.Paginator
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Paginator, string) (*models.Paginator, error)); ok {
+		return rf(paginate, query)
+	}
+	if rf, ok := ret.Get(0).(func(models.Paginator, string) *models.Paginator); ok {
+		r0 = rf(paginate, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Paginator)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Paginator, string) error); ok {
+		r1 = rf(paginate, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: origin, id, contact, version
+func (_m *Contacts) Update(origin models.Origin, id uint, contact models.Contact, version uint) (models.Contact, error) {
+	ret := _m.Called(origin, id, contact, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
@@ -126,17 +482,17 @@ func (_m *Contacts) Update(id uint, account models.Contact) (models.Contact, err
 
 	var r0 models.Contact
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint, models.Contact) (models.Contact, error)); ok {
-		return rf(id, account)
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, models.Contact, uint) (models.Contact, error)); ok {
+		return rf(origin, id, contact, version)
 	}
-	if rf, ok := ret.Get(0).(func(uint, models.Contact) models.Contact); ok {
-		r0 = rf(id, account)
+	if rf, ok := ret.Get(0).(func(models.Origin, uint, models.Contact, uint) models.Contact); ok {
+		r0 = rf(origin, id, contact, version)
 	} else {
 		r0 = ret.Get(0).(models.Contact)
 	}
 
-	if rf, ok := ret.Get(1).(func(uint, models.Contact) error); ok {
-		r1 = rf(id, account)
+	if rf, ok := ret.Get(1).(func(models.Origin, uint, models.Contact, uint) error); ok {
+		r1 = rf(origin, id, contact, version)
 	} else {
 		r1 = ret.Error(1)
 	}
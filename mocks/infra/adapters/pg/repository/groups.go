@@ -0,0 +1,225 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/AjxGnx/contacts-go/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Groups is an autogenerated mock type for the Groups type
+type Groups struct {
+	mock.Mock
+}
+
+// AddMembers provides a mock function with given fields: id, contactIDs, groupIDs
+func (_m *Groups) AddMembers(id uint, contactIDs []uint, groupIDs []uint) error {
+	ret := _m.Called(id, contactIDs, groupIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMembers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, []uint, []uint) error); ok {
+		r0 = rf(id, contactIDs, groupIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: group
+func (_m *Groups) Create(group models.Group) (models.Group, error) {
+	ret := _m.Called(group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Group) (models.Group, error)); ok {
+		return rf(group)
+	}
+	if rf, ok := ret.Get(0).(func(models.Group) models.Group); ok {
+		r0 = rf(group)
+	} else {
+		r0 = ret.Get(0).(models.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Group) error); ok {
+		r1 = rf(group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *Groups) Delete(id uint) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with no fields
+func (_m *Groups) Get() ([]models.Group, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 []models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.Group, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.Group); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Group)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: id
+func (_m *Groups) GetByID(id uint) (models.Group, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (models.Group, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) models.Group); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(models.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Members provides a mock function with given fields: id
+func (_m *Groups) Members(id uint) ([]models.Contact, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Members")
+	}
+
+	var r0 []models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]models.Contact, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []models.Contact); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMembers provides a mock function with given fields: id, contactIDs, groupIDs
+func (_m *Groups) RemoveMembers(id uint, contactIDs []uint, groupIDs []uint) error {
+	ret := _m.Called(id, contactIDs, groupIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMembers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, []uint, []uint) error); ok {
+		r0 = rf(id, contactIDs, groupIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: id, group
+func (_m *Groups) Update(id uint, group models.Group) (models.Group, error) {
+	ret := _m.Called(id, group)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 models.Group
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, models.Group) (models.Group, error)); ok {
+		return rf(id, group)
+	}
+	if rf, ok := ret.Get(0).(func(uint, models.Group) models.Group); ok {
+		r0 = rf(id, group)
+	} else {
+		r0 = ret.Get(0).(models.Group)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, models.Group) error); ok {
+		r1 = rf(id, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewGroups creates a new instance of Groups. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGroups(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Groups {
+	mock := &Groups{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,128 @@
+// Code generated by mockery v2.53.7. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/AjxGnx/contacts-go/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Idempotency is an autogenerated mock type for the Idempotency type
+type Idempotency struct {
+	mock.Mock
+}
+
+// Complete provides a mock function with given fields: request
+func (_m *Idempotency) Complete(request models.IdempotentRequest) error {
+	ret := _m.Called(request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.IdempotentRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PurgeExpired provides a mock function with given fields: now
+func (_m *Idempotency) PurgeExpired(now time.Time) (int64, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: key
+func (_m *Idempotency) Release(key string) error {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reserve provides a mock function with given fields: request, now
+func (_m *Idempotency) Reserve(request models.IdempotentRequest, now time.Time) (models.IdempotentRequest, bool, error) {
+	ret := _m.Called(request, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 models.IdempotentRequest
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(models.IdempotentRequest, time.Time) (models.IdempotentRequest, bool, error)); ok {
+		return rf(request, now)
+	}
+	if rf, ok := ret.Get(0).(func(models.IdempotentRequest, time.Time) models.IdempotentRequest); ok {
+		r0 = rf(request, now)
+	} else {
+		r0 = ret.Get(0).(models.IdempotentRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.IdempotentRequest, time.Time) bool); ok {
+		r1 = rf(request, now)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(models.IdempotentRequest, time.Time) error); ok {
+		r2 = rf(request, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewIdempotency creates a new instance of Idempotency. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotency(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Idempotency {
+	mock := &Idempotency{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
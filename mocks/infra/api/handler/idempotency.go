@@ -0,0 +1,48 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	echo "github.com/labstack/echo/v4"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Idempotency is an autogenerated mock type for the Idempotency type
+type Idempotency struct {
+	mock.Mock
+}
+
+// Handle provides a mock function with given fields: next
+func (_m *Idempotency) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	ret := _m.Called(next)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Handle")
+	}
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func(echo.HandlerFunc) echo.HandlerFunc); ok {
+		r0 = rf(next)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// NewIdempotency creates a new instance of Idempotency. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotency(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Idempotency {
+	mock := &Idempotency{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
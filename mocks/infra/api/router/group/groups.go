@@ -0,0 +1,33 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	echo "github.com/labstack/echo/v4"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Groups is an autogenerated mock type for the Groups type
+type Groups struct {
+	mock.Mock
+}
+
+// Resource provides a mock function with given fields: c
+func (_m *Groups) Resource(c *echo.Group) {
+	_m.Called(c)
+}
+
+// NewGroups creates a new instance of Groups. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewGroups(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Groups {
+	mock := &Groups{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
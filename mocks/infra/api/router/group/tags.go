@@ -0,0 +1,33 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	echo "github.com/labstack/echo/v4"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Tags is an autogenerated mock type for the Tags type
+type Tags struct {
+	mock.Mock
+}
+
+// Resource provides a mock function with given fields: c
+func (_m *Tags) Resource(c *echo.Group) {
+	_m.Called(c)
+}
+
+// NewTags creates a new instance of Tags. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTags(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Tags {
+	mock := &Tags{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}